@@ -0,0 +1,298 @@
+// Command getresponse is a command line client for the GetResponse API built on the getresponse package.
+//
+// Credentials are read from the environment:
+//
+//	GETRESPONSE_API_KEY  API key (required)
+//	GETRESPONSE_API_URL  API base URL (default https://api.getresponse.com)
+//	GETRESPONSE_DOMAIN   X-Domain header for GetResponse MAX accounts
+//
+// Usage:
+//
+//	getresponse [-format json|csv] contacts list [-campaign ID] [-email EMAIL] [-page N] [-per-page N]
+//	getresponse [-format json|csv] contacts get CONTACT_ID
+//	getresponse contacts create -email EMAIL -campaign ID [-name NAME]
+//	getresponse contacts delete CONTACT_ID
+//	getresponse [-format json|csv] contacts duplicates
+//	getresponse [-format json|csv] campaigns list [-page N] [-per-page N]
+//	getresponse [-format json|csv] custom-fields list [-page N] [-per-page N]
+//	getresponse [-format json|csv] tags list [-page N] [-per-page N]
+//	getresponse [-format json|csv] newsletters list [-page N] [-per-page N]
+//	getresponse [-timeout D] account backup [-contacts] > archive.json
+//	getresponse [-timeout D] account restore [-contacts] [-dry-run] archive.json
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/backup"
+	"github.com/devimteam/go-getresponse/getresponse/dedupe"
+)
+
+const defaultAPIURL = "https://api.getresponse.com"
+
+var errUsage = errors.New("usage: getresponse [-format json|csv] <contacts|campaigns|custom-fields|tags|newsletters|account> <command> [flags]")
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("getresponse", flag.ContinueOnError)
+	format := fs.String("format", "json", "output format: json or csv")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if fs.NArg() < 2 {
+		return errUsage
+	}
+
+	apiKey := os.Getenv("GETRESPONSE_API_KEY")
+	if apiKey == "" {
+		return errors.New("GETRESPONSE_API_KEY is not set")
+	}
+	apiURL := os.Getenv("GETRESPONSE_API_URL")
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	client := getresponse.NewClient(apiURL, apiKey, os.Getenv("GETRESPONSE_DOMAIN"), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	result, err := dispatch(ctx, client, fs.Arg(0), fs.Arg(1), fs.Args()[2:])
+	if err != nil || result == nil {
+		return err
+	}
+
+	return write(out, *format, result)
+}
+
+func dispatch(ctx context.Context, c getresponse.Client, resource, command string, args []string) (interface{}, error) {
+	switch resource + " " + command {
+	case "contacts list":
+		return listContacts(ctx, c, args)
+	case "contacts get":
+		if len(args) != 1 {
+			return nil, errors.New("usage: getresponse contacts get CONTACT_ID")
+		}
+		res, err := c.Contacts().Get(ctx, &getresponse.GetContactRequest{ID: args[0]})
+		if err != nil {
+			return nil, err
+		}
+		return res.Contact, nil
+	case "contacts create":
+		return nil, createContact(ctx, c, args)
+	case "contacts delete":
+		if len(args) != 1 {
+			return nil, errors.New("usage: getresponse contacts delete CONTACT_ID")
+		}
+		return nil, c.Contacts().Remove(ctx, &getresponse.RemoveContactRequest{ID: args[0]})
+	case "contacts duplicates":
+		return findDuplicates(ctx, c)
+	case "campaigns list":
+		page, perPage, err := pageFlags("campaigns list", args)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.Campaigns().List(ctx, &getresponse.GetCampaignsRequest{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, err
+		}
+		return res.Campaigns, nil
+	case "custom-fields list":
+		page, perPage, err := pageFlags("custom-fields list", args)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.CustomFields().List(ctx, &getresponse.GetCustomFieldsRequest{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, err
+		}
+		return res.CustomFields, nil
+	case "tags list":
+		page, perPage, err := pageFlags("tags list", args)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.Tags().List(ctx, &getresponse.GetTagsRequest{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, err
+		}
+		return res.Tags, nil
+	case "newsletters list":
+		page, perPage, err := pageFlags("newsletters list", args)
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.Newsletters().List(ctx, &getresponse.GetNewslettersRequest{Page: page, PerPage: perPage})
+		if err != nil {
+			return nil, err
+		}
+		return res.Newsletters, nil
+	case "account backup":
+		return backupAccount(ctx, c, args)
+	case "account restore":
+		return restoreAccount(ctx, c, args)
+	}
+
+	return nil, errUsage
+}
+
+func listContacts(ctx context.Context, c getresponse.Client, args []string) (interface{}, error) {
+	fs := flag.NewFlagSet("contacts list", flag.ContinueOnError)
+	campaign := fs.String("campaign", "", "campaign id")
+	email := fs.String("email", "", "email to search for")
+	page := fs.Int("page", 1, "page")
+	perPage := fs.Int("per-page", 100, "results per page")
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]string{}
+	if *campaign != "" {
+		query["campaignId"] = *campaign
+	}
+	if *email != "" {
+		query["email"] = *email
+	}
+	res, err := c.Contacts().List(ctx, &getresponse.GetContactsRequest{QueryHash: query, Page: int32(*page), PerPage: int32(*perPage)})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Contacts, nil
+}
+
+// duplicateRow is a contact on more than one campaign, flattened for spreadsheets
+type duplicateRow struct {
+	Email       string `json:"email"`
+	CampaignIDs string `json:"campaignIds"`
+	ContactIDs  string `json:"contactIds"`
+}
+
+func findDuplicates(ctx context.Context, c getresponse.Client) (interface{}, error) {
+	rows := []duplicateRow{}
+	err := dedupe.Find(ctx, c, func(d dedupe.Duplicate) error {
+		ids := []string{}
+		for _, contact := range d.Contacts {
+			if contact.ContactID != nil {
+				ids = append(ids, *contact.ContactID)
+			}
+		}
+		rows = append(rows, duplicateRow{Email: d.Email, CampaignIDs: strings.Join(d.CampaignIDs(), " "), ContactIDs: strings.Join(ids, " ")})
+		return nil
+	})
+	return rows, err
+}
+
+func createContact(ctx context.Context, c getresponse.Client, args []string) error {
+	fs := flag.NewFlagSet("contacts create", flag.ContinueOnError)
+	email := fs.String("email", "", "email (required)")
+	campaign := fs.String("campaign", "", "campaign id (required)")
+	name := fs.String("name", "", "name")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	req := &getresponse.CreateContactRequest{Email: *email, Campaign: getresponse.Campaign{CampaignID: *campaign}}
+	if *name != "" {
+		req.Name = name
+	}
+	err = req.Validate()
+	if err != nil {
+		return err
+	}
+
+	return c.Contacts().Create(ctx, req)
+}
+
+func backupAccount(ctx context.Context, c getresponse.Client, args []string) (interface{}, error) {
+	fs := flag.NewFlagSet("account backup", flag.ContinueOnError)
+	contacts := fs.Bool("contacts", false, "include contacts")
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &bytes.Buffer{}
+	err = backup.Backup(ctx, c, archive, backup.Options{Contacts: *contacts})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(archive.Bytes()), nil
+}
+
+// restoreSummary is a backup.Report flattened for output
+type restoreSummary struct {
+	Drift           []string `json:"drift"`
+	Created         []string `json:"created"`
+	SegmentsCreated []string `json:"segmentsCreated"`
+	ContactsCreated int      `json:"contactsCreated"`
+	ContactsSkipped int      `json:"contactsSkipped"`
+	Errors          []string `json:"errors"`
+}
+
+func restoreAccount(ctx context.Context, c getresponse.Client, args []string) (interface{}, error) {
+	fs := flag.NewFlagSet("account restore", flag.ContinueOnError)
+	contacts := fs.Bool("contacts", false, "restore contacts")
+	dryRun := fs.Bool("dry-run", false, "only report what is missing")
+	err := fs.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if fs.NArg() != 1 {
+		return nil, errors.New("usage: getresponse account restore [-contacts] [-dry-run] ARCHIVE")
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	report, err := backup.Restore(ctx, c, f, backup.RestoreOptions{Contacts: *contacts, DryRun: *dryRun})
+	if report == nil {
+		return nil, err
+	}
+	summary := restoreSummary{SegmentsCreated: report.SegmentsCreated, ContactsCreated: report.ContactsCreated, ContactsSkipped: report.ContactsSkipped}
+	if report.Config != nil {
+		for _, d := range report.Config.Drift {
+			summary.Drift = append(summary.Drift, d.String())
+		}
+		for _, d := range report.Config.Created {
+			summary.Created = append(summary.Created, d.String())
+		}
+	}
+	for _, e := range report.Errors {
+		summary.Errors = append(summary.Errors, e.Error())
+	}
+	return summary, err
+}
+
+func pageFlags(name string, args []string) (int32, int32, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	page := fs.Int("page", 1, "page")
+	perPage := fs.Int("per-page", 100, "results per page")
+	err := fs.Parse(args)
+	return int32(*page), int32(*perPage), err
+}
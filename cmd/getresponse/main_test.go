@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUnit_Run(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "api-key secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `[{"contactId":"a","email":"a@example.com","tags":[{"tagId":"t"}]},{"contactId":"b","name":"B, Jr.","email":"b@example.com"}]`)
+	}))
+	defer ts.Close()
+	os.Setenv("GETRESPONSE_API_KEY", "secret")
+	os.Setenv("GETRESPONSE_API_URL", ts.URL)
+	defer os.Unsetenv("GETRESPONSE_API_KEY")
+	defer os.Unsetenv("GETRESPONSE_API_URL")
+
+	out := &bytes.Buffer{}
+	err := run([]string{"-format", "csv", "contacts", "list", "-campaign", "123"}, out)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := "contactId,email,name,tags\n" +
+		"a,a@example.com,,\"[{\"\"tagId\"\":\"\"t\"\"}]\"\n" +
+		"b,b@example.com,\"B, Jr.\",\n"
+	if out.String() != expected {
+		t.Fatalf("Actual output (%s) did not match expected (%s)", out.String(), expected)
+	}
+
+	err = run([]string{"contacts", "frobnicate"}, out)
+	if err != errUsage {
+		t.Fatalf("Expected usage error, got (%#v)", err)
+	}
+}
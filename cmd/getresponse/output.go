@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+func write(w io.Writer, format string, v interface{}) error {
+	if format == "csv" {
+		return writeCSV(w, v)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeCSV writes one row per object with a column per top-level field. Nested values are written as JSON.
+func writeCSV(w io.Writer, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	rows := []map[string]interface{}{}
+	if json.Unmarshal(raw, &rows) != nil {
+		row := map[string]interface{}{}
+		err = json.Unmarshal(raw, &row)
+		if err != nil {
+			return fmt.Errorf("cannot write %T as csv", v)
+		}
+		rows = append(rows, row)
+	}
+
+	columns := []string{}
+	seen := map[string]bool{}
+	for _, row := range rows {
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	cw := csv.NewWriter(w)
+	err = cw.Write(columns)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = csvValue(row[col])
+		}
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64, bool:
+		return fmt.Sprint(t)
+	}
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
@@ -0,0 +1,117 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AccountTime is a time sent by the API, in UTC and in the time zone of the account
+type AccountTime struct {
+	UTC   time.Time
+	Local time.Time // in the account's time zone with WithAccountTimeZone, in UTC otherwise
+}
+
+// WithAccountTimeZone makes the client read the account's time zone once, on first use, and use it for the times
+// it returns and the days it filters: statistics dates, which the API sends without a zone, are read as account
+// times, GetListSize counts its From and To days in it, and the Local of AccountTime fields is set in it. Date
+// filters built with AccountLocation, as the watch, export and deliverability packages do, share the zone read.
+func WithAccountTimeZone() Option {
+	return func(g *getResponseClient) {
+		g.accountTimeZone = true
+	}
+}
+
+// accountZone caches the time zone of the account, which does not change while a client is in use
+type accountZone struct {
+	flight flight
+	mu     sync.Mutex
+	loc    *time.Location
+}
+
+func (g *getResponseClient) AccountLocation(ctx context.Context) (*time.Location, error) {
+	g.zone.mu.Lock()
+	loc := g.zone.loc
+	g.zone.mu.Unlock()
+	if loc != nil {
+		return loc, nil
+	}
+
+	err := g.zone.flight.do(ctx, func() error {
+		loc, err := g.fetchAccountLocation(ctx)
+		if err != nil {
+			return err
+		}
+		g.zone.mu.Lock()
+		g.zone.loc = loc
+		g.zone.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.zone.mu.Lock()
+	defer g.zone.mu.Unlock()
+	return g.zone.loc, nil
+}
+
+func (g *getResponseClient) fetchAccountLocation(ctx context.Context) (*time.Location, error) {
+	account, _, err := doGet[struct {
+		TimeZone struct {
+			Name   string `json:"name"`
+			Offset string `json:"offset"`
+		} `json:"timeZone"`
+	}](withOperation(ctx, OpGetAccount), g, "/v3/accounts", nil)
+	if err != nil {
+		return nil, err
+	}
+	tz := account.TimeZone
+	if loc, err := time.LoadLocation(tz.Name); err == nil && tz.Name != "" {
+		return loc, nil
+	}
+	// without the zone database, the current offset is the best approximation
+	offset, err := time.Parse("-07:00", tz.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q (%q)", ErrCouldNotUnmarshal, tz.Name, tz.Offset)
+	}
+	_, seconds := offset.Zone()
+	return time.FixedZone(tz.Name, seconds), nil
+}
+
+// location returns the zone the client reads and filters times in: the account's with WithAccountTimeZone, UTC
+// otherwise
+func (g *getResponseClient) location(ctx context.Context) (*time.Location, error) {
+	if !g.accountTimeZone {
+		return time.UTC, nil
+	}
+	return g.AccountLocation(ctx)
+}
+
+// accountTime reads a timestamp of the API, nil when it is empty or unreadable
+func accountTime(s *string, loc *time.Location) *AccountTime {
+	if s == nil || *s == "" {
+		return nil
+	}
+	t, err := time.Parse(sendOnLayout, *s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, *s)
+		if err != nil {
+			return nil
+		}
+	}
+	return &AccountTime{UTC: t.UTC(), Local: t.In(loc)}
+}
+
+// localizeNewsletters sets the AccountTime fields of newsletters
+func (g *getResponseClient) localizeNewsletters(ctx context.Context, newsletters ...*Newsletter) error {
+	loc, err := g.location(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range newsletters {
+		n.CreatedOnTime = accountTime(n.CreatedOn, loc)
+		n.SendOnTime = accountTime(n.SendOn, loc)
+	}
+	return nil
+}
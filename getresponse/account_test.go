@@ -0,0 +1,89 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_AccountLocation(t *testing.T) {
+	var calls int32
+	zone := `{"name":"Europe/Warsaw","offset":"+01:00"}`
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, `{"accountId":"a","timeZone":%s}`, zone)
+	})
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		loc, err := c.AccountLocation(context.Background())
+		if err != nil || loc.String() != "Europe/Warsaw" {
+			t.Fatalf("Unexpected location (%v, %#v)", loc, err)
+		}
+	}
+	loc, err := AccountLocation(context.Background(), c)
+	if err != nil || loc.String() != "Europe/Warsaw" {
+		t.Fatalf("Unexpected location (%v, %#v)", loc, err)
+	}
+	if calls != 1 {
+		t.Fatalf("Actual calls (%d) did not match expected (%d)", calls, 1)
+	}
+
+	zone = `{"name":"Unknown/Zone","offset":"-03:30"}`
+	loc, err = c.With().AccountLocation(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if _, offset := time.Date(2020, 1, 1, 0, 0, 0, 0, loc).Zone(); offset != -(3*3600 + 1800) {
+		t.Fatalf("Actual offset (%d) did not match expected (%d)", offset, -(3*3600 + 1800))
+	}
+}
+
+func TestUnit_AccountTimeZone(t *testing.T) {
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/accounts":
+			fmt.Fprint(w, `{"timeZone":{"name":"Pacific/Honolulu","offset":"-10:00"}}`)
+		case "/v3/newsletters":
+			fmt.Fprint(w, `[{"newsletterId":"n","sendOn":"2020-01-02T03:00:00+0000"}]`)
+		case "/v3/campaigns/statistics/list-size":
+			if r.URL.Query().Get("query[createdOn][from]") != "2020-01-01" {
+				t.Errorf("Unexpected query (%v)", r.URL.Query())
+			}
+			fmt.Fprint(w, `[{"createdOn":"2020-01-01","totalSubscribers":"5"}]`)
+		default:
+			t.Errorf("Unexpected request (%s)", r.URL.Path)
+		}
+	})
+	defer ts.Close()
+	ctx := context.Background()
+	honolulu := c.With(WithAccountTimeZone())
+
+	res, err := honolulu.Newsletters().List(ctx, &GetNewslettersRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	sendOn := res.Newsletters[0].SendOnTime
+	if sendOn == nil || sendOn.UTC.Hour() != 3 || sendOn.Local.Hour() != 17 || sendOn.Local.Day() != 1 {
+		t.Fatalf("Unexpected send time (%#v)", sendOn)
+	}
+	res, err = c.Newsletters().List(ctx, &GetNewslettersRequest{})
+	if err != nil || res.Newsletters[0].SendOnTime.Local.Hour() != 3 {
+		t.Fatalf("Expected UTC times without the account time zone (%#v, %#v)", res, err)
+	}
+
+	series, err := honolulu.Campaigns().ListSize(ctx, &GetListSizeRequest{
+		CampaignIDs: []string{"c"},
+		From:        time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	if len(series.Points) != 1 || !series.Points[0].Date.Equal(expected) {
+		t.Fatalf("Actual points (%v) did not match expected date (%v)", series.Points, expected)
+	}
+}
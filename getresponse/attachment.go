@@ -0,0 +1,70 @@
+package getresponse
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// MaxAttachmentsSize is the total size of the files attached to one message GetResponse accepts
+const MaxAttachmentsSize = 400 * 1024
+
+// Attachment is a file attached to a newsletter or transactional email
+type Attachment struct {
+	FileName string `json:"fileName"`
+	MimeType string `json:"mimeType"`
+	Content  string `json:"content"` // base64 encoded
+}
+
+// NewAttachment reads r and encodes it while reading, stopping with a *ValidationError once more than
+// MaxAttachmentsSize bytes were read. The mime type is guessed from the file name when empty.
+func NewAttachment(fileName, mimeType string, r io.Reader) (*Attachment, error) {
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(fileName))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	b := &strings.Builder{}
+	enc := base64.NewEncoder(base64.StdEncoding, b)
+	n, err := io.Copy(enc, io.LimitReader(r, MaxAttachmentsSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > MaxAttachmentsSize {
+		return nil, &ValidationError{Field: "attachments", Message: fmt.Sprintf("%s is larger than %d bytes", fileName, MaxAttachmentsSize)}
+	}
+	err = enc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Attachment{FileName: fileName, MimeType: mimeType, Content: b.String()}, nil
+}
+
+// Size returns the decoded size of the file
+func (a *Attachment) Size() int {
+	return base64.StdEncoding.DecodedLen(len(a.Content)) - strings.Count(a.Content, "=")
+}
+
+// validateAttachments checks each attachment is complete and that together they fit in MaxAttachmentsSize
+func validateAttachments(attachments []Attachment) error {
+	total := 0
+	for _, a := range attachments {
+		if a.FileName == "" {
+			return &ValidationError{Field: "attachments.fileName", Message: "is required"}
+		}
+		if a.MimeType == "" {
+			return &ValidationError{Field: "attachments.mimeType", Message: "is required"}
+		}
+		total += a.Size()
+	}
+	if total > MaxAttachmentsSize {
+		return &ValidationError{Field: "attachments", Message: fmt.Sprintf("are larger than %d bytes in total", MaxAttachmentsSize)}
+	}
+	return nil
+}
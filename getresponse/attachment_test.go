@@ -0,0 +1,128 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUnit_NewAttachment(t *testing.T) {
+
+	type testcase struct {
+		name             string
+		fileName         string
+		mimeType         string
+		content          []byte
+		expectedMimeType string
+		expectErr        bool
+	}
+
+	testcases := []testcase{
+		{
+			name:             "guesses the mime type",
+			fileName:         "invoice.pdf",
+			content:          []byte("%PDF-1.4"),
+			expectedMimeType: "application/pdf",
+		},
+		{
+			name:             "keeps the given mime type",
+			fileName:         "data",
+			mimeType:         "text/csv",
+			content:          []byte("a,b\n1,2\n"),
+			expectedMimeType: "text/csv",
+		},
+		{
+			name:             "unknown type",
+			fileName:         "blob",
+			content:          []byte{0, 1, 2},
+			expectedMimeType: "application/octet-stream",
+		},
+		{
+			name:      "too large",
+			fileName:  "big.bin",
+			content:   make([]byte, MaxAttachmentsSize+1),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := NewAttachment(tc.fileName, tc.mimeType, bytes.NewReader(tc.content))
+			if tc.expectErr {
+				vErr := &ValidationError{}
+				if !errors.As(err, &vErr) {
+					t.Fatalf("Expected a validation error, got (%#v)", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if a.MimeType != tc.expectedMimeType {
+				t.Fatalf("Actual mime type (%s) did not match expected (%s)", a.MimeType, tc.expectedMimeType)
+			}
+			decoded, _ := base64.StdEncoding.DecodeString(a.Content)
+			if !bytes.Equal(decoded, tc.content) || a.Size() != len(tc.content) {
+				t.Fatalf("Unexpected content (%s, size %d)", a.Content, a.Size())
+			}
+		})
+	}
+}
+
+func TestUnit_SendTransactionalEmail(t *testing.T) {
+	sent := SendTransactionalEmailRequest{}
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/transactional-emails" {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"transactionalEmailId":"te1"}`)
+	}))
+	defer ts.Close()
+
+	a, err := NewAttachment("hello.txt", "", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	req := &SendTransactionalEmailRequest{
+		FromField:   FromFieldRef{FromFieldID: "f1"},
+		Recipients:  TransactionalRecipients{To: TransactionalRecipient{Email: "foo@example.com"}},
+		Subject:     "Your receipt",
+		Content:     NewsletterContent{Plain: "Thanks"},
+		Attachments: []Attachment{*a},
+	}
+	res, err := c.SendTransactionalEmail(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if res.TransactionalEmailID != "te1" || len(sent.Attachments) != 1 || sent.Attachments[0].Content != "aGVsbG8=" {
+		t.Fatalf("Unexpected result (%#v, %#v)", res, sent)
+	}
+
+	big := Attachment{FileName: "big.bin", MimeType: "application/octet-stream", Content: strings.Repeat("A", MaxAttachmentsSize/3*4)}
+	req.Attachments = []Attachment{*a, big}
+	sent = SendTransactionalEmailRequest{}
+	vErr := &ValidationError{}
+	if _, err = c.SendTransactionalEmail(context.Background(), req); !errors.As(err, &vErr) || sent.Subject != "" {
+		t.Fatalf("Expected the total size to be validated before sending, got (%#v)", err)
+	}
+
+	newsletter := &CreateNewsletterRequest{
+		Subject:      "Hello",
+		Campaign:     Campaign{CampaignID: "c1"},
+		FromField:    FromFieldRef{FromFieldID: "f1"},
+		Content:      NewsletterContent{Plain: "Hi"},
+		SendSettings: SendSettings{SelectedCampaigns: []string{"c1"}},
+		Attachments:  []Attachment{*a, big},
+	}
+	if _, err = c.Newsletters().Create(context.Background(), newsletter); !errors.As(err, &vErr) {
+		t.Fatalf("Expected the total size to be validated before sending, got (%#v)", err)
+	}
+}
@@ -0,0 +1,111 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// AuditEntry describes a successful create, update or delete
+type AuditEntry struct {
+	Operation Operation // empty for requests sent with Do, Get or Post
+	Method    string
+	Path      string
+	// ResourceID is the id of the changed resource: taken from the path, or from the response of a create. It is
+	// empty when a create does not return the resource, as contact creation does.
+	ResourceID string
+	// Actor is who made the change, see ContextWithActor
+	Actor         string
+	CorrelationID string
+	// Changes are the JSON names of the fields sent, e.g. "name" or "customFieldValues". Previous values are not
+	// fetched, so these tell what was written rather than what differed. Empty for deletes.
+	Changes []string
+}
+
+// AuditHook is called after every successful write, not in dry run mode
+type AuditHook func(ctx context.Context, e AuditEntry)
+
+// WithAuditHook registers a hook receiving the changes made through the client, e.g. to keep an audit trail
+func WithAuditHook(h AuditHook) Option {
+	return func(g *getResponseClient) {
+		g.auditHook = h
+	}
+}
+
+type actorKey struct{}
+
+// ContextWithActor returns a copy of ctx naming who makes the requests, reported to the audit hook
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by ContextWithActor
+func ActorFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok && actor != ""
+}
+
+// audit reports a successful write to the hook
+func (g *getResponseClient) audit(ctx context.Context, method, path string, body, ret []byte) error {
+	if g.auditHook == nil || g.dryRun || isRead(method, path) {
+		return nil
+	}
+	e := AuditEntry{Operation: operationFromContext(ctx), Method: method, Path: path}
+	e.Actor, _ = ActorFromContext(ctx)
+	e.CorrelationID, _ = CorrelationIDFromContext(ctx)
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && isVersionSegment(segments[0]) {
+		segments = segments[1:]
+	}
+	switch {
+	case len(segments) > 1:
+		e.ResourceID = segments[1]
+	case len(segments) == 1:
+		e.ResourceID = createdID(segments[0], ret)
+	}
+
+	if method != http.MethodDelete {
+		e.Changes = bodyFields(body)
+	}
+	return g.callHook(ctx, "audit", func() {
+		g.auditHook(ctx, e)
+	})
+}
+
+// createdID reads the id of a created resource from the response, e.g. "tagId" for tags or "customFieldId" for
+// custom-fields
+func createdID(resource string, ret []byte) string {
+	fields := map[string]json.RawMessage{}
+	if json.Unmarshal(ret, &fields) != nil {
+		return ""
+	}
+	words := strings.Split(strings.TrimSuffix(resource, "s"), "-")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	id := ""
+	json.Unmarshal(fields[strings.Join(words, "")+"Id"], &id)
+	return id
+}
+
+// bodyFields returns the top-level field names of a JSON object, sorted
+func bodyFields(body []byte) []string {
+	fields := map[string]json.RawMessage{}
+	if json.Unmarshal(body, &fields) != nil {
+		return nil
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,62 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUnit_AuditHook(t *testing.T) {
+	var entries []AuditEntry
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/tags":
+			fmt.Fprint(w, `{"tagId":"t1","name":"vip"}`)
+		case "/v3/contacts/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":1000,"message":"Validation error"}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+	c = NewClient(ts.URL, "", "", nil, WithAuditHook(func(ctx context.Context, e AuditEntry) {
+		entries = append(entries, e)
+	}))
+	ctx := ContextWithCorrelationID(ContextWithActor(context.Background(), "alice@example.com"), "req-1")
+
+	name := "Alice"
+	_, err := c.Tags().Create(ctx, &CreateTagRequest{Name: "vip"})
+	if err == nil {
+		_, err = c.Contacts().Update(ctx, &UpdateContactRequest{ID: "a", NewData: Contact{Name: &name}, Clear: []string{"note"}})
+	}
+	if err == nil {
+		err = c.Contacts().Remove(ctx, &RemoveContactRequest{ID: "a"})
+	}
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	_, err = c.Contacts().Update(ctx, &UpdateContactRequest{ID: "bad", NewData: Contact{Name: &name}})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+	_, err = c.Contacts().Get(ctx, &GetContactRequest{ID: "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []AuditEntry{
+		{Operation: OpCreateTag, Method: http.MethodPost, Path: "/v3/tags", ResourceID: "t1", Changes: []string{"name"}},
+		{Operation: OpUpdateContact, Method: http.MethodPost, Path: "/v3/contacts/a", ResourceID: "a", Changes: []string{"name", "note"}},
+		{Operation: OpRemoveContact, Method: http.MethodDelete, Path: "/v3/contacts/a", ResourceID: "a"},
+	}
+	for i := range expected {
+		expected[i].Actor = "alice@example.com"
+		expected[i].CorrelationID = "req-1"
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("Actual entries (%+v) did not match expected (%+v)", entries, expected)
+	}
+}
@@ -0,0 +1,371 @@
+// Package backup snapshots the configuration of an account, and optionally its contacts, into a single JSON archive
+// and restores it, for disaster recovery:
+//
+//	err := backup.Backup(ctx, client, f, backup.Options{Contacts: true})
+//	...
+//	report, err := backup.Restore(ctx, otherClient, f, backup.RestoreOptions{Contacts: true})
+//
+// Restore matches resources by name, so it can run against the same account or a fresh one: campaigns, custom
+// fields, tags and from fields are created with the reconcile package, and contacts reference them by name.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/reconcile"
+)
+
+// ArchiveVersion is the version of the archive format written by Backup
+const ArchiveVersion = 1
+
+const listPerPage = 100
+
+// Archive is the content of a backup
+type Archive struct {
+	Version      int                                 `json:"version"`
+	CreatedAt    time.Time                           `json:"createdAt"`
+	Campaigns    []getresponse.Campaign              `json:"campaigns"`
+	CustomFields []getresponse.CustomFieldDefinition `json:"customFields"`
+	Tags         []getresponse.Tag                   `json:"tags"`
+	FromFields   []getresponse.FromField             `json:"fromFields"`
+	// Segments are the saved contact searches as the API returns them, conditions included
+	Segments []json.RawMessage     `json:"segments"`
+	Contacts []getresponse.Contact `json:"contacts,omitempty"`
+}
+
+// Options controls Backup
+type Options struct {
+	// Contacts also archives the contacts, fetched one by one to include their custom fields and tags
+	Contacts bool
+}
+
+// Backup writes an archive of the account to w
+func Backup(ctx context.Context, c getresponse.Client, w io.Writer, opts Options) error {
+	a := &Archive{Version: ArchiveVersion, CreatedAt: time.Now().UTC()}
+	steps := []func(context.Context, getresponse.Client, *Archive) error{
+		backupCampaigns,
+		backupCustomFields,
+		backupTags,
+		backupFromFields,
+		backupSegments,
+	}
+	if opts.Contacts {
+		steps = append(steps, backupContacts)
+	}
+	for _, step := range steps {
+		err := step(ctx, c, a)
+		if err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(a)
+}
+
+func backupCampaigns(ctx context.Context, c getresponse.Client, a *Archive) error {
+	return forEachPage(func(page int32) (int, error) {
+		res, err := c.Campaigns().List(ctx, &getresponse.GetCampaignsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		a.Campaigns = append(a.Campaigns, res.Campaigns...)
+		return len(res.Campaigns), nil
+	})
+}
+
+func backupCustomFields(ctx context.Context, c getresponse.Client, a *Archive) error {
+	return forEachPage(func(page int32) (int, error) {
+		res, err := c.CustomFields().List(ctx, &getresponse.GetCustomFieldsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		a.CustomFields = append(a.CustomFields, res.CustomFields...)
+		return len(res.CustomFields), nil
+	})
+}
+
+func backupTags(ctx context.Context, c getresponse.Client, a *Archive) error {
+	return forEachPage(func(page int32) (int, error) {
+		res, err := c.Tags().List(ctx, &getresponse.GetTagsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		a.Tags = append(a.Tags, res.Tags...)
+		return len(res.Tags), nil
+	})
+}
+
+func backupFromFields(ctx context.Context, c getresponse.Client, a *Archive) error {
+	return forEachPage(func(page int32) (int, error) {
+		res, err := c.FromFields().List(ctx, &getresponse.GetFromFieldsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		a.FromFields = append(a.FromFields, res.FromFields...)
+		return len(res.FromFields), nil
+	})
+}
+
+func backupSegments(ctx context.Context, c getresponse.Client, a *Archive) error {
+	return forEachPage(func(page int32) (int, error) {
+		segments, err := getresponse.Get[[]getresponse.SearchContact](ctx, c, "/v3/search-contacts", pageQuery(page))
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range segments {
+			// the list leaves out the conditions
+			full, err := getresponse.Get[json.RawMessage](ctx, c, "/v3/search-contacts/"+url.PathEscape(s.SearchContactID), nil)
+			if err != nil {
+				return 0, err
+			}
+			a.Segments = append(a.Segments, full)
+		}
+		return len(segments), nil
+	})
+}
+
+func backupContacts(ctx context.Context, c getresponse.Client, a *Archive) error {
+	return forEachPage(func(page int32) (int, error) {
+		res, err := c.Contacts().List(ctx, &getresponse.GetContactsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		for _, contact := range res.Contacts {
+			// the list leaves out custom fields and tags
+			full, err := c.Contacts().Get(ctx, &getresponse.GetContactRequest{ID: *contact.ContactID})
+			if err != nil {
+				return 0, err
+			}
+			a.Contacts = append(a.Contacts, full.Contact)
+		}
+		return len(res.Contacts), nil
+	})
+}
+
+// RestoreOptions controls Restore
+type RestoreOptions struct {
+	// DryRun only reports what is missing without creating anything
+	DryRun bool
+	// Contacts also restores the archived contacts. Contacts already in their campaign are skipped.
+	Contacts bool
+}
+
+// Report tells what Restore did
+type Report struct {
+	Config          *reconcile.Report
+	SegmentsCreated []string
+	ContactsCreated int
+	ContactsSkipped int
+	// Errors are the segments and contacts that could not be restored, which do not stop the others
+	Errors []error
+}
+
+// Restore recreates the resources of the archive read from r that the account is missing. Configuration is restored
+// first, so contacts can reference it. Segment conditions are restored as archived and may reference campaign ids of
+// the original account.
+func Restore(ctx context.Context, c getresponse.Client, r io.Reader, opts RestoreOptions) (*Report, error) {
+	a := &Archive{}
+	err := json.NewDecoder(r).Decode(a)
+	if err != nil {
+		return nil, err
+	}
+	if a.Version != ArchiveVersion {
+		return nil, fmt.Errorf("backup: unsupported archive version %d", a.Version)
+	}
+
+	report := &Report{}
+	report.Config, err = reconcile.Apply(ctx, c, spec(a), reconcile.Options{DryRun: opts.DryRun})
+	if err != nil {
+		return report, err
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	err = restoreSegments(ctx, c, a, report)
+	if err != nil {
+		return report, err
+	}
+	if opts.Contacts {
+		err = restoreContacts(ctx, c, a, report)
+	}
+	return report, err
+}
+
+func spec(a *Archive) reconcile.Spec {
+	s := reconcile.Spec{}
+	for _, campaign := range a.Campaigns {
+		cs := reconcile.CampaignSpec{Name: campaign.Name}
+		if campaign.LanguageCode != nil {
+			cs.LanguageCode = *campaign.LanguageCode
+		}
+		s.Campaigns = append(s.Campaigns, cs)
+	}
+	for _, f := range a.CustomFields {
+		hidden, _ := strconv.ParseBool(f.Hidden)
+		s.CustomFields = append(s.CustomFields, reconcile.CustomFieldSpec{Name: f.Name, Type: f.Type, Hidden: hidden, Values: f.Values})
+	}
+	for _, t := range a.Tags {
+		s.Tags = append(s.Tags, reconcile.TagSpec{Name: t.Name})
+	}
+	for _, f := range a.FromFields {
+		s.FromFields = append(s.FromFields, reconcile.FromFieldSpec{Name: f.Name, Email: f.Email})
+	}
+	return s
+}
+
+// segment is the part of an archived segment Restore reads, the rest is sent back as is
+type segment struct {
+	Name string `json:"name"`
+}
+
+func restoreSegments(ctx context.Context, c getresponse.Client, a *Archive, report *Report) error {
+	if len(a.Segments) == 0 {
+		return nil
+	}
+	existing := map[string]bool{}
+	err := forEachPage(func(page int32) (int, error) {
+		segments, err := getresponse.Get[[]getresponse.SearchContact](ctx, c, "/v3/search-contacts", pageQuery(page))
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range segments {
+			existing[s.Name] = true
+		}
+		return len(segments), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range a.Segments {
+		s := segment{}
+		body := map[string]json.RawMessage{}
+		err := json.Unmarshal(raw, &s)
+		if err == nil {
+			err = json.Unmarshal(raw, &body)
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("backup: segment: %w", err))
+			continue
+		}
+		if existing[s.Name] {
+			continue
+		}
+		for _, key := range []string{"searchContactId", "href", "createdOn"} {
+			delete(body, key)
+		}
+		_, err = getresponse.Post[map[string]json.RawMessage, json.RawMessage](ctx, c, "/v3/search-contacts", body)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("backup: segment %q: %w", s.Name, err))
+			continue
+		}
+		report.SegmentsCreated = append(report.SegmentsCreated, s.Name)
+	}
+	return nil
+}
+
+func restoreContacts(ctx context.Context, c getresponse.Client, a *Archive, report *Report) error {
+	campaigns := map[string]string{}
+	for _, campaign := range a.Campaigns {
+		campaigns[campaign.CampaignID] = campaign.Name
+	}
+	fields := map[string]string{}
+	for _, f := range a.CustomFields {
+		fields[f.CustomFieldID] = f.Name
+	}
+	tags := map[string]string{}
+	for _, t := range a.Tags {
+		tags[t.TagID] = t.Name
+	}
+
+	for _, contact := range a.Contacts {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		email := ""
+		if contact.Email != nil {
+			email = *contact.Email
+		}
+
+		err := restoreContact(ctx, c, contact, campaigns, fields, tags)
+		apiErr := &getresponse.APIError{}
+		switch {
+		case errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusConflict:
+			report.ContactsSkipped++
+		case err != nil:
+			report.Errors = append(report.Errors, fmt.Errorf("backup: contact %s: %w", email, err))
+		default:
+			report.ContactsCreated++
+		}
+	}
+	return nil
+}
+
+func restoreContact(ctx context.Context, c getresponse.Client, contact getresponse.Contact, campaigns, fields, tags map[string]string) error {
+	if contact.Email == nil || contact.Campaign == nil {
+		return fmt.Errorf("archived contact has no email or campaign")
+	}
+	campaignName, ok := campaigns[contact.Campaign.CampaignID]
+	if !ok {
+		return fmt.Errorf("campaign %s is not in the archive", contact.Campaign.CampaignID)
+	}
+	campaignID, err := c.Campaigns().ResolveByName(ctx, campaignName)
+	if err != nil {
+		return err
+	}
+
+	req := &getresponse.CreateContactRequest{
+		Name:       contact.Name,
+		Email:      *contact.Email,
+		DayOfCycle: contact.DayOfCycle,
+		Campaign:   getresponse.Campaign{CampaignID: campaignID},
+		IPAddress:  contact.IPAddress,
+	}
+	for _, f := range contact.CustomFieldValues {
+		name, ok := fields[f.CustomFieldID]
+		if !ok {
+			return fmt.Errorf("custom field %s is not in the archive", f.CustomFieldID)
+		}
+		if req.NamedCustomFields == nil {
+			req.NamedCustomFields = map[string]interface{}{}
+		}
+		req.NamedCustomFields[name] = f.Value
+	}
+	for _, t := range contact.Tags {
+		name, ok := tags[t.TagID]
+		if !ok {
+			return fmt.Errorf("tag %s is not in the archive", t.TagID)
+		}
+		req.TagNames = append(req.TagNames, name)
+	}
+	return c.Contacts().Create(ctx, req)
+}
+
+// forEachPage calls fetch with increasing page numbers until a page comes back short
+func forEachPage(fetch func(page int32) (int, error)) error {
+	for page := int32(1); ; page++ {
+		n, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if n < listPerPage {
+			return nil
+		}
+	}
+}
+
+func pageQuery(page int32) url.Values {
+	return url.Values{"page": {strconv.Itoa(int(page))}, "perPage": {strconv.Itoa(listPerPage)}}
+}
@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_BackupRestore(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/campaigns":
+			fmt.Fprint(w, `[{"campaignId":"c1","name":"newsletter","languageCode":"EN"}]`)
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"f1","name":"plan","type":"text","hidden":"false"}]`)
+		case "/v3/tags":
+			fmt.Fprint(w, `[{"tagId":"t1","name":"vip"}]`)
+		case "/v3/from-fields":
+			fmt.Fprint(w, `[{"fromFieldId":"ff1","name":"Support","email":"help@example.com"}]`)
+		case "/v3/search-contacts":
+			fmt.Fprint(w, `[{"searchContactId":"s1","name":"active"}]`)
+		case "/v3/search-contacts/s1":
+			fmt.Fprint(w, `{"searchContactId":"s1","name":"active","subscribersType":["subscribed"],"sectionLogicOperator":"or","section":[]}`)
+		case "/v3/contacts":
+			fmt.Fprint(w, `[{"contactId":"a","email":"a@example.com"}]`)
+		case "/v3/contacts/a":
+			fmt.Fprint(w, `{"contactId":"a","email":"a@example.com","campaign":{"campaignId":"c1"},
+				"customFieldValues":[{"customFieldId":"f1","value":["pro"]}],"tags":[{"tagId":"t1"}]}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer source.Close()
+
+	archive := &bytes.Buffer{}
+	err := Backup(context.Background(), getresponse.NewClient(source.URL, "", "", nil), archive, Options{Contacts: true})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	// a fresh account, where everything created gets a new id
+	var writes []string
+	created := map[string]bool{}
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := ioutil.ReadAll(r.Body)
+			writes = append(writes, fmt.Sprintf("%s %s", r.URL.Path, body))
+			created[r.URL.Path] = true
+			switch r.URL.Path {
+			case "/v3/tags":
+				fmt.Fprint(w, `{"tagId":"T1","name":"vip"}`)
+			default:
+				fmt.Fprint(w, `{}`)
+			}
+			return
+		}
+		switch {
+		case r.URL.Path == "/v3/campaigns" && created["/v3/campaigns"]:
+			fmt.Fprint(w, `[{"campaignId":"C1","name":"newsletter"}]`)
+		case r.URL.Path == "/v3/custom-fields" && created["/v3/custom-fields"]:
+			fmt.Fprint(w, `[{"customFieldId":"F1","name":"plan","fieldType":"text","valueType":"string"}]`)
+		case r.URL.Path == "/v3/tags" && created["/v3/tags"]:
+			fmt.Fprint(w, `[{"tagId":"T1","name":"vip"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer target.Close()
+
+	report, err := Restore(context.Background(), getresponse.NewClient(target.URL, "", "", nil), archive, RestoreOptions{Contacts: true})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(report.Errors) != 0 || report.ContactsCreated != 1 || len(report.SegmentsCreated) != 1 || len(report.Config.Created) != 4 {
+		t.Fatalf("Unexpected report (%#v)", report)
+	}
+
+	sort.Strings(writes)
+	expected := []string{
+		`/v3/campaigns {"name":"newsletter","languageCode":"EN"}`,
+		`/v3/contacts {"email":"a@example.com","campaign":{"campaignId":"C1"},"customFieldValues":[{"customFieldId":"F1","value":["pro"]}],"tags":[{"tagId":"T1"}]}`,
+		`/v3/custom-fields {"name":"plan","type":"text","hidden":false,"values":[]}`,
+		`/v3/from-fields {"name":"Support","email":"help@example.com"}`,
+		`/v3/search-contacts {"name":"active","section":[],"sectionLogicOperator":"or","subscribersType":["subscribed"]}`,
+		`/v3/tags {"name":"vip"}`,
+	}
+	if fmt.Sprint(writes) != fmt.Sprint(expected) {
+		t.Fatalf("Actual writes (%v) did not match expected (%v)", writes, expected)
+	}
+}
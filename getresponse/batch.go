@@ -0,0 +1,79 @@
+package getresponse
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// GetByIDsOptions controls ContactsClient.GetByIDs
+type GetByIDsOptions struct {
+	// Concurrency is the number of lookups in flight at once, 4 when not set. The client's throttler still paces
+	// them, so raising it only helps when the request budget is not the bottleneck.
+	Concurrency int
+	// Fields limits the returned fields of every contact, see GetContactRequest.Fields
+	Fields []string
+}
+
+func (g *getResponseClient) getContactsByIDs(ctx context.Context, ids []string, opts *GetByIDsOptions) (map[string]Contact, map[string]error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = &GetByIDsOptions{}
+	}
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = defaultBatchConcurrency
+	}
+
+	queue := make(chan string)
+	contacts := make(map[string]Contact, len(ids))
+	var errs map[string]error
+	var mu sync.Mutex
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range queue {
+				res, err := g.getContact(ctx, &GetContactRequest{ID: id, Fields: opts.Fields})
+
+				mu.Lock()
+				if err != nil {
+					if errs == nil {
+						errs = map[string]error{}
+					}
+					errs[id] = err
+				} else {
+					contacts[id] = res.Contact
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if ctx.Err() != nil {
+			// not sent, report why
+			mu.Lock()
+			if errs == nil {
+				errs = map[string]error{}
+			}
+			errs[id] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+		queue <- id
+	}
+	close(queue)
+	wg.Wait()
+
+	return contacts, errs
+}
@@ -0,0 +1,85 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_GetContactsByIDs(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	requested := map[string]int{}
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/v3/contacts/")
+		mu.Lock()
+		requested[id]++
+		mu.Unlock()
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"httpStatus":404,"code":1013,"message":"Contact not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"contactId":%q,"name":"Contact %s"}`, id, id)
+	}))
+	defer ts.Close()
+
+	ids := []string{"a", "b", "c", "missing", "d", "a", "bad id"}
+	contacts, errs := c.Contacts().GetByIDs(context.Background(), ids, &GetByIDsOptions{Concurrency: 2})
+
+	if len(contacts) != 4 {
+		t.Fatalf("Actual contacts (%d) did not match expected (%d)", len(contacts), 4)
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if contacts[id].ContactID == nil || *contacts[id].ContactID != id {
+			t.Fatalf("Actual contact %s (%#v) did not match expected", id, contacts[id])
+		}
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Actual errors (%v) did not match expected", errs)
+	}
+	apiErr := &APIError{}
+	if !errors.As(errs["missing"], &apiErr) || apiErr.ErrorCode != ErrResourceNotFound {
+		t.Fatalf("Expected a not found error, got (%#v)", errs["missing"])
+	}
+	vErr := &ValidationError{}
+	if !errors.As(errs["bad id"], &vErr) {
+		t.Fatalf("Expected a ValidationError, got (%#v)", errs["bad id"])
+	}
+	if requested["a"] != 1 {
+		t.Fatalf("Duplicate id was requested %d times", requested["a"])
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Fatalf("Actual concurrency (%d) exceeded the limit (%d)", max, 2)
+	}
+}
+
+func TestUnit_GetContactsByIDsCancelled(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contactId":"a"}`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	contacts, errs := c.Contacts().GetByIDs(ctx, []string{"a", "b"}, nil)
+	if len(contacts) != 0 || len(errs) != 2 || !errors.Is(errs["a"], context.Canceled) || !errors.Is(errs["b"], context.Canceled) {
+		t.Fatalf("Unexpected result (%v, %v)", contacts, errs)
+	}
+}
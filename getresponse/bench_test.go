@@ -0,0 +1,82 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchContacts is a page of contacts as the API returns it
+var benchContacts = func() string {
+	contacts := make([]string, 100)
+	for i := range contacts {
+		contacts[i] = fmt.Sprintf(`{"contactId":"c%d","name":"Contact %d","email":"c%d@example.com","origin":"api",`+
+			`"campaign":{"campaignId":"V","name":"list"},"tags":[{"tagId":"t1"}],"customFieldValues":[`+
+			`{"customFieldId":"f1","name":"interests","value":["go","mail"]}]}`, i, i, i)
+	}
+	return "[" + strings.Join(contacts, ",") + "]"
+}()
+
+func benchClient(b *testing.B, body string) Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+		io.WriteString(w, body)
+	}))
+	b.Cleanup(ts.Close)
+	return NewClient(ts.URL, "key", "", nil, WithoutThrottling())
+}
+
+func BenchmarkCreateContact(b *testing.B) {
+	c := benchClient(b, `{}`)
+	request := &CreateContactRequest{
+		Email:        "john@example.com",
+		Name:         makeStringPtr("John"),
+		Campaign:     Campaign{CampaignID: "V"},
+		CustomFields: []CustomField{{CustomFieldID: "f1", Value: []string{"go"}}},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := c.Contacts().Create(context.Background(), request)
+		if err != nil {
+			b.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+}
+
+func BenchmarkGetContacts(b *testing.B) {
+	c := benchClient(b, benchContacts)
+	request := &GetContactsRequest{
+		QueryHash: map[string]string{"campaignId": "V"},
+		Sort:      []SortTerm{Descending("createdOn")},
+		Page:      1,
+		PerPage:   100,
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		res, err := c.Contacts().List(context.Background(), request)
+		if err != nil || len(res.Contacts) != 100 {
+			b.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+}
+
+func BenchmarkListQuery(b *testing.B) {
+	queryHash := map[string]string{"campaignId": "V", "name": "john", "origin": "api"}
+	r := TimeRange{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}
+	r.SetHash(queryHash, "createdOn")
+	fields := ContactFields(FieldContactID, FieldEmail, FieldChangedOn)
+	sort := []SortTerm{Descending("changedOn"), Ascending("email")}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encodeQuery(listQuery(queryHash, nil, sort, fields, int32(i%1000)+1, 1000))
+	}
+}
@@ -0,0 +1,148 @@
+package getresponse
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// maxPooledBuffer is the largest buffer kept for reuse, larger ones are left to the garbage collector so a single
+// big response does not pin its memory
+const maxPooledBuffer = 1 << 20
+
+// bufferPool holds the buffers responses are read into
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// readAll reads r to the end like io.ReadAll, but through a pooled buffer, so the result is allocated once at its
+// final size instead of growing with the reads
+func readAll(r io.Reader) ([]byte, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			buf.Reset()
+			bufferPool.Put(buf)
+		}
+	}()
+	_, err := buf.ReadFrom(r)
+	ret := make([]byte, buf.Len())
+	copy(ret, buf.Bytes())
+	return ret, err
+}
+
+// errBodyConsumed is returned when a streamed body that cannot be rewound would be sent again
+var errBodyConsumed = errors.New("request body was already sent and cannot be replayed")
+
+// requestBody is the body of a request, opened again for every attempt. Bodies read from an io.Reader are streamed
+// rather than buffered; those that cannot be rewound are sent once, without retries.
+type requestBody struct {
+	open       func() (io.ReadCloser, error)
+	length     int64  // -1 when unknown
+	data       []byte // the whole body when it is in memory, for dry runs, debug dumps and audits
+	replayable bool
+}
+
+// bytesBody is a body held in memory, nil for no body
+func bytesBody(data []byte) *requestBody {
+	if len(data) == 0 {
+		return nil
+	}
+	return &requestBody{
+		open: func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+		length:     int64(len(data)),
+		data:       data,
+		replayable: true,
+	}
+}
+
+// readerBody streams r. Readers that can seek are rewound to where they were for retries.
+func readerBody(r io.Reader) (*requestBody, error) {
+	switch v := r.(type) {
+	case nil:
+		return nil, nil
+	case *bytes.Buffer:
+		return bytesBody(v.Bytes()), nil
+	case io.ReadSeeker:
+		start, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+		_, err = v.Seek(start, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+		s := &sharedSeeker{r: v}
+		return &requestBody{
+			open: func() (io.ReadCloser, error) {
+				return s.rewind(start)
+			},
+			length:     end - start,
+			replayable: true,
+		}, nil
+	}
+
+	var once sync.Once
+	return &requestBody{
+		open: func() (io.ReadCloser, error) {
+			rc := io.ReadCloser(nil)
+			once.Do(func() {
+				rc = ioutil.NopCloser(r)
+			})
+			if rc == nil {
+				return nil, errBodyConsumed
+			}
+			return rc, nil
+		},
+		length: -1,
+	}, nil
+}
+
+// sharedSeeker hands out the readers of the attempts at a request over the same io.ReadSeeker. The transport may
+// still be writing an attempt's body after its response arrived, so once the reader is rewound for the next attempt
+// the previous ones fail instead of moving the offset under it.
+type sharedSeeker struct {
+	mu         sync.Mutex
+	r          io.ReadSeeker
+	generation int
+}
+
+func (s *sharedSeeker) rewind(start int64) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	_, err := s.r.Seek(start, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	return &seekerAttempt{s: s, generation: s.generation}, nil
+}
+
+// seekerAttempt is the body of one attempt, see sharedSeeker
+type seekerAttempt struct {
+	s          *sharedSeeker
+	generation int
+}
+
+func (a *seekerAttempt) Read(p []byte) (int, error) {
+	a.s.mu.Lock()
+	defer a.s.mu.Unlock()
+	if a.generation != a.s.generation {
+		return 0, errBodyConsumed
+	}
+	return a.s.r.Read(p)
+}
+
+func (a *seekerAttempt) Close() error {
+	return nil
+}
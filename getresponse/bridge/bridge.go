@@ -0,0 +1,294 @@
+// Package bridge applies contact events consumed from a message bus such as Kafka or NATS to GetResponse. The bus
+// is plugged in through Consumer and the events are turned into writes by a Mapper, so only those two are left to
+// each integration:
+//
+//	b := &bridge.Bridge{Client: client, Consumer: consumer, Map: func(d bridge.Delivery) ([]scheduler.Op, error) {
+//		...decode d.Value into the app's event and return the writes it calls for
+//	}}
+//	err := b.Run(ctx)
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/scheduler"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultDedupWindow = 10000
+	defaultIdleWait    = time.Second
+)
+
+// Delivery is a message received from the bus
+type Delivery struct {
+	// Key identifies the message, redeliveries of it are skipped while it is within the dedup window
+	Key   string
+	Value []byte
+	// Handle is whatever the consumer needs to commit the delivery, e.g. a partition offset
+	Handle interface{}
+}
+
+// Consumer reads messages from the bus. Deliveries are committed once their writes were applied, or failed for good,
+// so a crash leads to redelivery rather than loss.
+type Consumer interface {
+	// Fetch returns up to max deliveries, or none when nothing is available
+	Fetch(ctx context.Context, max int) ([]Delivery, error)
+	// Commit acknowledges processed deliveries
+	Commit(ctx context.Context, deliveries []Delivery) error
+}
+
+// Mapper turns a delivery into the writes it calls for, none to skip it. Op IDs are set by the bridge.
+type Mapper func(d Delivery) ([]scheduler.Op, error)
+
+// Bridge moves events from a Consumer to a client in batches. A Bridge must not be run from several goroutines.
+type Bridge struct {
+	Client   getresponse.Client
+	Consumer Consumer
+	Map      Mapper
+	// BatchSize is how many deliveries are fetched and applied together, 100 when not set
+	BatchSize int
+	// Concurrency is the number of writes of a batch sent at once, 1 when not set
+	Concurrency int
+	// DedupWindow is how many recent delivery keys are remembered to skip redeliveries, 10000 when not set
+	DedupWindow int
+	// IdleWait is how long to wait before fetching again when the bus had nothing, 1s when not set
+	IdleWait time.Duration
+	// OnError, when set, is told about deliveries that could not be mapped or whose writes failed for good. They are
+	// committed anyway, so a poison message does not block the bridge. It may be called concurrently when
+	// Concurrency is above 1.
+	OnError func(d Delivery, err error)
+
+	seen  map[string]bool
+	order []string
+}
+
+// Run processes batches until ctx is done or the consumer fails, and returns that error
+func (b *Bridge) Run(ctx context.Context) error {
+	for {
+		n, err := b.ProcessBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		idle := b.IdleWait
+		if idle <= 0 {
+			idle = defaultIdleWait
+		}
+		timer := time.NewTimer(idle)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ProcessBatch fetches one batch, applies its writes and commits it. It returns the number of deliveries fetched.
+//
+// Within a batch a write made pointless by a later one is not sent: an update whose fields a later update of the
+// same contact sets as well, or any write to a contact removed later in the batch, is dropped.
+func (b *Bridge) ProcessBatch(ctx context.Context) (int, error) {
+	size := b.BatchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	deliveries, err := b.Consumer.Fetch(ctx, size)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	var ops []scheduler.Op
+	source := map[string]Delivery{}
+	inBatch := map[string]bool{}
+	for n, d := range deliveries {
+		if b.seen[d.Key] || inBatch[d.Key] {
+			continue
+		}
+		if d.Key != "" {
+			inBatch[d.Key] = true
+		}
+		mapped, err := b.Map(d)
+		if err == nil {
+			for i := range mapped {
+				// keys may be empty, the position in the batch is not
+				mapped[i].ID = fmt.Sprintf("%d/%d", n, i)
+			}
+			err = validate(mapped)
+		}
+		if err != nil {
+			// a delivery is applied whole or not at all
+			b.report(d, fmt.Errorf("bridge: mapping %s: %w", d.Key, err))
+			continue
+		}
+		for _, o := range mapped {
+			source[o.ID] = d
+			ops = append(ops, o)
+		}
+	}
+
+	s := &scheduler.Scheduler{
+		Client:      b.Client,
+		Store:       &scheduler.MemoryStore{},
+		Concurrency: b.Concurrency,
+		OnResult: func(o scheduler.Op, err error) {
+			if err != nil {
+				b.report(source[o.ID], err)
+			}
+		},
+	}
+	for _, o := range coalesce(ops) {
+		err := s.Enqueue(o)
+		if err != nil {
+			b.report(source[o.ID], err)
+		}
+	}
+	_, err = s.Run(ctx)
+	if err != nil {
+		return len(deliveries), err
+	}
+
+	err = b.Consumer.Commit(ctx, deliveries)
+	if err != nil {
+		return len(deliveries), err
+	}
+	for _, d := range deliveries {
+		b.remember(d.Key)
+	}
+	return len(deliveries), nil
+}
+
+func validate(ops []scheduler.Op) error {
+	for _, o := range ops {
+		err := o.Validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// coalesce drops the writes made pointless by later ones of the same batch, keeping the order of the rest
+func coalesce(ops []scheduler.Op) []scheduler.Op {
+	removed := map[string]int{}
+	for i, o := range ops {
+		if o.Kind == scheduler.KindRemove {
+			removed[o.ContactID] = i
+		}
+	}
+
+	var ret []scheduler.Op
+	for i, o := range ops {
+		if superseded(ops, i) {
+			continue
+		}
+		if r, ok := removed[o.ContactID]; ok && o.ContactID != "" && r > i {
+			continue
+		}
+		ret = append(ret, o)
+	}
+	return ret
+}
+
+// superseded reports whether a later write of the batch to the same target sets every field ops[i] sets
+func superseded(ops []scheduler.Op, i int) bool {
+	key, fields := target(ops[i])
+	for _, o := range ops[i+1:] {
+		k, f := target(o)
+		if k == key && covers(f, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// target identifies what a write applies to and the fields it sets, nil when it applies to the whole target.
+// Updates set their masked fields, or the fields of their data when they have no mask.
+func target(o scheduler.Op) (string, []string) {
+	switch o.Kind {
+	case scheduler.KindCreate:
+		return string(o.Kind) + " " + strings.ToLower(o.Create.Email) + " " + o.Create.Campaign.CampaignID, nil
+	case scheduler.KindUpdate:
+		if len(o.Fields) > 0 {
+			return string(o.Kind) + " " + o.ContactID, o.Fields
+		}
+		return string(o.Kind) + " " + o.ContactID, dataFields(o.Data)
+	case scheduler.KindUpdateCustomFields:
+		ids := make([]string, 0, len(o.CustomFields))
+		for _, f := range o.CustomFields {
+			ids = append(ids, f.CustomFieldID)
+		}
+		return string(o.Kind) + " " + o.ContactID, ids
+	}
+	return string(o.Kind) + " " + o.ContactID, nil
+}
+
+// dataFields returns the names of the fields an update without a mask sends
+func dataFields(data *getresponse.Contact) []string {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	fields := map[string]json.RawMessage{}
+	if json.Unmarshal(body, &fields) != nil {
+		return nil
+	}
+	ret := make([]string, 0, len(fields))
+	for field := range fields {
+		ret = append(ret, field)
+	}
+	return ret
+}
+
+// covers reports whether the fields of a later write include all the fields of an earlier one
+func covers(later, earlier []string) bool {
+	set := map[string]bool{}
+	for _, f := range later {
+		set[f] = true
+	}
+	for _, f := range earlier {
+		if !set[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// remember adds a committed key to the dedup window, keys are only remembered once committed so deliveries of a
+// failed batch are not skipped when they come again
+func (b *Bridge) remember(key string) {
+	if key == "" {
+		return
+	}
+	if b.seen == nil {
+		b.seen = map[string]bool{}
+	}
+	if b.seen[key] {
+		return
+	}
+
+	window := b.DedupWindow
+	if window <= 0 {
+		window = defaultDedupWindow
+	}
+	b.seen[key] = true
+	b.order = append(b.order, key)
+	if len(b.order) > window {
+		delete(b.seen, b.order[0])
+		b.order = b.order[1:]
+	}
+}
+
+func (b *Bridge) report(d Delivery, err error) {
+	if b.OnError != nil {
+		b.OnError(d, err)
+	}
+}
@@ -0,0 +1,155 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/scheduler"
+)
+
+type fakeConsumer struct {
+	batches   [][]Delivery
+	committed []string
+}
+
+func (c *fakeConsumer) Fetch(ctx context.Context, max int) ([]Delivery, error) {
+	if len(c.batches) == 0 {
+		return nil, nil
+	}
+	b := c.batches[0]
+	c.batches = c.batches[1:]
+	return b, nil
+}
+
+func (c *fakeConsumer) Commit(ctx context.Context, deliveries []Delivery) error {
+	for _, d := range deliveries {
+		c.committed = append(c.committed, d.Key)
+	}
+	return nil
+}
+
+func TestUnit_Bridge(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		if r.Method == http.MethodPost && r.URL.Path != "/v3/contacts" {
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+
+	event := func(key, value string) Delivery {
+		return Delivery{Key: key, Value: []byte(value)}
+	}
+	consumer := &fakeConsumer{batches: [][]Delivery{
+		{
+			event("e1", `{"kind":"create","create":{"email":"a@example.com","campaign":{"campaignId":"c"}}}`),
+			event("e2", `{"kind":"update","contactId":"c1","data":{"name":"X"}}`),
+			event("e3", `{"kind":"update","contactId":"c2","data":{"name":"Z"}}`),
+			event("e4", `{"kind":"update","contactId":"c1","data":{"name":"Y"}}`),
+			event("e5", `not json`),
+			event("e6", `{"kind":"remove","contactId":"c2"}`),
+			event("e4", `{"kind":"update","contactId":"c1","data":{"name":"Y"}}`),
+			event("e7", `{"kind":"update","contactId":"c3","data":{"name":"A"}}`),
+			event("e8", `{"kind":"update","contactId":"c3","data":{"note":"B"}}`),
+		},
+		{
+			event("e1", `{"kind":"create","create":{"email":"a@example.com","campaign":{"campaignId":"c"}}}`),
+		},
+	}}
+
+	var failed []string
+	b := &Bridge{
+		Client:   getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Consumer: consumer,
+		Map: func(d Delivery) ([]scheduler.Op, error) {
+			o := scheduler.Op{}
+			err := json.Unmarshal(d.Value, &o)
+			return []scheduler.Op{o}, err
+		},
+		OnError: func(d Delivery, err error) {
+			failed = append(failed, d.Key)
+		},
+	}
+
+	for _, expected := range []int{9, 1, 0} {
+		n, err := b.ProcessBatch(context.Background())
+		if err != nil || n != expected {
+			t.Fatalf("Unexpected batch result (%d, %#v)", n, err)
+		}
+	}
+
+	expectedCalls := []string{
+		`POST /v3/contacts {"email":"a@example.com","campaign":{"campaignId":"c"}}`,
+		`POST /v3/contacts/c1 {"name":"Y"}`,
+		`DELETE /v3/contacts/c2 `,
+		`POST /v3/contacts/c3 {"name":"A"}`,
+		`POST /v3/contacts/c3 {"note":"B"}`,
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(expectedCalls) {
+		t.Fatalf("Actual calls (%q) did not match expected (%q)", calls, expectedCalls)
+	}
+	if fmt.Sprint(failed) != "[e5]" {
+		t.Fatalf("Actual failures (%v) did not match expected ([e5])", failed)
+	}
+	if len(consumer.committed) != 10 {
+		t.Fatalf("Expected every delivery to be committed, got (%v)", consumer.committed)
+	}
+}
+
+func TestUnit_BridgeRejectsWholeDeliveries(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/v3/contacts/bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":1000,"message":"Validation error"}`)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	// deliveries without keys, each mapped to several writes
+	consumer := &fakeConsumer{batches: [][]Delivery{{
+		{Value: []byte(`[{"kind":"remove","contactId":"a"},{"kind":"remove"}]`)},
+		{Value: []byte(`[{"kind":"remove","contactId":"b"}]`)},
+		{Value: []byte(`[{"kind":"update","contactId":"bad","data":{"name":"X"}}]`)},
+	}}}
+	var failed []string
+	b := &Bridge{
+		Client:   getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Consumer: consumer,
+		Map: func(d Delivery) ([]scheduler.Op, error) {
+			var ops []scheduler.Op
+			err := json.Unmarshal(d.Value, &ops)
+			return ops, err
+		},
+		OnError: func(d Delivery, err error) {
+			failed = append(failed, string(d.Value))
+		},
+	}
+
+	_, err := b.ProcessBatch(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expectedCalls := []string{"DELETE /v3/contacts/b", "POST /v3/contacts/bad"}
+	if fmt.Sprint(calls) != fmt.Sprint(expectedCalls) {
+		t.Fatalf("Actual calls (%q) did not match expected (%q)", calls, expectedCalls)
+	}
+	expectedFailed := []string{
+		`[{"kind":"remove","contactId":"a"},{"kind":"remove"}]`,
+		`[{"kind":"update","contactId":"bad","data":{"name":"X"}}]`,
+	}
+	if fmt.Sprint(failed) != fmt.Sprint(expectedFailed) {
+		t.Fatalf("Actual failures (%q) did not match expected (%q)", failed, expectedFailed)
+	}
+}
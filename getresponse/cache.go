@@ -0,0 +1,236 @@
+package getresponse
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTTL        = 5 * time.Minute
+	defaultCacheRevalidate = time.Hour
+)
+
+// Cache stores encoded responses of read endpoints, see WithCache. Keys start with the request path followed by "?",
+// so everything cached for a resource can be dropped with DeletePrefix.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	DeletePrefix(prefix string)
+}
+
+// cacheableOperations are served from the cache when one is configured
+var cacheableOperations = map[Operation]bool{
+	OpGetContact:      true,
+	OpGetCampaigns:    true,
+	OpGetCustomFields: true,
+}
+
+// WithCache enables read-through caching of GetContact, GetCampaigns and GetCustomFields for ttl (default 5m).
+// Cached contacts are invalidated when they are updated or deleted through the client.
+func WithCache(c Cache, ttl time.Duration) Option {
+	return func(g *getResponseClient) {
+		if ttl <= 0 {
+			ttl = defaultCacheTTL
+		}
+		g.cache = c
+		g.cacheTTL = ttl
+	}
+}
+
+// WithCacheRevalidation keeps cached responses carrying an ETag or Last-Modified header for window after they
+// expire, and revalidates them with If-None-Match/If-Modified-Since instead of downloading them again. The default
+// window is 1h, 0 disables conditional requests.
+func WithCacheRevalidation(window time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.cacheRevalidate = window
+	}
+}
+
+// cachedResponse is what gets stored in the Cache
+type cachedResponse struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FreshUntil   time.Time `json:"freshUntil"`
+}
+
+func (c *cachedResponse) fresh() bool {
+	return time.Now().Before(c.FreshUntil)
+}
+
+// conditionalHeaders returns the headers revalidating a stale response, if it can be revalidated
+func (c *cachedResponse) conditionalHeaders() http.Header {
+	if c == nil || (c.ETag == "" && c.LastModified == "") {
+		return nil
+	}
+	h := http.Header{}
+	if c.ETag != "" {
+		h.Set("If-None-Match", c.ETag)
+	}
+	if c.LastModified != "" {
+		h.Set("If-Modified-Since", c.LastModified)
+	}
+	return h
+}
+
+// revalidated returns the headers of a 304 response completed with the validators of the cached response
+func (c *cachedResponse) revalidated(h http.Header) http.Header {
+	ret := http.Header{}
+	ret.Set("ETag", c.ETag)
+	ret.Set("Last-Modified", c.LastModified)
+	for _, name := range []string{"ETag", "Last-Modified"} {
+		if v := h.Get(name); v != "" {
+			ret.Set(name, v)
+		}
+	}
+	return ret
+}
+
+type noCacheKey struct{}
+
+// withoutCache makes reads skip cached responses, for callers that must see the current state
+func withoutCache(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+func cacheKey(path string, query url.Values) string {
+	return path + "?" + encodeQuery(query)
+}
+
+func (g *getResponseClient) cacheGet(ctx context.Context, path string, query url.Values) *cachedResponse {
+	if g.cache == nil || !cacheableOperations[operationFromContext(ctx)] || ctx.Value(noCacheKey{}) != nil {
+		return nil
+	}
+	raw, ok := g.cache.Get(cacheKey(path, query))
+	if !ok {
+		return nil
+	}
+	c := &cachedResponse{}
+	if json.Unmarshal(raw, c) != nil {
+		return nil
+	}
+	return c
+}
+
+func (g *getResponseClient) cacheSet(ctx context.Context, path string, query url.Values, body []byte, h http.Header) {
+	if g.cache == nil || !cacheableOperations[operationFromContext(ctx)] {
+		return
+	}
+	c := &cachedResponse{
+		Body:       body,
+		FreshUntil: time.Now().Add(g.cacheTTL),
+	}
+	ttl := g.cacheTTL
+	if g.cacheRevalidate > 0 {
+		c.ETag = h.Get("ETag")
+		c.LastModified = h.Get("Last-Modified")
+		if c.ETag != "" || c.LastModified != "" {
+			ttl += g.cacheRevalidate
+		}
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	g.cache.Set(cacheKey(path, query), raw, ttl)
+}
+
+// invalidate drops every cached response for the resource at path
+func (g *getResponseClient) invalidate(path string) {
+	if g.cache != nil {
+		g.cache.DeletePrefix(path + "?")
+	}
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// LRUCache is an in-memory Cache holding at most a fixed number of entries. It is safe for concurrent use.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewLRUCache returns an LRUCache holding up to size entries
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if time.Now().After(e.expires) {
+		l.remove(el)
+		return nil, false
+	}
+	l.order.MoveToFront(el)
+	return e.value, true
+}
+
+func (l *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.entries[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.value = value
+		e.expires = time.Now().Add(ttl)
+		l.order.MoveToFront(el)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expires: time.Now().Add(ttl)})
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *LRUCache) DeletePrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, el := range l.entries {
+		if strings.HasPrefix(key, prefix) {
+			l.remove(el)
+		}
+	}
+}
+
+// Len returns the number of entries, including expired ones not evicted yet
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRUCache) remove(el *list.Element) {
+	l.order.Remove(el)
+	delete(l.entries, el.Value.(*lruEntry).key)
+}
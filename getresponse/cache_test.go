@@ -0,0 +1,109 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnit_Cache(t *testing.T) {
+	calls := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method+" "+r.URL.Path]++
+		switch r.URL.Path {
+		case "/v3/campaigns":
+			fmt.Fprint(w, `[{"campaignId":"123","name":"list"}]`)
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"abc","name":"age","valueType":"number"}]`)
+		default:
+			fmt.Fprintf(w, `{"contactId":"foo","name":"name %d"}`, calls[r.Method+" "+r.URL.Path])
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil, WithCache(NewLRUCache(10), time.Minute))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ret, err := c.GetContact(ctx, &GetContactRequest{ID: "foo"})
+		if err != nil || *ret.Contact.Name != "name 1" {
+			t.Fatalf("Unexpected response (%#v, %#v)", ret, err)
+		}
+		campaigns, err := c.GetCampaigns(ctx, &GetCampaignsRequest{})
+		if err != nil || campaigns.Campaigns[0].CampaignID != "123" {
+			t.Fatalf("Unexpected response (%#v, %#v)", campaigns, err)
+		}
+		fields, err := c.GetCustomFields(ctx, &GetCustomFieldsRequest{})
+		if err != nil || fields.CustomFields[0].Name != "age" {
+			t.Fatalf("Unexpected response (%#v, %#v)", fields, err)
+		}
+	}
+	if calls["GET /v3/contacts/foo"] != 1 || calls["GET /v3/campaigns"] != 1 || calls["GET /v3/custom-fields"] != 1 {
+		t.Fatalf("Expected a single call per resource, got (%v)", calls)
+	}
+
+	_, err := c.UpdateContact(ctx, &UpdateContactRequest{ID: "foo", NewData: Contact{Name: makeStringPtr("new")}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	ret, err := c.GetContact(ctx, &GetContactRequest{ID: "foo"})
+	if err != nil || *ret.Contact.Name != "name 2" || calls["GET /v3/contacts/foo"] != 2 {
+		t.Fatalf("Expected the update to invalidate the cached contact (%#v, %#v)", ret, err)
+	}
+}
+
+func TestUnit_LRUCache(t *testing.T) {
+	l := NewLRUCache(2)
+	l.Set("a?", []byte("a"), time.Minute)
+	l.Set("b?", []byte("b"), time.Minute)
+	l.Get("a?")
+	l.Set("c?", []byte("c"), time.Minute)
+
+	if _, ok := l.Get("b?"); ok {
+		t.Fatalf("Expected the least recently used entry to be evicted")
+	}
+	if v, ok := l.Get("a?"); !ok || string(v) != "a" {
+		t.Fatalf("Expected entry a to be kept")
+	}
+
+	l.Set("d?", []byte("d"), -time.Second)
+	if _, ok := l.Get("d?"); ok {
+		t.Fatalf("Expected expired entry to be missing")
+	}
+
+	l.DeletePrefix("a?")
+	if _, ok := l.Get("a?"); ok || l.Len() != 0 {
+		t.Fatalf("Expected entry a to be deleted, %d entries left", l.Len())
+	}
+}
+
+func TestUnit_CacheRevalidation(t *testing.T) {
+	calls, notModified := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"contactId":"foo","name":"foobar"}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil, WithCache(NewLRUCache(10), time.Millisecond))
+	for i := 0; i < 3; i++ {
+		ret, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+		if err != nil || *ret.Contact.Name != "foobar" {
+			t.Fatalf("Unexpected response (%#v, %#v)", ret, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if calls != 3 || notModified != 2 {
+		t.Fatalf("Expected stale entries to be revalidated, got %d calls and %d not modified", calls, notModified)
+	}
+}
@@ -0,0 +1,106 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const campaignNamesTTL = 10 * time.Minute
+
+// UnknownCampaignError is returned when no campaign has the name
+type UnknownCampaignError struct {
+	Name string
+}
+
+func (e *UnknownCampaignError) Error() string {
+	return fmt.Sprintf("campaign %q does not exist", e.Name)
+}
+
+// AmbiguousCampaignError is returned when the name matches several campaigns, which only differ in case
+type AmbiguousCampaignError struct {
+	Name        string
+	CampaignIDs []string
+}
+
+func (e *AmbiguousCampaignError) Error() string {
+	return fmt.Sprintf("campaign name %q matches campaigns %s", e.Name, strings.Join(e.CampaignIDs, ", "))
+}
+
+// campaignNames caches the campaigns of the account by name
+type campaignNames struct {
+	flight   flight
+	mu       sync.Mutex
+	byName   map[string][]Campaign // lower case name -> campaigns
+	loadedAt time.Time
+}
+
+func (g *getResponseClient) resolveCampaignByName(ctx context.Context, name string) (string, error) {
+	g.campaignNames.mu.Lock()
+	stale := g.campaignNames.byName == nil || time.Since(g.campaignNames.loadedAt) > campaignNamesTTL
+	matches := g.campaignNames.byName[strings.ToLower(name)]
+	g.campaignNames.mu.Unlock()
+
+	if stale || len(matches) == 0 {
+		// the campaign may have been created or renamed since the campaigns were listed
+		err := g.campaignNames.flight.do(ctx, func() error {
+			byName, err := g.listCampaignNames(ctx)
+			if err != nil {
+				return err
+			}
+			g.campaignNames.mu.Lock()
+			g.campaignNames.byName, g.campaignNames.loadedAt = byName, time.Now()
+			g.campaignNames.mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		g.campaignNames.mu.Lock()
+		matches = g.campaignNames.byName[strings.ToLower(name)]
+		g.campaignNames.mu.Unlock()
+	}
+
+	return matchCampaignName(name, matches)
+}
+
+func (g *getResponseClient) listCampaignNames(ctx context.Context) (map[string][]Campaign, error) {
+	byName := map[string][]Campaign{}
+	req := &GetCampaignsRequest{Fields: []string{"campaignId", "name"}, Page: 1, PerPage: 100}
+	for {
+		res, err := g.getCampaigns(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range res.Campaigns {
+			key := strings.ToLower(c.Name)
+			byName[key] = append(byName[key], Campaign{CampaignID: c.CampaignID, Name: c.Name})
+		}
+		if len(res.Campaigns) < int(req.PerPage) {
+			return byName, nil
+		}
+		req.Page++
+	}
+}
+
+// matchCampaignName picks the campaign named exactly name, or the only one matching it regardless of case
+func matchCampaignName(name string, matches []Campaign) (string, error) {
+	for _, c := range matches {
+		if c.Name == name {
+			return c.CampaignID, nil
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", &UnknownCampaignError{Name: name}
+	case 1:
+		return matches[0].CampaignID, nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, c := range matches {
+		ids = append(ids, c.CampaignID)
+	}
+	return "", &AmbiguousCampaignError{Name: name, CampaignIDs: ids}
+}
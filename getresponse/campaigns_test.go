@@ -0,0 +1,55 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_ResolveCampaignByName(t *testing.T) {
+	lists := 0
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		if r.URL.Query().Get("fields") != "campaignId,name" {
+			t.Errorf("Unexpected query (%v)", r.URL.Query())
+		}
+		fmt.Fprint(w, `[{"campaignId":"a","name":"Newsletter"},{"campaignId":"b","name":"newsletter"},{"campaignId":"c","name":"Customers"}]`)
+	}))
+	defer ts.Close()
+
+	type testcase struct {
+		name     string
+		expected string
+		err      error
+	}
+	for _, tc := range []testcase{
+		{name: "Newsletter", expected: "a"},
+		{name: "newsletter", expected: "b"},
+		{name: "customers", expected: "c"},
+		{name: "NEWSLETTER", err: &AmbiguousCampaignError{}},
+		{name: "Leads", err: &UnknownCampaignError{}},
+	} {
+		id, err := c.Campaigns().ResolveByName(context.Background(), tc.name)
+		switch tc.err.(type) {
+		case nil:
+			if err != nil || id != tc.expected {
+				t.Fatalf("Actual id (%v, %#v) did not match expected (%v) for %s", id, err, tc.expected, tc.name)
+			}
+		case *AmbiguousCampaignError:
+			aErr := &AmbiguousCampaignError{}
+			if !errors.As(err, &aErr) || fmt.Sprint(aErr.CampaignIDs) != "[a b]" {
+				t.Fatalf("Expected an ambiguous campaign error, got (%#v)", err)
+			}
+		case *UnknownCampaignError:
+			uErr := &UnknownCampaignError{}
+			if !errors.As(err, &uErr) || uErr.Name != tc.name {
+				t.Fatalf("Expected an unknown campaign error, got (%#v)", err)
+			}
+		}
+	}
+	if lists != 2 {
+		t.Fatalf("Actual list calls (%v) did not match expected (%v)", lists, 2)
+	}
+}
@@ -0,0 +1,61 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// CancelHook is called when a request is aborted because its context was cancelled or timed out, either while
+// sending it or while reading the response body. err is the context's error.
+type CancelHook func(ctx context.Context, op Operation, err error)
+
+// WithCancelHook registers a hook observing aborted requests, e.g. to count them in metrics
+func WithCancelHook(h CancelHook) Option {
+	return func(g *getResponseClient) {
+		g.cancelHook = h
+	}
+}
+
+// cancelled reports an aborted request to the hook and returns the error to surface
+func (g *getResponseClient) cancelled(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	if ctxErr != nil && g.cancelHook != nil {
+		pErr := g.callHook(ctx, "cancel", func() {
+			g.cancelHook(ctx, operationFromContext(ctx), ctxErr)
+		})
+		if pErr != nil {
+			return pErr
+		}
+	}
+	return err
+}
+
+// contextReader stops reading once ctx is done, so a large body is not read to the end after cancellation even when
+// the transport does not abort the read itself
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// PageError is returned by paginated helpers such as ExportContactsCSV when fetching a page fails after the previous
+// pages were processed. Setting the request's Page to Page resumes where the helper stopped.
+type PageError struct {
+	Page int32
+	Err  error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("page %d: %s", e.Page, e.Err.Error())
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,121 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// endlessBody is a response body that never ends and cancels the request after the first read
+type endlessBody struct {
+	cancel context.CancelFunc
+	reads  int
+}
+
+func (b *endlessBody) Read(p []byte) (int, error) {
+	b.reads++
+	b.cancel()
+	return copy(p, "[{}"), nil
+}
+
+func (b *endlessBody) Close() error {
+	return nil
+}
+
+func TestUnit_CancelDuringBodyRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body := &endlessBody{cancel: cancel}
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body, Request: r}, nil
+	})
+
+	var hooked []string
+	hook := func(ctx context.Context, op Operation, err error) {
+		hooked = append(hooked, fmt.Sprintf("%s %s", op, err))
+	}
+	c := NewClient("http://example.com", "", "", &http.Client{Transport: transport}, WithCancelHook(hook), WithoutThrottling())
+
+	_, err := c.GetContacts(ctx, &GetContactsRequest{Page: 1, PerPage: 10})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got (%#v)", err)
+	}
+	if body.reads != 1 {
+		t.Fatalf("Body was read %d times after cancellation", body.reads)
+	}
+	expected := []string{"GetContacts context canceled"}
+	if len(hooked) != 1 || hooked[0] != expected[0] {
+		t.Fatalf("Actual hook calls (%v) did not match expected (%v)", hooked, expected)
+	}
+}
+
+func TestUnit_CancelHookIgnoresOtherErrors(t *testing.T) {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	called := false
+	c := NewClient("http://example.com", "", "", &http.Client{Transport: transport}, WithCancelHook(func(context.Context, Operation, error) {
+		called = true
+	}))
+
+	_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	if err == nil || called {
+		t.Fatalf("Unexpected result (%#v, hook called %t)", err, called)
+	}
+}
+
+func TestUnit_ExportContactsCSVResume(t *testing.T) {
+	failing := true
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/custom-fields" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `[{"contactId":"a","email":"a@example.com"}]`)
+		case "2":
+			if failing {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, `{"code":1,"message":"internal error"}`)
+				return
+			}
+			fmt.Fprint(w, `[{"contactId":"b","email":"b@example.com"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	out := &strings.Builder{}
+	err := c.ExportContactsCSV(context.Background(), out, &GetContactsRequest{PerPage: 1})
+	pErr := &PageError{}
+	if !errors.As(err, &pErr) || pErr.Page != 2 {
+		t.Fatalf("Expected a PageError for page 2, got (%#v)", err)
+	}
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusServiceUnavailable {
+		t.Fatalf("Expected the API error to be wrapped, got (%#v)", err)
+	}
+
+	failing = false
+	err = c.ExportContactsCSV(context.Background(), out, &GetContactsRequest{PerPage: 1, Page: pErr.Page})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := "contactId,email,name,campaignId,dayOfCycle,origin,createdOn,changedOn,ipAddress\n" +
+		"a,a@example.com,,,,,,,\n" +
+		"b,b@example.com,,,,,,,\n"
+	if out.String() != expected {
+		t.Fatalf("Actual output (%s) did not match expected (%s)", out.String(), expected)
+	}
+}
@@ -0,0 +1,175 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Plan is the kind of GetResponse account
+type Plan string
+
+const (
+	PlanSMB Plan = "smb"
+	PlanMAX Plan = "max" // GetResponse MAX (360), see RegionMAXPoland and RegionMAXUS
+)
+
+// Feature is a part of the API an account may not have. Its value is the first path segment of its endpoints.
+type Feature string
+
+const (
+	FeatureTransactional  Feature = "transactional-emails"
+	FeatureSMS            Feature = "sms"
+	FeatureAutoresponders Feature = "autoresponders"
+	FeatureWorkflows      Feature = "workflow"
+	FeatureWebinars       Feature = "webinars"
+	FeatureEcommerce      Feature = "shops"
+	FeatureLandingPages   Feature = "landing-pages"
+)
+
+// probedFeatures are the features Capabilities checks
+var probedFeatures = []Feature{
+	FeatureTransactional, FeatureSMS, FeatureAutoresponders, FeatureWorkflows, FeatureWebinars, FeatureEcommerce,
+	FeatureLandingPages,
+}
+
+// ErrFeatureUnavailable is matched by the errors of requests to features the account does not have
+var ErrFeatureUnavailable = errors.New("feature not available for this account")
+
+// FeatureError is returned, before anything is sent, for a request to a feature the account does not have, see
+// WithCapabilityChecks
+type FeatureError struct {
+	Feature Feature
+	Plan    Plan
+}
+
+func (e *FeatureError) Error() string {
+	return fmt.Sprintf("%s is not available for this %s account", e.Feature, e.Plan)
+}
+
+func (e *FeatureError) Is(target error) bool {
+	return target == ErrFeatureUnavailable
+}
+
+// Capabilities tells what the account can use
+type Capabilities struct {
+	Plan     Plan
+	Features map[Feature]bool
+}
+
+// Has reports whether the account has the feature. Features that were not probed are assumed available.
+func (c *Capabilities) Has(f Feature) bool {
+	available, probed := c.Features[f]
+	return available || !probed
+}
+
+// WithCapabilityChecks makes requests to a feature the account does not have fail with a *FeatureError instead of
+// being sent. Capabilities are probed before the first request to one of the probed features.
+func WithCapabilityChecks() Option {
+	return func(g *getResponseClient) {
+		g.capabilityChecks = true
+	}
+}
+
+// capabilityCache holds the capabilities of the account, which are probed once for the life of the client
+type capabilityCache struct {
+	flight flight
+	mu     sync.Mutex
+	caps   *Capabilities
+}
+
+func (g *getResponseClient) Capabilities(ctx context.Context) (*Capabilities, error) {
+	g.capabilities.mu.Lock()
+	caps := g.capabilities.caps
+	g.capabilities.mu.Unlock()
+	if caps != nil {
+		return caps, nil
+	}
+
+	err := g.capabilities.flight.do(ctx, func() error {
+		caps, err := g.probeCapabilities(ctx)
+		if err != nil {
+			return err
+		}
+		g.capabilities.mu.Lock()
+		g.capabilities.caps = caps
+		g.capabilities.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.capabilities.mu.Lock()
+	defer g.capabilities.mu.Unlock()
+	return g.capabilities.caps, nil
+}
+
+func (g *getResponseClient) probeCapabilities(ctx context.Context) (*Capabilities, error) {
+	ctx = withoutCache(withOperation(ctx, OpProbeCapabilities))
+
+	caps := &Capabilities{Plan: PlanSMB, Features: map[Feature]bool{}}
+	if u, err := url.Parse(g.apiUrl); g.domain != "" || (err == nil && isMAXHost(u.Hostname())) {
+		caps.Plan = PlanMAX
+	}
+	query := url.Values{"perPage": {"1"}}
+	for _, f := range probedFeatures {
+		// only the status is needed, so headers are asked for and the listing is fetched only when HEAD is refused
+		path := "/v3/" + string(f)
+		_, _, err := g.do(ctx, http.MethodHead, path, query, nil)
+		if status := errorStatus(err); status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented {
+			_, _, err = g.do(ctx, http.MethodGet, path, query, nil)
+		}
+		switch status := errorStatus(err); {
+		case err == nil:
+			caps.Features[f] = true
+		case status == http.StatusForbidden || status == http.StatusNotFound:
+			caps.Features[f] = false
+		default:
+			return nil, err
+		}
+	}
+	return caps, nil
+}
+
+// errorStatus returns the HTTP status of an API error, 0 for other errors
+func errorStatus(err error) int {
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus
+	}
+	return 0
+}
+
+func (g *getResponseClient) checkCapability(ctx context.Context, path string) error {
+	if !g.capabilityChecks || operationFromContext(ctx) == OpProbeCapabilities {
+		return nil
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && isVersionSegment(segments[0]) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || !isProbedFeature(Feature(segments[0])) {
+		return nil
+	}
+	caps, err := g.Capabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if !caps.Has(Feature(segments[0])) {
+		return &FeatureError{Feature: Feature(segments[0]), Plan: caps.Plan}
+	}
+	return nil
+}
+
+func isProbedFeature(f Feature) bool {
+	for _, p := range probedFeatures {
+		if p == f {
+			return true
+		}
+	}
+	return false
+}
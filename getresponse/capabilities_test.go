@@ -0,0 +1,88 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestUnit_Capabilities(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/v3/sms":
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/v3/webinars" && r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/v3/webinars":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "example.com", nil, WithCapabilityChecks(), WithoutThrottling())
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if caps.Plan != PlanMAX || !caps.Has(FeatureTransactional) || caps.Has(FeatureSMS) || caps.Has(FeatureWebinars) {
+		t.Errorf("Actual capabilities (%#v) did not match expected", caps)
+	}
+	if requests["GET /v3/webinars"] != 1 || requests["GET /v3/transactional-emails"] != 0 {
+		t.Errorf("Expected GET only where HEAD was refused (%v)", requests)
+	}
+
+	err = c.Do(context.Background(), http.MethodPost, "/v3/sms/send", nil, map[string]string{}, nil)
+	fErr := &FeatureError{}
+	if !errors.As(err, &fErr) || fErr.Feature != FeatureSMS || !errors.Is(err, ErrFeatureUnavailable) {
+		t.Errorf("Actual error (%#v) did not match expected (%v)", err, ErrFeatureUnavailable)
+	}
+	_, err = c.Newsletters().List(context.Background(), &GetNewslettersRequest{})
+	if err != nil {
+		t.Errorf("Unexpected error occurred (%#v)", err)
+	}
+	if requests["HEAD /v3/sms"] != 1 || requests["POST /v3/sms/send"] != 0 {
+		t.Errorf("Expected the capabilities to be probed once and the SMS request not to be sent (%v)", requests)
+	}
+}
+
+func TestUnit_CapabilitiesWithPermissions(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil, WithCapabilityChecks(), WithAllowedPermissions("autoresponders.read"), WithoutThrottling())
+	_, err := c.Campaigns().Cycle(context.Background(), "V")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	_, err = c.Tags().List(context.Background(), &GetTagsRequest{})
+	pErr := &PermissionError{}
+	if !errors.As(err, &pErr) {
+		t.Errorf("Actual error (%#v) did not match expected (%T)", err, pErr)
+	}
+	for _, p := range paths {
+		if p == "GET /v3/tags" {
+			t.Errorf("Expected the denied request not to be sent (%v)", paths)
+		}
+	}
+}
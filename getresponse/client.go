@@ -6,11 +6,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Error codes
@@ -47,239 +52,997 @@ var (
 	ErrCouldNotUnmarshal = errors.New("could not unmarshal")
 )
 
-// Client can make requests to the GR api
+// Client can make requests to the GR api.
+//
+// A Client is safe for concurrent use by multiple goroutines and should be shared rather than created per request.
+// Its configuration is fixed once NewClient returns; SetDebug is the only method changing it and is synchronized.
+// The throttler and cache are shared by all requests of the client and synchronize internally.
 type Client interface {
+	// Contacts returns the calls on contacts
+	Contacts() ContactsClient
+
+	// Campaigns returns the calls on campaigns (lists)
+	Campaigns() CampaignsClient
+
+	// CustomFields returns the calls on custom field definitions
+	CustomFields() CustomFieldsClient
+
+	// Tags returns the calls on tags
+	Tags() TagsClient
+
+	// FromFields returns the calls on from fields
+	FromFields() FromFieldsClient
+
+	// Newsletters returns the calls on newsletters
+	Newsletters() NewslettersClient
+
 	// CreateContact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.create
+	//
+	// Deprecated: use Contacts().Create.
 	CreateContact(ctx context.Context, request *CreateContactRequest) error
 
+	// CreateContactAndWait creates the contact and polls GetContacts until it has been added, since contact creation
+	// is asynchronous. Returns ErrContactPendingConfirmation if the campaign requires double opt-in.
+	//
+	// Deprecated: use Contacts().CreateAndWait.
+	CreateContactAndWait(ctx context.Context, request *CreateContactRequest, opts *WaitOptions) (*Contact, error)
+
+	// GetContactConfirmation reports whether the contact confirmed its subscription to a double opt-in campaign
+	//
+	// Deprecated: use Contacts().GetConfirmation.
+	GetContactConfirmation(ctx context.Context, email, campaignID string) (ConfirmationStatus, error)
+
 	// GetContacts - https://apidocs.getresponse.com/v3/resources/contacts#contacts.get.all
+	//
+	// Deprecated: use Contacts().List.
 	GetContacts(ctx context.Context, request *GetContactsRequest) (*GetContactsResponse, error)
 
 	// Get Contact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.get
+	//
+	// Deprecated: use Contacts().Get.
 	GetContact(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error)
 
 	// UpdateContact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.update
+	//
+	// Deprecated: use Contacts().Update.
 	UpdateContact(ctx context.Context, request *UpdateContactRequest) (*UpdateContactResponse, error)
 
+	// UpdateContactIf reads the contact, applies mutate to a copy and sends only what mutate changed. It starts over
+	// when the contact changes in between, and returns ErrUpdateConflict if it keeps changing. Custom fields are
+	// upserted one by one, so concurrent updates of other custom fields are kept.
+	//
+	// Deprecated: use Contacts().UpdateIf.
+	UpdateContactIf(ctx context.Context, id string, mutate func(*Contact) error) (*Contact, error)
+
+	// MoveContactToCampaign moves the contact to another campaign, restarting, keeping or leaving its autoresponder
+	// cycle as opts says
+	//
+	// Deprecated: use Contacts().MoveToCampaign.
+	MoveContactToCampaign(ctx context.Context, contactID, targetCampaignID string, opts *MoveOptions) (*Contact, error)
+
+	// CopyContactToCampaign adds the contact to another campaign as well, with its name, custom fields and tags. If
+	// it is already there, the tags and custom field values are merged into that contact.
+	//
+	// Deprecated: use Contacts().CopyToCampaign.
+	CopyContactToCampaign(ctx context.Context, contactID, targetCampaignID string) error
+
 	// UpdateContactCustomFields - https://apidocs.getresponse.com/v3/resources/contacts#contacts.upsert.custom-fields
+	//
+	// Deprecated: use Contacts().UpdateCustomFields.
 	UpdateContactCustomFields(ctx context.Context, request *UpdateContactCustomFieldsRequest) (*UpdateContactCustomFieldsResponse, error)
 
 	// DeleteContact - https://apidocs.getresponse.com/v3/resources/contacts#contacts.delete
+	//
+	// Deprecated: the call unsubscribes the contact when MessageID is set and removes it otherwise, use
+	// Contacts().Unsubscribe or Contacts().Remove instead.
 	DeleteContact(ctx context.Context, request *DeleteContactRequest) error
+
+	// UnsubscribeContact unsubscribes the contact in response to a message, keeping its history in the account
+	//
+	// Deprecated: use Contacts().Unsubscribe.
+	UnsubscribeContact(ctx context.Context, request *UnsubscribeContactRequest) error
+
+	// RemoveContact removes the contact from the campaign without recording an unsubscription
+	//
+	// Deprecated: use Contacts().Remove.
+	RemoveContact(ctx context.Context, request *RemoveContactRequest) error
+
+	// GetCampaigns - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.get.all
+	//
+	// Deprecated: use Campaigns().List.
+	GetCampaigns(ctx context.Context, request *GetCampaignsRequest) (*GetCampaignsResponse, error)
+
+	// GetCustomFields - https://apidocs.getresponse.com/v3/resources/customfields#customfields.get.all
+	//
+	// Deprecated: use CustomFields().List.
+	GetCustomFields(ctx context.Context, request *GetCustomFieldsRequest) (*GetCustomFieldsResponse, error)
+
+	// GetTags - https://apidocs.getresponse.com/v3/resources/tags#tags.get.all
+	//
+	// Deprecated: use Tags().List.
+	GetTags(ctx context.Context, request *GetTagsRequest) (*GetTagsResponse, error)
+
+	// GetNewsletters - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.get.all
+	//
+	// Deprecated: use Newsletters().List.
+	GetNewsletters(ctx context.Context, request *GetNewslettersRequest) (*GetNewslettersResponse, error)
+
+	// GetCampaign - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.get
+	//
+	// Deprecated: use Campaigns().Get.
+	GetCampaign(ctx context.Context, request *GetCampaignRequest) (*Campaign, error)
+
+	// UpdateCampaignSettings - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.update
+	//
+	// Deprecated: use Campaigns().UpdateSettings.
+	UpdateCampaignSettings(ctx context.Context, request *UpdateCampaignSettingsRequest) (*Campaign, error)
+
+	// CreateCampaign - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.create
+	//
+	// Deprecated: use Campaigns().Create.
+	CreateCampaign(ctx context.Context, request *CreateCampaignRequest) (*Campaign, error)
+
+	// CreateCustomField - https://apidocs.getresponse.com/v3/resources/customfields#customfields.create
+	//
+	// Deprecated: use CustomFields().Create.
+	CreateCustomField(ctx context.Context, request *CreateCustomFieldRequest) (*CustomFieldDefinition, error)
+
+	// CreateTag - https://apidocs.getresponse.com/v3/resources/tags#tags.create
+	//
+	// Deprecated: use Tags().Create.
+	CreateTag(ctx context.Context, request *CreateTagRequest) (*Tag, error)
+
+	// GetFromFields - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.get.all
+	//
+	// Deprecated: use FromFields().List.
+	GetFromFields(ctx context.Context, request *GetFromFieldsRequest) (*GetFromFieldsResponse, error)
+
+	// CreateFromField - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.create
+	//
+	// Deprecated: use FromFields().Create.
+	CreateFromField(ctx context.Context, request *CreateFromFieldRequest) (*FromField, error)
+
+	// GetSearchContact - https://apidocs.getresponse.com/v3/resources/searchcontacts#search-contacts.get
+	GetSearchContact(ctx context.Context, request *GetSearchContactRequest) (*SearchContact, error)
+
+	// CreateNewsletter - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.create
+	//
+	// Deprecated: use Newsletters().Create.
+	CreateNewsletter(ctx context.Context, request *CreateNewsletterRequest) (*Newsletter, error)
+
+	// SendTransactionalEmail - https://apidocs.getresponse.com/v3/resources/transactionalemails#transactional-emails.create
+	SendTransactionalEmail(ctx context.Context, request *SendTransactionalEmailRequest) (*TransactionalEmail, error)
+
+	// GetMergeTags lists the merge tags messages can use: the predefined ones and one per custom field
+	GetMergeTags(ctx context.Context) ([]string, error)
+
+	// SendNewsletterToSegment creates the newsletter sent to the given segments (saved contact searches) on top of
+	// the request's send settings, after checking the segments exist
+	//
+	// Deprecated: use Newsletters().SendToSegment.
+	SendNewsletterToSegment(ctx context.Context, request *CreateNewsletterRequest, segmentIDs ...string) (*Newsletter, error)
+
+	// ExportContactsCSV writes every contact matching the query to w as CSV, fetching one page at a time. A failed page
+	// is returned as a *PageError telling where to resume: a run started at its Page leaves out the header row, so
+	// its output continues the failed one. Custom fields get a column each, named after the field, unless Fields leaves
+	// out customFieldValues.
+	//
+	// Deprecated: use Contacts().ExportCSV.
+	ExportContactsCSV(ctx context.Context, w io.Writer, query *GetContactsRequest) error
+
+	// ImportContactsCSV creates the contacts read from CSV in the campaign, mapping extra columns to custom fields
+	//
+	// Deprecated: use Contacts().ImportCSV.
+	ImportContactsCSV(ctx context.Context, r io.Reader, campaignID string, fieldMapping map[string]string) (*ImportResult, error)
+
+	// Ping makes a cheap authenticated call to check the API can be used, e.g. from a readiness probe. It bypasses
+	// the cache, and the result tells network, authentication and quota failures apart.
+	Ping(ctx context.Context) PingResult
+
+	// QuotaStatus returns the request budget reported by the most recent response with rate limit headers
+	QuotaStatus() QuotaStatus
+
+	// Do sends a request to an endpoint the client has no method for, e.g. "/v3/webforms", with body marshaled as
+	// JSON when not nil, and decodes the response into out when not nil. See Get and Post for typed helpers.
+	// A body that is an io.Reader is streamed as is, for large payloads: it is rewound for retries when it is an
+	// io.Seeker, and sent once otherwise.
+	Do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error
+
+	// With returns a client configured like this one and then by opts, e.g. WithDomain, WithDefaultPolicy or
+	// WithReadOnly for a tenant, without building a new transport: both clients send through the same connections
+	// and throttler. Transport options given here are ignored, while middlewares wrap the shared transport.
+	// Catalogs and quota status are kept apart and the cache is not inherited, as derived clients may target other
+	// accounts: pass WithCache again to cache their responses.
+	With(opts ...Option) Client
+
+	// AccountLocation returns the time zone of the account, which date filters count days in. It is read once and
+	// kept for the life of the client.
+	AccountLocation(ctx context.Context) (*time.Location, error)
+
+	// Capabilities probes which features the account has, e.g. transactional emails or SMS, and whether it is a MAX
+	// account. The probe sends a HEAD request per feature once and is kept for the life of the client.
+	Capabilities(ctx context.Context) (*Capabilities, error)
+
+	// Close shuts the client down: requests started afterwards fail with ErrClientClosed, background components
+	// started on it such as a BudgetTracker are stopped, and requests in flight are waited for. When ctx ends first
+	// they are cancelled and ctx's error is returned. Idle connections are closed last. Clients derived with With
+	// are closed separately.
+	Close(ctx context.Context) error
+
+	// SetDebug starts dumping requests and responses to w, or stops when w is nil. Safe for concurrent use.
+	SetDebug(w io.Writer)
 }
 
 type getResponseClient struct {
-	c      *http.Client
-	apiKey string
-	domain string
-	apiUrl string
+	c           *http.Client
+	credentials CredentialsProvider
+	domain      string
+	apiUrl      string
+	logger      Logger
+	dryRun      bool
+	redact      Redactor
+
+	errorBodyLimit int
+
+	debugMu   sync.Mutex
+	debugOut  io.Writer
+	debugHook DebugHook
+
+	correlationIDHeader string
+
+	throttler *Throttler
+	quota     quotaTracker
+
+	defaultPolicy Policy
+	policies      map[Operation]Policy
+
+	defaultCustomFieldLimit CustomFieldLimit
+	customFieldLimits       map[string]CustomFieldLimit
+
+	cache           Cache
+	cacheTTL        time.Duration
+	cacheRevalidate time.Duration
+
+	transport transportConfig
+
+	cancelHook CancelHook
+
+	utm      *UTM
+	utmAudit func(ctx context.Context, changes []LinkChange)
+
+	apiVersion string
+
+	strictDecoding    bool
+	unknownFieldsHook UnknownFieldsHook
+	rawResponses      bool
+
+	customFieldCatalog    *CustomFieldCatalog
+	customFieldCatalogTTL time.Duration
+	tagCatalog            *TagCatalog
+	tagCatalogTTL         time.Duration
+	tagAutoCreate         bool
+	campaignNames         campaignNames
+
+	allowedPermissions  []string
+	deniedPermissions   []string
+	restrictPermissions bool
+
+	auditHook AuditHook
+
+	bodyReadTimeout time.Duration
+
+	anonymizeIP bool
+
+	accountTimeZone bool
+	zone            accountZone
+
+	capabilityChecks bool
+	capabilities     capabilityCache
+
+	signer      RequestSigner
+	middlewares []Middleware
+
+	panicRecovery bool
+
+	constructor constructor
+	lifecycle   lifecycle
+
+	configErr error
 }
 
-// NewClient returns a new pushy client
-func NewClient(apiUrl, apiKey, domain string, client *http.Client) Client {
-	if client == nil {
-		client = http.DefaultClient
-	}
+// NewClient returns a new pushy client. apiUrl is the API base URL, with or without the version segment. Options are applied here only, so the http.Client and any Cache, Logger or
+// Redactor passed in must themselves be safe for concurrent use.
+func NewClient(apiUrl, apiKey, domain string, client *http.Client, opts ...Option) Client {
+	g := &getResponseClient{
+		c:           client,
+		credentials: StaticCredentials(apiKey),
+		apiUrl:      apiUrl,
+		domain:      domain,
+
+		redact:         RedactPII,
+		errorBodyLimit: defaultErrorBodyLimit,
+
+		correlationIDHeader: DefaultCorrelationIDHeader,
+
+		throttler:       NewThrottler(defaultThrottleMaxWait),
+		cacheRevalidate: defaultCacheRevalidate,
 
-	return &getResponseClient{
-		c:      client,
-		apiKey: apiKey,
-		apiUrl: apiUrl,
-		domain: domain,
+		apiVersion: "/" + DefaultAPIVersion,
+
+		customFieldCatalogTTL: defaultCustomFieldCatalogTTL,
+		tagCatalogTTL:         defaultTagCatalogTTL,
+	}
+	g.constructor = constructor{apiUrl: apiUrl, credentials: g.credentials, domain: domain, opts: opts}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.customFieldCatalog = NewCustomFieldCatalog(g, g.customFieldCatalogTTL)
+	g.tagCatalog = NewTagCatalog(g, g.tagCatalogTTL)
+	if g.c == nil {
+		g.c = g.transport.newHTTPClient()
 	}
+	g.constructor.client = g.c
+	if len(g.middlewares) > 0 {
+		g.c = wrapTransport(g.c, append([]Middleware{g.recoverMiddlewares}, g.middlewares...))
+	}
+	if g.dryRun && g.logger == nil {
+		g.logger = log.New(os.Stderr, "getresponse: ", log.LstdFlags)
+	}
+	g.apiUrl = normalizeBaseURL(g.apiUrl)
+	g.configErr = validateEndpoint(g.apiUrl, g.domain)
+
+	return g
 }
 
-func (g *getResponseClient) CreateContact(ctx context.Context, request *CreateContactRequest) error {
+func (g *getResponseClient) createContact(ctx context.Context, request *CreateContactRequest) error {
+	ctx = withOperation(ctx, OpCreateContact)
+
+	request, err := g.resolveCustomFields(ctx, request)
+	if err != nil {
+		return err
+	}
+	request, err = g.resolveCreateTags(ctx, request)
+	if err != nil {
+		return err
+	}
+	request, err = g.normalizeCreateIP(request)
+	if err != nil {
+		return err
+	}
+	request, err = g.limitCreateCustomFields(request)
+	if err != nil {
+		return err
+	}
+	if err := g.validateDryRun(request); err != nil {
+		return err
+	}
+
 	body, err := json.Marshal(request)
 	if err != nil {
 		return err
 	}
 
-	status, ret, err := g.roundTrip(ctx, http.MethodPost, "/v3/contacts", nil, body)
-	return g.checkGetResponseError(status, ret, err)
+	_, _, err = g.do(ctx, http.MethodPost, "/v3/contacts", nil, body)
+	return err
+}
+
+func (g *getResponseClient) getContacts(ctx context.Context, req *GetContactsRequest) (*GetContactsResponse, error) {
+	ctx = withOperation(ctx, OpGetContacts)
+
+	if err := validateContactFields(req.Fields, req.AdditionalFlags); err != nil {
+		return nil, err
+	}
+	query := listQuery(req.QueryHash, req.SortHash, req.Sort, req.Fields, req.Page, req.PerPage)
+	if req.AdditionalFlags != nil && *req.AdditionalFlags != "" {
+		query.Set("additionalFlags", *req.AdditionalFlags)
+	}
+
+	result, raw, err := doGet[[]Contact](ctx, g, "/v3/contacts", query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetContactsResponse{Contacts: result, Raw: raw}, nil
 }
 
-func (g *getResponseClient) GetContacts(ctx context.Context, req *GetContactsRequest) (*GetContactsResponse, error) {
+func (g *getResponseClient) getContact(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error) {
+	ctx = withOperation(ctx, OpGetContact)
+
+	if err := validateContactFields(request.Fields, nil); err != nil {
+		return nil, err
+	}
 	query := url.Values{}
-	for k, v := range req.QueryHash {
-		query.Set(fmt.Sprintf("query[%s]", k), v)
+	if len(request.Fields) > 0 {
+		query.Set("fields", strings.Join(request.Fields, ","))
 	}
 
-	for k, v := range req.SortHash {
-		query.Set(fmt.Sprintf("sort[%s]", k), v)
+	path, err := resourcePath("contacts", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	c, raw, err := doGet[Contact](ctx, g, path, query)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(req.Fields) > 0 {
-		query.Set("fields", strings.Join(req.Fields, ","))
+	return &GetContactResponse{
+		Contact: c,
+		Raw:     raw,
+	}, nil
+}
+
+func (g *getResponseClient) updateContact(ctx context.Context, req *UpdateContactRequest) (*UpdateContactResponse, error) {
+	ctx = withOperation(ctx, OpUpdateContact)
+
+	req, err := g.resolveUpdateTags(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	req, err = g.normalizeUpdateIP(req)
+	if err != nil {
+		return nil, err
+	}
+	req, err = g.limitUpdateCustomFields(req)
+	if err != nil {
+		return nil, err
+	}
+	// a cleared field the API cannot clear would otherwise be sent as null
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
-	query.Set("page", strconv.Itoa(int(req.Page)))
-	query.Set("perPage", strconv.Itoa(int(req.PerPage)))
+	body, err := req.body()
+	if err != nil {
+		return nil, err
+	}
 
-	if req.AdditionalFlags != nil {
-		query.Set("additionalFlags", *req.AdditionalFlags)
+	path, err := resourcePath("contacts", req.ID)
+	if err != nil {
+		return nil, err
+	}
+	c, raw, err := doJSON[Contact](ctx, g, http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, err
 	}
 
-	status, ret, err := g.roundTrip(ctx, http.MethodGet, "/v3/contacts", query, nil)
-	err = g.checkGetResponseError(status, ret, err)
+	return &UpdateContactResponse{Contact: c, Raw: raw}, nil
+}
+
+func (g *getResponseClient) updateContactCustomFields(ctx context.Context, request *UpdateContactCustomFieldsRequest) (*UpdateContactCustomFieldsResponse, error) {
+	ctx = withOperation(ctx, OpUpdateContactCustomFields)
+
+	fields, changed, err := g.limitCustomFields(request.CustomFields)
 	if err != nil {
 		return nil, err
 	}
+	if changed {
+		limited := *request
+		limited.CustomFields = fields
+		request = &limited
+	}
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
+	}
 
-	res := &GetContactsResponse{}
-	jErr := json.Unmarshal(ret, &res.Contacts)
-	if jErr != nil {
-		return nil, &GetResponseErrorRaw{
-			Err:        ErrCouldNotUnmarshal,
-			HTTPStatus: status,
-			HTTPBody:   ret,
-		}
+	path, err := resourcePath("contacts", request.ID)
+	if err != nil {
+		return nil, err
 	}
+	c, raw, err := doPost[*UpdateContactCustomFieldsRequest, Contact](ctx, g, path+"/custom-fields", request)
+	if err != nil {
+		return nil, err
+	}
+	g.invalidate(path)
 
-	return res, nil
+	return &UpdateContactCustomFieldsResponse{Contact: c, Raw: raw}, nil
 }
 
-func (g *getResponseClient) GetContact(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error) {
+func (g *getResponseClient) DeleteContact(ctx context.Context, request *DeleteContactRequest) error {
+	ctx = withOperation(ctx, OpDeleteContact)
+
+	if err := g.validateDryRun(request); err != nil {
+		return err
+	}
+
+	return g.deleteContact(ctx, request.ID, request.MessageID, request.IpAddress)
+}
+
+func (g *getResponseClient) unsubscribeContact(ctx context.Context, request *UnsubscribeContactRequest) error {
+	ctx = withOperation(ctx, OpUnsubscribeContact)
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	return g.deleteContact(ctx, request.ID, request.MessageID, request.IPAddress)
+}
+
+func (g *getResponseClient) removeContact(ctx context.Context, request *RemoveContactRequest) error {
+	ctx = withOperation(ctx, OpRemoveContact)
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	return g.deleteContact(ctx, request.ID, "", request.IPAddress)
+}
+
+// deleteContact unsubscribes the contact when messageID is set and removes it otherwise
+func (g *getResponseClient) deleteContact(ctx context.Context, id, messageID, ipAddress string) error {
+	ipAddress, err := g.normalizeIP(ipAddress)
+	if err != nil {
+		return err
+	}
+
 	query := url.Values{}
-	if len(request.Fields) > 0 {
-		query.Set("fields", strings.Join(request.Fields, ","))
+	if messageID != "" {
+		query.Set("messageId", messageID)
+	}
+	if ipAddress != "" {
+		query.Set("ipAddress", ipAddress)
 	}
 
-	status, ret, err := g.roundTrip(ctx, http.MethodGet, fmt.Sprintf("/v3/contacts/%s", request.ID), query, nil)
-	err = g.checkGetResponseError(status, ret, err)
+	path, err := resourcePath("contacts", id)
+	if err != nil {
+		return err
+	}
+	_, _, err = g.do(ctx, http.MethodDelete, path, query, nil)
+	return err
+}
+
+func (g *getResponseClient) getCampaigns(ctx context.Context, req *GetCampaignsRequest) (*GetCampaignsResponse, error) {
+	ctx = withOperation(ctx, OpGetCampaigns)
+
+	query := listQuery(req.QueryHash, req.SortHash, req.Sort, req.Fields, req.Page, req.PerPage)
+	result, raw, err := doGet[[]Campaign](ctx, g, "/v3/campaigns", query)
 	if err != nil {
 		return nil, err
 	}
 
-	c := Contact{}
-	jErr := json.Unmarshal(ret, &c)
-	if jErr != nil {
-		return nil, &GetResponseErrorRaw{
-			Err:        ErrCouldNotUnmarshal,
-			HTTPStatus: status,
-			HTTPBody:   ret,
+	return &GetCampaignsResponse{Campaigns: result, Raw: raw}, nil
+}
+
+func (g *getResponseClient) getCustomFields(ctx context.Context, req *GetCustomFieldsRequest) (*GetCustomFieldsResponse, error) {
+	ctx = withOperation(ctx, OpGetCustomFields)
+
+	query := listQuery(req.QueryHash, req.SortHash, req.Sort, req.Fields, req.Page, req.PerPage)
+	result, raw, err := doGet[[]CustomFieldDefinition](ctx, g, "/v3/custom-fields", query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetCustomFieldsResponse{CustomFields: result, Raw: raw}, nil
+}
+
+func (g *getResponseClient) getTags(ctx context.Context, req *GetTagsRequest) (*GetTagsResponse, error) {
+	ctx = withOperation(ctx, OpGetTags)
+
+	query := listQuery(req.QueryHash, req.SortHash, req.Sort, req.Fields, req.Page, req.PerPage)
+	result, raw, err := doGet[[]Tag](ctx, g, "/v3/tags", query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTagsResponse{Tags: result, Raw: raw}, nil
+}
+
+func (g *getResponseClient) getNewsletters(ctx context.Context, req *GetNewslettersRequest) (*GetNewslettersResponse, error) {
+	ctx = withOperation(ctx, OpGetNewsletters)
+
+	query := listQuery(req.QueryHash, req.SortHash, req.Sort, req.Fields, req.Page, req.PerPage)
+	result, raw, err := doGet[[]Newsletter](ctx, g, "/v3/newsletters", query)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if err := g.localizeNewsletters(ctx, &result[i]); err != nil {
+			return nil, err
 		}
 	}
 
-	return &GetContactResponse{
-		Contact: c,
-	}, nil
+	return &GetNewslettersResponse{Newsletters: result, Raw: raw}, nil
 }
 
-func (g *getResponseClient) UpdateContact(ctx context.Context, req *UpdateContactRequest) (*UpdateContactResponse, error) {
-	body, err := json.Marshal(req.NewData)
+func (g *getResponseClient) getCampaign(ctx context.Context, request *GetCampaignRequest) (*Campaign, error) {
+	ctx = withOperation(ctx, OpGetCampaign)
+
+	path, err := resourcePath("campaigns", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := doGet[Campaign](ctx, g, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	status, ret, err := g.roundTrip(ctx, http.MethodPost, fmt.Sprintf("/v3/contacts/%s", req.ID), nil, body)
-	err = g.checkGetResponseError(status, ret, err)
+	return &result, nil
+}
+
+func (g *getResponseClient) updateCampaignSettings(ctx context.Context, request *UpdateCampaignSettingsRequest) (*Campaign, error) {
+	ctx = withOperation(ctx, OpUpdateCampaignSettings)
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
+	}
+
+	path, err := resourcePath("campaigns", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := doPost[CampaignSettings, Campaign](ctx, g, path, request.Settings)
 	if err != nil {
 		return nil, err
 	}
+	g.invalidate("/v3/campaigns")
 
-	result := &UpdateContactResponse{}
-	jErr := json.Unmarshal(ret, &result.Contact)
-	if jErr != nil {
-		return nil, &GetResponseErrorRaw{
-			Err:        ErrCouldNotUnmarshal,
-			HTTPStatus: status,
-			HTTPBody:   ret,
-		}
+	return &result, nil
+}
+
+func (g *getResponseClient) createCampaign(ctx context.Context, request *CreateCampaignRequest) (*Campaign, error) {
+	ctx = withOperation(ctx, OpCreateCampaign)
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	result, _, err := doPost[*CreateCampaignRequest, Campaign](ctx, g, "/v3/campaigns", request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
-func (g *getResponseClient) UpdateContactCustomFields(ctx context.Context, request *UpdateContactCustomFieldsRequest) (*UpdateContactCustomFieldsResponse, error) {
+func (g *getResponseClient) createCustomField(ctx context.Context, request *CreateCustomFieldRequest) (*CustomFieldDefinition, error) {
+	ctx = withOperation(ctx, OpCreateCustomField)
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
+	}
 
-	body, err := json.Marshal(request)
+	result, _, err := doPost[*CreateCustomFieldRequest, CustomFieldDefinition](ctx, g, "/v3/custom-fields", request)
 	if err != nil {
 		return nil, err
 	}
 
-	status, ret, err := g.roundTrip(ctx, http.MethodPost, fmt.Sprintf("/v3/contacts/%s/custom-fields", request.ID), nil, body)
-	err = g.checkGetResponseError(status, ret, err)
+	return &result, nil
+}
+
+func (g *getResponseClient) createTag(ctx context.Context, request *CreateTagRequest) (*Tag, error) {
+	ctx = withOperation(ctx, OpCreateTag)
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
+	}
+
+	result, _, err := doPost[*CreateTagRequest, Tag](ctx, g, "/v3/tags", request)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &UpdateContactCustomFieldsResponse{}
-	jErr := json.Unmarshal(ret, &result.Contact)
-	if jErr != nil {
-		return nil, &GetResponseErrorRaw{
-			Err:        ErrCouldNotUnmarshal,
-			HTTPStatus: status,
-			HTTPBody:   ret,
+	return &result, nil
+}
+
+func (g *getResponseClient) GetSearchContact(ctx context.Context, request *GetSearchContactRequest) (*SearchContact, error) {
+	ctx = withOperation(ctx, OpGetSearchContact)
+
+	path, err := resourcePath("search-contacts", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := doGet[SearchContact](ctx, g, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (g *getResponseClient) createNewsletter(ctx context.Context, request *CreateNewsletterRequest) (*Newsletter, error) {
+	ctx = withOperation(ctx, OpCreateNewsletter)
+	// a schedule the API cannot honour would otherwise be sent, or sent right away
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	if g.utm != nil {
+		decorated := *request
+		var changes []LinkChange
+		decorated.Content, changes = DecorateLinks(request.Content, *g.utm)
+		if g.utmAudit != nil {
+			err := g.callHook(ctx, "link decoration", func() {
+				g.utmAudit(ctx, changes)
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
+		request = &decorated
+	}
+
+	result, _, err := doPost[*CreateNewsletterRequest, Newsletter](ctx, g, "/v3/newsletters", request)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.localizeNewsletters(ctx, &result); err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return &result, nil
 }
 
-func (g *getResponseClient) DeleteContact(ctx context.Context, request *DeleteContactRequest) error {
-	query := url.Values{}
-	query.Set("messageId", request.MessageID)
-	query.Set("ipAddress", request.IpAddress)
+func (g *getResponseClient) SendTransactionalEmail(ctx context.Context, request *SendTransactionalEmailRequest) (*TransactionalEmail, error) {
+	ctx = withOperation(ctx, OpSendTransactionalEmail)
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
+	}
+	// attachments built by hand rather than with NewAttachment are checked too
+	if err := validateAttachments(request.Attachments); err != nil {
+		return nil, err
+	}
 
-	status, ret, err := g.roundTrip(ctx, http.MethodDelete, fmt.Sprintf("/v3/contacts/%s", request.ID), query, nil)
-	return g.checkGetResponseError(status, ret, err)
+	result, _, err := doPost[*SendTransactionalEmailRequest, TransactionalEmail](ctx, g, "/v3/transactional-emails", request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
-func (g *getResponseClient) checkGetResponseError(status int, ret []byte, err error) error {
+func (g *getResponseClient) getFromFields(ctx context.Context, req *GetFromFieldsRequest) (*GetFromFieldsResponse, error) {
+	ctx = withOperation(ctx, OpGetFromFields)
+
+	query := listQuery(req.QueryHash, req.SortHash, req.Sort, req.Fields, req.Page, req.PerPage)
+	result, raw, err := doGet[[]FromField](ctx, g, "/v3/from-fields", query)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return &GetFromFieldsResponse{FromFields: result, Raw: raw}, nil
+}
+
+func (g *getResponseClient) createFromField(ctx context.Context, request *CreateFromFieldRequest) (*FromField, error) {
+	ctx = withOperation(ctx, OpCreateFromField)
+	if err := g.validateDryRun(request); err != nil {
+		return nil, err
+	}
+
+	result, _, err := doPost[*CreateFromFieldRequest, FromField](ctx, g, "/v3/from-fields", request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// resourceIDPattern is what resource ids look like, GetResponse ids are short alphanumeric strings
+var resourceIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// resourcePath returns the path of a resource of the collection. Ids that could change the path, such as ones with
+// "/" or "?", are rejected with a ValidationError rather than sent; the id is escaped all the same.
+func resourcePath(collection, id string) (string, error) {
+	if !resourceIDPattern.MatchString(id) {
+		return "", &ValidationError{Field: "id", Message: fmt.Sprintf("%q is not a valid resource id", id)}
+	}
+	return "/v3/" + collection + "/" + url.PathEscape(id), nil
+}
+
+// listQuery builds the query shared by the list endpoints. Unset values are left out rather than sent empty or as
+// 0, so the API applies its defaults (the first page of 100).
+func listQuery(queryHash, sortHash map[string]string, sort []SortTerm, fields []string, page, perPage int32) url.Values {
+	// export jobs build one of these per page, so the single values share one allocation
+	query := make(url.Values, len(queryHash)+len(sortHash)+len(sort)+4)
+	values := make([]string, 0, len(queryHash)+3)
+	set := func(k, v string) {
+		values = append(values, v)
+		query[k] = values[len(values)-1 : len(values) : len(values)]
+	}
+	for k, v := range queryHash {
+		if v != "" {
+			set("query["+k+"]", v)
+		}
+	}
+
+	setSort(query, sortHash, sort)
+
+	if len(fields) > 0 {
+		set("fields", strings.Join(fields, ","))
+	}
+
+	if page > 0 {
+		set("page", strconv.Itoa(int(page)))
+	}
+	if perPage > 0 {
+		set("perPage", strconv.Itoa(int(perPage)))
+	}
+
+	return query
+}
+
+func (g *getResponseClient) checkGetResponseError(ctx context.Context, method, path string, status int, ret []byte, err error) error {
+	if err != nil {
+		return g.newAPIError(ctx, method, path, status, ret, err)
 	}
 
 	if status >= 200 && status < 400 {
 		return nil
 	}
 
+	var retErr error
 	grErr := &GetResponseError{}
 	jsonErr := json.Unmarshal(ret, grErr)
 	if jsonErr != nil {
-		return &GetResponseErrorRaw{
+		retErr = &GetResponseErrorRaw{
 			Err:        jsonErr,
 			HTTPStatus: status,
 			HTTPBody:   ret,
 		}
+	} else {
+		retErr = grErr
 	}
 
-	return grErr
+	if status == http.StatusTooManyRequests {
+		tErr := &ThrottledError{Err: retErr}
+		if g.throttler != nil {
+			tErr.RetryAfter = g.throttler.retryAfter()
+		}
+		retErr = tErr
+	}
+
+	return g.newAPIError(ctx, method, path, status, ret, retErr)
 }
 
-func (g *getResponseClient) roundTrip(ctx context.Context, method string, path string, query url.Values, body []byte) (int, []byte, error) {
-	u, err := url.Parse(g.apiUrl + path)
+// decodeError is returned when a successful response could not be unmarshaled, or had unknown fields in strict mode
+func (g *getResponseClient) decodeError(ctx context.Context, method, path string, status int, ret []byte, err error) error {
+	if !errors.Is(err, ErrCouldNotUnmarshal) {
+		err = ErrCouldNotUnmarshal
+	}
+	return g.newAPIError(ctx, method, path, status, ret, &GetResponseErrorRaw{
+		Err:        err,
+		HTTPStatus: status,
+		HTTPBody:   ret,
+	})
+}
+
+func (g *getResponseClient) roundTrip(ctx context.Context, method string, path string, query url.Values, body *requestBody) (int, []byte, error) {
+	if g.configErr != nil {
+		return 0, nil, g.configErr
+	}
+	if err := g.checkPermission(ctx, method, path); err != nil {
+		return 0, nil, err
+	}
+	if err := g.checkCapability(ctx, path); err != nil {
+		return 0, nil, err
+	}
+	u, err := url.Parse(g.apiUrl + g.versionedPath(path))
 	if err != nil {
 		return 0, nil, err
 	}
-	u.RawQuery = query.Encode()
+	u.RawQuery = encodeQuery(query)
+
+	if g.dryRun && !isRead(method, path) {
+		var data []byte
+		if body != nil {
+			data = body.data
+		}
+		return g.dryRunResponse(ctx, method, u, data)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var cached *cachedResponse
+	if method == http.MethodGet {
+		cached = g.cacheGet(ctx, path, query)
+		if cached != nil && cached.fresh() {
+			return http.StatusOK, cached.Body, nil
+		}
+	}
+	header := cached.conditionalHeaders()
+
+	p := g.policyFor(operationFromContext(ctx))
+	for retry := 0; ; retry++ {
+		status, respHeader, ret, err := g.send(ctx, p.Timeout, method, u, header, body)
+		if retry >= p.MaxRetries || !shouldRetry(ctx, status, ret, err) || (body != nil && !body.replayable) {
+			if err == nil && status == http.StatusNotModified && cached != nil {
+				status, ret, respHeader = http.StatusOK, cached.Body, cached.revalidated(respHeader)
+			}
+			if err == nil && method == http.MethodGet && status == http.StatusOK {
+				g.cacheSet(ctx, path, query, ret, respHeader)
+			}
+			storeResponseHeader(ctx, respHeader)
+			return status, ret, err
+		}
+		if sErr := sleepContext(ctx, p.backoff(retry)); sErr != nil {
+			return status, ret, err
+		}
+	}
+}
+
+// send makes a single attempt at a request
+func (g *getResponseClient) send(ctx context.Context, timeout time.Duration, method string, u *url.URL, header http.Header, body *requestBody) (int, http.Header, []byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var abort context.CancelFunc
+	if g.bodyReadTimeout > 0 {
+		ctx, abort = context.WithCancel(ctx)
+		defer abort()
+	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(body))
+	if g.throttler != nil {
+		err := g.throttler.Wait(ctx)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), nil)
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, err
+	}
+	if body != nil {
+		req.Body, err = body.open()
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		req.ContentLength = body.length
+		if body.replayable {
+			req.GetBody = body.open
+		}
 	}
 
-	req.Header.Set(XAuthTokenHeader, fmt.Sprintf("api-key %s", g.apiKey))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	secret, err := g.authenticate(ctx, req)
+	if err != nil {
+		return 0, nil, nil, &credentialsError{err: err}
+	}
 	req.Header.Set("Content-type", "application/json")
 	if g.domain != "" {
 		req.Header.Set(XDomainHeader, g.domain)
 	}
+	if id, ok := CorrelationIDFromContext(ctx); ok {
+		req.Header.Set(g.correlationIDHeader, id)
+	}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
+	req = req.WithContext(ctx)
+	err = g.sign(req, body)
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return 0, nil, nil, err
 	}
 
+	err = g.callDebugHook(ctx, req)
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return 0, nil, nil, err
+	}
+	g.dumpRequest(req, body == nil || body.data != nil, secret)
 	resp, err := g.c.Do(req)
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, g.cancelled(ctx, err)
 	}
 	defer resp.Body.Close()
+	g.quota.observe(resp.Header)
+	if g.throttler != nil {
+		g.throttler.Observe(resp.StatusCode, resp.Header)
+	}
 
-	ret, err := ioutil.ReadAll(resp.Body)
+	ret, err := readBody(ctx, abort, resp.Body, g.bodyReadTimeout)
+	g.dumpResponse(resp, ret, secret)
+	if errors.Is(err, ErrBodyStalled) {
+		return 0, nil, nil, err
+	}
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, g.cancelled(ctx, err)
+	}
+	if resp.StatusCode >= 400 && secret != "" {
+		// error bodies end up in error messages and logs, and some proxies echo the request back
+		ret = bytes.Replace(ret, []byte(secret), []byte(redactedValue), -1)
 	}
 
-	return resp.StatusCode, ret, nil
+	return resp.StatusCode, resp.Header, ret, nil
 }
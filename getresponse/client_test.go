@@ -2,9 +2,14 @@ package getresponse
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -217,6 +222,7 @@ func TestUnit_GetContact(t *testing.T) {
 				fmt.Fprint(w, `{"not json"`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
 		},
 		testcase{
@@ -226,6 +232,7 @@ func TestUnit_GetContact(t *testing.T) {
 				fmt.Fprint(w, `{"code":1008}`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("1008"),
 		},
 	}
@@ -282,6 +289,7 @@ func TestUnit_UpdateContact(t *testing.T) {
 				fmt.Fprint(w, `{"not json"`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
 		},
 		testcase{
@@ -291,6 +299,7 @@ func TestUnit_UpdateContact(t *testing.T) {
 				fmt.Fprint(w, `{"code":1008}`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("1008"),
 		},
 	}
@@ -316,6 +325,108 @@ func TestUnit_UpdateContact(t *testing.T) {
 	}
 }
 
+func TestUnit_UpdateContactClear(t *testing.T) {
+
+	type testcase struct {
+		name         string
+		request      UpdateContactRequest
+		expectedBody string
+		expectErr    bool
+	}
+
+	testcases := []testcase{
+		{
+			name:         "unchanged fields are omitted",
+			request:      UpdateContactRequest{ID: "foo", NewData: Contact{Note: makeStringPtr("vip")}},
+			expectedBody: `{"note":"vip"}`,
+		},
+		{
+			name:         "cleared fields are null",
+			request:      UpdateContactRequest{ID: "foo", NewData: Contact{Note: makeStringPtr("vip")}, Clear: []string{"name", "dayOfCycle"}},
+			expectedBody: `{"dayOfCycle":null,"name":null,"note":"vip"}`,
+		},
+		{
+			name: "field mask",
+			request: UpdateContactRequest{
+				ID:      "foo",
+				NewData: Contact{Name: makeStringPtr("foobar"), Note: makeStringPtr("vip"), Email: makeStringPtr("foo@bar.baz")},
+				Fields:  []string{"note", "dayOfCycle"},
+			},
+			expectedBody: `{"note":"vip"}`,
+		},
+		{
+			name:      "unknown field",
+			request:   UpdateContactRequest{ID: "foo", Clear: []string{"email", "campaign"}},
+			expectErr: true,
+		},
+		{
+			name:      "unknown masked field",
+			request:   UpdateContactRequest{ID: "foo", Fields: []string{"createdOn"}},
+			expectErr: true,
+		},
+		{
+			name:      "misspelled masked field",
+			request:   UpdateContactRequest{ID: "foo", NewData: Contact{Note: makeStringPtr("vip")}, Fields: []string{"note", "bogus"}},
+			expectErr: true,
+		},
+		{
+			name:      "set and cleared",
+			request:   UpdateContactRequest{ID: "foo", NewData: Contact{Name: makeStringPtr("foobar")}, Clear: []string{"name"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := ""
+			requests := 0
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				raw, _ := ioutil.ReadAll(r.Body)
+				body = string(raw)
+				fmt.Fprint(w, `{"contactId":"foo"}`)
+			}))
+			defer ts.Close()
+
+			_, err := c.Contacts().Update(context.Background(), &tc.request)
+			if tc.expectErr {
+				vErr := &ValidationError{}
+				if !errors.As(err, &vErr) || requests != 0 {
+					t.Fatalf("Expected a validation error without a request, got (%#v, %d requests)", err, requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if body != tc.expectedBody {
+				t.Fatalf("Actual body (%s) did not match expected (%s)", body, tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestUnit_ChangedContactFields(t *testing.T) {
+	before := Contact{
+		ContactID: makeStringPtr("foo"),
+		Name:      makeStringPtr("foobar"),
+		Note:      makeStringPtr("old"),
+		Tags:      []Tag{{TagID: "t1"}},
+		ChangedOn: makeStringPtr("2020-01-01"),
+	}
+	after := before
+	after.Note = makeStringPtr("new")
+	after.Tags = []Tag{{TagID: "t1"}, {TagID: "t2"}}
+	after.DayOfCycle = makeInt32Ptr(0)
+	after.ChangedOn = makeStringPtr("2020-01-02")
+
+	changed := ChangedContactFields(before, after)
+	expected := []string{"dayOfCycle", "note", "tags"}
+	if fmt.Sprint(changed) != fmt.Sprint(expected) {
+		t.Fatalf("Actual fields (%v) did not match expected (%v)", changed, expected)
+	}
+}
+
 func TestUnit_UpdateContactCustomFields(t *testing.T) {
 
 	type testcase struct {
@@ -347,6 +458,7 @@ func TestUnit_UpdateContactCustomFields(t *testing.T) {
 				fmt.Fprint(w, `{"not json"`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
 		},
 		testcase{
@@ -356,6 +468,7 @@ func TestUnit_UpdateContactCustomFields(t *testing.T) {
 				fmt.Fprint(w, `{"message":1008}`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("1008"),
 		},
 	}
@@ -410,6 +523,7 @@ func TestUnit_DeleteContact(t *testing.T) {
 				fmt.Fprint(w, `{"not json"`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("ERROR_DECODING_ERROR"),
 		},
 		testcase{
@@ -419,6 +533,7 @@ func TestUnit_DeleteContact(t *testing.T) {
 				fmt.Fprint(w, `{"message":1008}`)
 			}),
 			ctx:             context.Background(),
+			id:              "foo",
 			expectedErrCode: makeStringPtr("1008"),
 		},
 	}
@@ -439,3 +554,360 @@ func TestUnit_DeleteContact(t *testing.T) {
 		})
 	}
 }
+
+func TestUnit_UnsubscribeAndRemoveContact(t *testing.T) {
+
+	type testcase struct {
+		name          string
+		call          func(Client) error
+		expectedQuery string
+		expectErr     bool
+	}
+
+	testcases := []testcase{
+		{
+			name: "unsubscribe",
+			call: func(c Client) error {
+				return c.UnsubscribeContact(context.Background(), &UnsubscribeContactRequest{ID: "123", MessageID: "m1", IPAddress: "127.0.0.1"})
+			},
+			expectedQuery: "ipAddress=127.0.0.1&messageId=m1",
+		},
+		{
+			name: "unsubscribe without message",
+			call: func(c Client) error {
+				return c.UnsubscribeContact(context.Background(), &UnsubscribeContactRequest{ID: "123"})
+			},
+			expectErr: true,
+		},
+		{
+			name: "remove",
+			call: func(c Client) error {
+				return c.RemoveContact(context.Background(), &RemoveContactRequest{ID: "123"})
+			},
+			expectedQuery: "",
+		},
+		{
+			name: "remove without id",
+			call: func(c Client) error {
+				return c.RemoveContact(context.Background(), &RemoveContactRequest{})
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []string
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path+" "+r.URL.RawQuery)
+			}))
+			defer ts.Close()
+
+			err := tc.call(c)
+			if tc.expectErr {
+				vErr := &ValidationError{}
+				if !errors.As(err, &vErr) || len(requests) != 0 {
+					t.Fatalf("Expected a validation error and no request, got (%#v, %v)", err, requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			expected := "DELETE /v3/contacts/123 " + tc.expectedQuery
+			if len(requests) != 1 || requests[0] != expected {
+				t.Fatalf("Actual requests (%v) did not match expected (%s)", requests, expected)
+			}
+		})
+	}
+}
+
+func TestUnit_ErrorUnwrap(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"not json"`)
+	}))
+	defer ts.Close()
+
+	_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	if !errors.Is(err, ErrCouldNotUnmarshal) {
+		t.Fatalf("Expected error to wrap ErrCouldNotUnmarshal, got (%#v)", err)
+	}
+	raw := &GetResponseErrorRaw{}
+	if !errors.As(err, &raw) || raw.HTTPStatus != http.StatusOK {
+		t.Fatalf("Expected a GetResponseErrorRaw, got (%#v)", err)
+	}
+
+	wrapped := &APIError{
+		Method:     http.MethodGet,
+		Path:       "/v3/contacts/foo",
+		HTTPStatus: http.StatusBadGateway,
+		Body:       []byte("<html>"),
+		Err:        fmt.Errorf("decoding: %w", &GetResponseErrorRaw{HTTPStatus: http.StatusBadGateway, Err: ErrCouldNotUnmarshal}),
+	}
+	if !strings.HasSuffix(wrapped.Error(), `: body "<html>"`) {
+		t.Fatalf("Expected the body of a wrapped raw error to be printed, got (%s)", wrapped.Error())
+	}
+}
+
+func TestUnit_APIError(t *testing.T) {
+
+	type testcase struct {
+		name           string
+		handler        http.HandlerFunc
+		opts           []Option
+		expectedStatus int
+		expectedCode   int
+		expectedUUID   string
+		expectedBody   string
+		expectedMsg    string
+	}
+
+	testcases := []testcase{
+		{
+			name: "error response",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"httpStatus":404,"code":1013,"message":"Contact not found","uuid":"abc-123"}`)
+			}),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   ErrResourceNotFound,
+			expectedUUID:   "abc-123",
+			expectedBody:   `{"httpStatus":404,"code":1013,"message":"Contact not found","uuid":"abc-123"}`,
+			expectedMsg:    "GET /v3/contacts/foo: 404: code 1013 resource not found (uuid abc-123): Contact not found",
+		},
+		{
+			name: "undocumented code",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"httpStatus":400,"code":1099,"codeDescription":"Nieznany błąd","message":"Coś poszło nie tak"}`)
+			}),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   1099,
+			expectedBody:   `{"httpStatus":400,"code":1099,"codeDescription":"Nieznany błąd","message":"Coś poszło nie tak"}`,
+			expectedMsg:    "GET /v3/contacts/foo: 400: code 1099 Nieznany błąd: Coś poszło nie tak",
+		},
+		{
+			name: "truncated raw body",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+				fmt.Fprint(w, `<html>bad gateway</html>`)
+			}),
+			opts:           []Option{WithErrorBodyLimit(6)},
+			expectedStatus: http.StatusBadGateway,
+			expectedBody:   `<html>`,
+			expectedMsg:    `GET /v3/contacts/foo: 502: invalid character '<' looking for beginning of value: body "<html>"...`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(tc.handler)
+			defer ts.Close()
+			c := NewClient(ts.URL, "", "", nil, tc.opts...)
+
+			_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+			apiErr := &APIError{}
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected an APIError, got (%#v)", err)
+			}
+			if apiErr.Method != http.MethodGet || apiErr.Path != "/v3/contacts/foo" || apiErr.HTTPStatus != tc.expectedStatus ||
+				apiErr.ErrorCode != tc.expectedCode || apiErr.UUID != tc.expectedUUID || string(apiErr.Body) != tc.expectedBody {
+				t.Fatalf("Unexpected APIError (%#v)", apiErr)
+			}
+			if err.Error() != tc.expectedMsg {
+				t.Fatalf("Actual message (%s) did not match expected (%s)", err.Error(), tc.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestUnit_ErrorContextShapes(t *testing.T) {
+	type testcase struct {
+		name     string
+		context  string
+		expected []string
+	}
+	testcases := []testcase{
+		{name: "strings", context: `["email","name"]`, expected: []string{"email", "name"}},
+		{name: "objects", context: `[{"field":"email","error":"invalid"}]`, expected: []string{`{"field":"email","error":"invalid"}`}},
+		{name: "object", context: `{"field":"email"}`, expected: []string{`{"field":"email"}`}},
+		{name: "missing", context: `null`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, `{"httpStatus":400,"code":1000,"message":"Validation error","context":%s}`, tc.context)
+			}))
+			defer ts.Close()
+
+			_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+			grErr := &GetResponseError{}
+			if !errors.As(err, &grErr) {
+				t.Fatalf("Expected a GetResponseError, got (%#v)", err)
+			}
+			if grErr.Message != "Validation error" {
+				t.Fatalf("Actual message (%s) did not match expected (%s)", grErr.Message, "Validation error")
+			}
+			if actual := grErr.Context.Strings(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("Actual context (%q) did not match expected (%q)", actual, tc.expected)
+			}
+		})
+	}
+
+	grErr := &GetResponseError{}
+	err := json.Unmarshal([]byte(`{"code":1000,"context":[{"field":"email"}]}`), grErr)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	var fields []struct{ Field string }
+	if err := grErr.Context.Decode(&fields); err != nil || len(fields) != 1 || fields[0].Field != "email" {
+		t.Fatalf("Actual decoded context (%v, %v) did not match expected", fields, err)
+	}
+	out, _ := json.Marshal(grErr)
+	if !strings.Contains(string(out), `"context":[{"field":"email"}]`) {
+		t.Fatalf("Context was not preserved when marshaling (%s)", out)
+	}
+}
+
+func TestUnit_ErrorCodeDescription(t *testing.T) {
+	if d := ErrorCodeDescription(ErrTemporarilyBlocked); d != "temporarily blocked" {
+		t.Fatalf("Actual description (%s) did not match expected (%s)", d, "temporarily blocked")
+	}
+	if d := ErrorCodeDescription(1099); d != "" {
+		t.Fatalf("Expected no description for an unknown code, got (%s)", d)
+	}
+}
+
+func TestUnit_UpdateCampaignSettings(t *testing.T) {
+	body := ""
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			raw, _ := ioutil.ReadAll(r.Body)
+			body = string(raw)
+		}
+		fmt.Fprint(w, `{"campaignId":"c1","name":"news","optinTypes":{"api":"double","import":"single"},
+			"subscriptionNotifications":{"status":"enabled","recipients":[{"fromFieldId":"f1"}]},
+			"postal":{"addPostalToMessages":"true","city":"Gdansk"}}`)
+	}))
+	defer ts.Close()
+
+	double := "double"
+	req := &UpdateCampaignSettingsRequest{
+		ID: "c1",
+		Settings: CampaignSettings{
+			IsDefault:                 makeStringPtr("true"),
+			OptinTypes:                &OptinTypes{API: double},
+			SubscriptionNotifications: &SubscriptionNotifications{Status: NotificationsEnabled, Recipients: []FromFieldRef{{FromFieldID: "f1"}}},
+		},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	campaign, err := c.UpdateCampaignSettings(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := `{"isDefault":"true","optinTypes":{"api":"double"},"subscriptionNotifications":{"status":"enabled","recipients":[{"fromFieldId":"f1"}]}}`
+	if body != expected {
+		t.Fatalf("Actual body (%s) did not match expected (%s)", body, expected)
+	}
+	if campaign.OptinTypes.Import != OptinSingle || *campaign.Postal.City != "Gdansk" {
+		t.Fatalf("Unexpected campaign (%#v)", campaign)
+	}
+
+	req.Settings.OptinTypes.API = "triple"
+	vErr := &ValidationError{}
+	if err = req.Validate(); !errors.As(err, &vErr) || vErr.Field != "optinTypes.api" {
+		t.Fatalf("Expected a validation error, got (%#v)", err)
+	}
+}
+
+func TestUnit_ResourceClients(t *testing.T) {
+	var paths []string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/v3/contacts/foo":
+			fmt.Fprint(w, `{"contactId":"foo"}`)
+		case "/v3/campaigns/c":
+			fmt.Fprint(w, `{"campaignId":"c"}`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	res, err := c.Contacts().Get(ctx, &GetContactRequest{ID: "foo"})
+	if err != nil || *res.Contact.ContactID != "foo" {
+		t.Fatalf("Unexpected result (%#v, %#v)", res, err)
+	}
+	campaign, err := c.Campaigns().Get(ctx, &GetCampaignRequest{ID: "c"})
+	if err != nil || campaign.CampaignID != "c" {
+		t.Fatalf("Unexpected result (%#v, %#v)", campaign, err)
+	}
+	calls := []func() error{
+		func() error { _, err := c.CustomFields().List(ctx, &GetCustomFieldsRequest{}); return err },
+		func() error { _, err := c.Tags().List(ctx, &GetTagsRequest{}); return err },
+		func() error { _, err := c.FromFields().List(ctx, &GetFromFieldsRequest{}); return err },
+		func() error { _, err := c.Newsletters().List(ctx, &GetNewslettersRequest{}); return err },
+	}
+	for _, call := range calls {
+		if err := call(); err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+
+	expected := []string{"GET /v3/contacts/foo", "GET /v3/campaigns/c", "GET /v3/custom-fields", "GET /v3/tags", "GET /v3/from-fields", "GET /v3/newsletters"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Fatalf("Actual requests (%v) did not match expected (%v)", paths, expected)
+	}
+}
+
+func TestUnit_InvalidResourceIDs(t *testing.T) {
+	called := false
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer ts.Close()
+
+	for _, id := range []string{"", "a/b", "../campaigns", "a?fields=email", "a#b", "a b", "a%2Fb"} {
+		_, err := c.Contacts().Get(context.Background(), &GetContactRequest{ID: id})
+		vErr := &ValidationError{}
+		if !errors.As(err, &vErr) || vErr.Field != "id" {
+			t.Fatalf("Expected a validation error for %q, got (%#v)", id, err)
+		}
+		_, err = c.Campaigns().Get(context.Background(), &GetCampaignRequest{ID: id})
+		if !errors.As(err, &vErr) {
+			t.Fatalf("Expected a validation error for %q, got (%#v)", id, err)
+		}
+	}
+	if called {
+		t.Fatalf("Requests with invalid ids must not be sent")
+	}
+}
+
+func TestUnit_OmitUnsetQueryValues(t *testing.T) {
+	var queries []string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `[]`)
+	}))
+	defer ts.Close()
+
+	_, err := c.Contacts().List(context.Background(), &GetContactsRequest{QueryHash: map[string]string{"email": "", "campaignId": "c"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = c.DeleteContact(context.Background(), &DeleteContactRequest{ID: "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []string{"query%5BcampaignId%5D=c", ""}
+	if fmt.Sprint(queries) != fmt.Sprint(expected) {
+		t.Fatalf("Actual queries (%q) did not match expected (%q)", queries, expected)
+	}
+}
@@ -0,0 +1,98 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a debug writer that can be written from several goroutines
+type lockedBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (l *lockedBuffer) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.Write(p)
+}
+
+// TestUnit_ConcurrentUse shares clients between goroutines, run it with -race
+func TestUnit_ConcurrentUse(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set(XRateLimitLimitHeader, "30000")
+		w.Header().Set(XRateLimitRemainingHeader, fmt.Sprint(30000-n))
+		w.Header().Set(XRateLimitResetHeader, "600 seconds")
+		w.Header().Set("ETag", `"v1"`)
+		switch {
+		case r.URL.Path == "/v3/contacts":
+			fmt.Fprint(w, `[{"contactId":"c1","name":"foobar","email":"foo@example.com"}]`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"contactId":"c1","name":"foobar","email":"foo@example.com"}`)
+		default:
+			fmt.Fprint(w, `{"contactId":"c1"}`)
+		}
+	}))
+	defer ts.Close()
+
+	throttler := NewThrottler(time.Second)
+	cache := NewLRUCache(16)
+	debug := &lockedBuffer{}
+	clients := []Client{
+		NewClient(ts.URL, "key", "", nil, WithThrottler(throttler), WithCache(cache, time.Millisecond), WithDebug(debug)),
+		NewClient(ts.URL, "key", "", nil, WithThrottler(throttler), WithCache(cache, time.Millisecond),
+			WithDefaultPolicy(Policy{MaxRetries: 1, Backoff: time.Millisecond})),
+	}
+
+	const goroutines = 32
+	const iterations = 20
+	errs := make(chan error, goroutines*iterations)
+	wg := sync.WaitGroup{}
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := clients[i%len(clients)]
+			ctx := ContextWithCorrelationID(context.Background(), fmt.Sprintf("req-%d", i))
+			for j := 0; j < iterations; j++ {
+				var err error
+				switch (i + j) % 4 {
+				case 0:
+					_, err = c.GetContact(ctx, &GetContactRequest{ID: "c1"})
+				case 1:
+					name := fmt.Sprintf("name %d", j)
+					_, err = c.UpdateContact(ctx, &UpdateContactRequest{ID: "c1", NewData: Contact{Name: &name}})
+				case 2:
+					_, err = c.GetContacts(ctx, &GetContactsRequest{Page: int32(j + 1), PerPage: 10})
+				case 3:
+					if j%2 == 0 {
+						c.SetDebug(nil)
+					} else {
+						c.SetDebug(debug)
+					}
+				}
+				if err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("Unexpected error occurred (%s)", err)
+	}
+	if atomic.LoadInt32(&calls) == 0 {
+		t.Fatalf("Expected requests to reach the server")
+	}
+}
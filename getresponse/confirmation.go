@@ -0,0 +1,65 @@
+package getresponse
+
+import (
+	"context"
+	"strings"
+)
+
+// ConfirmationStatus is where a contact is in the double opt-in flow
+type ConfirmationStatus string
+
+const (
+	// ConfirmationConfirmed contacts are subscribed, either confirmed or added to a single opt-in campaign
+	ConfirmationConfirmed ConfirmationStatus = "confirmed"
+	// ConfirmationPending contacts were not found in a campaign requiring double opt-in, so they have not confirmed
+	// (yet) or were never added
+	ConfirmationPending ConfirmationStatus = "pending"
+	// ConfirmationNotFound contacts are not in the campaign, which does not require confirmation
+	ConfirmationNotFound ConfirmationStatus = "not_found"
+)
+
+// getContactConfirmation reports whether the contact confirmed its subscription to the campaign. The API does not
+// list unconfirmed contacts, nor offer a way to resend the confirmation message, so a pending contact can only be
+// told apart from one that was never added by the caller.
+func (g *getResponseClient) getContactConfirmation(ctx context.Context, email, campaignID string) (ConfirmationStatus, error) {
+	if email == "" {
+		return "", &ValidationError{Field: "email", Message: "is required"}
+	}
+	if campaignID == "" {
+		return "", &ValidationError{Field: "campaignId", Message: "is required"}
+	}
+
+	res, err := g.getContacts(withoutCache(ctx), &GetContactsRequest{
+		QueryHash:       map[string]string{"email": email, "campaignId": campaignID},
+		Page:            1,
+		PerPage:         100,
+		AdditionalFlags: ContactFlags(FlagExactMatch),
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, c := range res.Contacts {
+		if c.Email != nil && strings.EqualFold(*c.Email, email) {
+			return ConfirmationConfirmed, nil
+		}
+	}
+
+	double, err := g.campaignRequiresAPIConfirmation(ctx, campaignID)
+	if err != nil {
+		return "", err
+	}
+	if double {
+		return ConfirmationPending, nil
+	}
+	return ConfirmationNotFound, nil
+}
+
+// campaignRequiresAPIConfirmation reports whether contacts added to the campaign through the API
+// must confirm their subscription before they show up in the contact list
+func (g *getResponseClient) campaignRequiresAPIConfirmation(ctx context.Context, campaignID string) (bool, error) {
+	c, err := g.getCampaign(ctx, &GetCampaignRequest{ID: campaignID})
+	if err != nil {
+		return false, err
+	}
+	return c.OptinTypes != nil && c.OptinTypes.API == OptinDouble, nil
+}
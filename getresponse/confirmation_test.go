@@ -0,0 +1,66 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_GetContactConfirmation(t *testing.T) {
+
+	type testcase struct {
+		name           string
+		contacts       string
+		optin          string
+		expectedStatus ConfirmationStatus
+	}
+
+	testcases := []testcase{
+		{
+			name:           "listed contact",
+			contacts:       `[{"contactId":"c1","email":"Foo@example.com"}]`,
+			optin:          OptinDouble,
+			expectedStatus: ConfirmationConfirmed,
+		},
+		{
+			name:           "double opt-in campaign",
+			contacts:       `[]`,
+			optin:          OptinDouble,
+			expectedStatus: ConfirmationPending,
+		},
+		{
+			name:           "single opt-in campaign",
+			contacts:       `[]`,
+			optin:          OptinSingle,
+			expectedStatus: ConfirmationNotFound,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v3/contacts":
+					if r.URL.Query().Get("query[campaignId]") != "123" {
+						t.Errorf("Unexpected query (%s)", r.URL.RawQuery)
+					}
+					fmt.Fprint(w, tc.contacts)
+				case "/v3/campaigns/123":
+					fmt.Fprintf(w, `{"campaignId":"123","optinTypes":{"api":"%s"}}`, tc.optin)
+				default:
+					t.Errorf("Unexpected request %s", r.URL.Path)
+				}
+			}))
+			defer ts.Close()
+
+			status, err := c.GetContactConfirmation(context.Background(), "foo@example.com", "123")
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if status != tc.expectedStatus {
+				t.Fatalf("Actual status (%s) did not match expected (%s)", status, tc.expectedStatus)
+			}
+		})
+	}
+}
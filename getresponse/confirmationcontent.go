@@ -0,0 +1,91 @@
+package getresponse
+
+import (
+	"context"
+	"net/url"
+)
+
+// ConfirmationSubject is a subject available for the confirmation message of double opt-in campaigns
+type ConfirmationSubject struct {
+	SubscriptionConfirmationSubjectID string  `json:"subscriptionConfirmationSubjectId"`
+	Href                              *string `json:"href,omitempty"`
+	Subject                           string  `json:"subject"`
+	IsDefault                         bool    `json:"isDefault,omitempty"`
+	CreatedOn                         *string `json:"createdOn,omitempty"`
+}
+
+// ConfirmationBody is a body available for the confirmation message of double opt-in campaigns
+type ConfirmationBody struct {
+	SubscriptionConfirmationBodyID string  `json:"subscriptionConfirmationBodyId"`
+	Href                           *string `json:"href,omitempty"`
+	Name                           string  `json:"name,omitempty"`
+	ContentPlain                   string  `json:"contentPlain,omitempty"`
+	CreatedOn                      *string `json:"createdOn,omitempty"`
+}
+
+// ConfirmationContent holds the confirmation subjects and bodies of a language
+type ConfirmationContent struct {
+	LanguageCode string
+	Subjects     []ConfirmationSubject
+	Bodies       []ConfirmationBody
+}
+
+// Confirmation returns the settings selecting the default subject and the first body of the language, for the
+// Confirmation of CampaignSettings. It is nil when the language has no subject or no body.
+func (c *ConfirmationContent) Confirmation() *CampaignConfirmation {
+	if len(c.Subjects) == 0 || len(c.Bodies) == 0 {
+		return nil
+	}
+	subject := c.Subjects[0]
+	for _, s := range c.Subjects {
+		if s.IsDefault {
+			subject = s
+			break
+		}
+	}
+	return &CampaignConfirmation{
+		SubscriptionConfirmationSubjectID: &subject.SubscriptionConfirmationSubjectID,
+		SubscriptionConfirmationBodyID:    &c.Bodies[0].SubscriptionConfirmationBodyID,
+	}
+}
+
+func (g *getResponseClient) getConfirmationContent(ctx context.Context, languageCodes []string) (map[string]*ConfirmationContent, error) {
+	ctx = withOperation(ctx, OpGetConfirmationContent)
+
+	ret := make(map[string]*ConfirmationContent, len(languageCodes))
+	for _, code := range languageCodes {
+		if _, ok := ret[code]; ok {
+			continue
+		}
+		if !resourceIDPattern.MatchString(code) {
+			return nil, &ValidationError{Field: "languageCode", Message: code + " is not a valid language code"}
+		}
+		content := &ConfirmationContent{LanguageCode: code}
+		var err error
+		content.Subjects, _, err = doGet[[]ConfirmationSubject](ctx, g, "/v3/subscription-confirmations/subject/"+url.PathEscape(code), nil)
+		if err != nil {
+			return nil, err
+		}
+		content.Bodies, _, err = doGet[[]ConfirmationBody](ctx, g, "/v3/subscription-confirmations/body/"+url.PathEscape(code), nil)
+		if err != nil {
+			return nil, err
+		}
+		ret[code] = content
+	}
+	return ret, nil
+}
+
+func (g *getResponseClient) localizeConfirmation(ctx context.Context, campaignID, languageCode string) (*Campaign, error) {
+	contents, err := g.getConfirmationContent(ctx, []string{languageCode})
+	if err != nil {
+		return nil, err
+	}
+	confirmation := contents[languageCode].Confirmation()
+	if confirmation == nil {
+		return nil, &ValidationError{Field: "languageCode", Message: "has no confirmation subject or body: " + languageCode}
+	}
+	return g.updateCampaignSettings(ctx, &UpdateCampaignSettingsRequest{
+		ID:       campaignID,
+		Settings: CampaignSettings{Confirmation: confirmation},
+	})
+}
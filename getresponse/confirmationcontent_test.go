@@ -0,0 +1,53 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_ConfirmationContent(t *testing.T) {
+	var update string
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/subscription-confirmations/subject/PL":
+			fmt.Fprint(w, `[{"subscriptionConfirmationSubjectId":"s1","subject":"Potwierdź"},{"subscriptionConfirmationSubjectId":"s2","subject":"Potwierdź subskrypcję","isDefault":true}]`)
+		case "/v3/subscription-confirmations/body/PL":
+			fmt.Fprint(w, `[{"subscriptionConfirmationBodyId":"b1","name":"Domyślna"}]`)
+		case "/v3/subscription-confirmations/subject/DE", "/v3/subscription-confirmations/body/DE":
+			fmt.Fprint(w, `[]`)
+		case "/v3/campaigns/c":
+			body, _ := ioutil.ReadAll(r.Body)
+			update = string(body)
+			fmt.Fprint(w, `{"campaignId":"c"}`)
+		default:
+			t.Errorf("Unexpected request (%s)", r.URL.Path)
+		}
+	})
+	defer ts.Close()
+	ctx := context.Background()
+
+	contents, err := c.Campaigns().ConfirmationContent(ctx, "PL", "DE", "PL")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(contents) != 2 || len(contents["PL"].Subjects) != 2 || contents["DE"].Confirmation() != nil {
+		t.Fatalf("Unexpected contents (%#v)", contents)
+	}
+
+	_, err = c.Campaigns().LocalizeConfirmation(ctx, "c", "PL")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := `{"confirmation":{"subscriptionConfirmationBodyId":"b1","subscriptionConfirmationSubjectId":"s2"}}`
+	if update != expected {
+		t.Fatalf("Actual update (%s) did not match expected (%s)", update, expected)
+	}
+
+	_, err = c.Campaigns().LocalizeConfirmation(ctx, "c", "DE")
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Expected a validation error, got (%#v)", err)
+	}
+}
@@ -0,0 +1,102 @@
+package getresponse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContactField names a field of the contacts returned by GetContacts and GetContact, so a typo is a compile error:
+//
+//	Fields: getresponse.ContactFields(getresponse.FieldEmail, getresponse.FieldCampaign)
+type ContactField string
+
+// Contact fields
+const (
+	FieldContactID         ContactField = "contactId"
+	FieldHref              ContactField = "href"
+	FieldName              ContactField = "name"
+	FieldEmail             ContactField = "email"
+	FieldNote              ContactField = "note"
+	FieldDayOfCycle        ContactField = "dayOfCycle"
+	FieldOrigin            ContactField = "origin"
+	FieldCreatedOn         ContactField = "createdOn"
+	FieldChangedOn         ContactField = "changedOn"
+	FieldCampaign          ContactField = "campaign"
+	FieldGeolocation       ContactField = "geolocation"
+	FieldTags              ContactField = "tags"
+	FieldCustomFieldValues ContactField = "customFieldValues"
+	FieldTimeZone          ContactField = "timeZone"
+	FieldIPAddress         ContactField = "ipAddress"
+	FieldActivities        ContactField = "activities"
+	FieldScoring           ContactField = "scoring"
+	FieldEngagementScore   ContactField = "engagementScore"
+)
+
+var contactFields = map[ContactField]bool{
+	FieldContactID: true, FieldHref: true, FieldName: true, FieldEmail: true, FieldNote: true, FieldDayOfCycle: true,
+	FieldOrigin: true, FieldCreatedOn: true, FieldChangedOn: true, FieldCampaign: true, FieldGeolocation: true,
+	FieldTags: true, FieldCustomFieldValues: true, FieldTimeZone: true, FieldIPAddress: true,
+	FieldActivities: true, FieldScoring: true, FieldEngagementScore: true,
+}
+
+// ContactFields returns fields as the Fields of a GetContactsRequest or GetContactRequest
+func ContactFields(fields ...ContactField) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = string(f)
+	}
+	return names
+}
+
+// ContactFlag is an additional flag of GetContacts
+type ContactFlag string
+
+// Additional flags of GetContacts
+const (
+	FlagExactMatch        ContactFlag = "exactMatch"        // match the query values exactly instead of as substrings
+	FlagForceCustomFields ContactFlag = "forceCustomFields" // include the custom field values
+)
+
+var contactFlags = map[ContactFlag]bool{FlagExactMatch: true, FlagForceCustomFields: true}
+
+// ContactFlags returns flags as the AdditionalFlags of a GetContactsRequest
+func ContactFlags(flags ...ContactFlag) *string {
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = string(f)
+	}
+	joined := strings.Join(names, ",")
+	return &joined
+}
+
+// withContactFlag returns flags with flag added when it is not set already
+func withContactFlag(flags *string, flag ContactFlag) *string {
+	if flags == nil || *flags == "" {
+		return ContactFlags(flag)
+	}
+	for _, f := range strings.Split(*flags, ",") {
+		if ContactFlag(f) == flag {
+			return flags
+		}
+	}
+	joined := *flags + "," + string(flag)
+	return &joined
+}
+
+// validateContactFields rejects the fields and flags the API does not know, which it would otherwise ignore
+func validateContactFields(fields []string, flags *string) error {
+	for _, f := range fields {
+		if !contactFields[ContactField(f)] {
+			return &ValidationError{Field: "fields", Message: fmt.Sprintf("has unknown contact field %q", f)}
+		}
+	}
+	if flags == nil || *flags == "" {
+		return nil
+	}
+	for _, f := range strings.Split(*flags, ",") {
+		if !contactFlags[ContactFlag(strings.TrimSpace(f))] {
+			return &ValidationError{Field: "additionalFlags", Message: fmt.Sprintf("has unknown flag %q", f)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_ContactFields(t *testing.T) {
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("fields") != "email,campaign" || q.Get("additionalFlags") != "exactMatch,forceCustomFields" {
+			t.Errorf("Unexpected query (%v)", q)
+		}
+		fmt.Fprint(w, `[]`)
+	})
+	defer ts.Close()
+
+	_, err := c.Contacts().List(context.Background(), &GetContactsRequest{
+		Fields:          ContactFields(FieldEmail, FieldCampaign),
+		AdditionalFlags: ContactFlags(FlagExactMatch, FlagForceCustomFields),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	type testcase struct {
+		request *GetContactsRequest
+		field   string
+	}
+	exact := "exactMatch,exactMacth"
+	for _, tc := range []testcase{
+		{&GetContactsRequest{Fields: []string{"email", "campaing"}}, "fields"},
+		{&GetContactsRequest{AdditionalFlags: &exact}, "additionalFlags"},
+	} {
+		_, err := c.Contacts().List(context.Background(), tc.request)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || verr.Field != tc.field {
+			t.Fatalf("Actual error (%#v) did not match expected field (%v)", err, tc.field)
+		}
+	}
+	_, err = c.Contacts().Get(context.Background(), &GetContactRequest{ID: "a", Fields: []string{"emial"}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Expected a validation error, got (%#v)", err)
+	}
+}
+
+func TestUnit_ContactFieldsDocumented(t *testing.T) {
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	defer ts.Close()
+
+	// every contact field the API documents is accepted, not only the ones Contact decodes
+	_, err := c.Contacts().List(context.Background(), &GetContactsRequest{Fields: []string{"email", "engagementScore"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+}
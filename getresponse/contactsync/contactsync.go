@@ -0,0 +1,322 @@
+// Package contactsync computes and applies the create, update and delete operations that make a campaign's contacts
+// match a desired set.
+//
+//	s := &contactsync.Syncer{Client: client, CampaignID: "123", DeleteMissing: true}
+//	plan, err := s.Plan(ctx, desired)
+//	...
+//	fmt.Print(plan) // preview
+//	res, err := s.Apply(ctx, plan)
+package contactsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+const listPerPage = 100
+
+// Desired is the state a contact should be in. Nil fields are left alone: Name and Tags are only compared when set,
+// and only the custom fields listed in CustomFields are compared.
+type Desired struct {
+	Email        string
+	Name         *string
+	CustomFields map[string][]string // custom field id -> values
+	Tags         []string            // tag ids, the contact ends up with exactly these tags when not nil
+}
+
+// ActionType is the kind of change an Action makes
+type ActionType string
+
+const (
+	ActionCreate ActionType = "create"
+	ActionUpdate ActionType = "update"
+	ActionDelete ActionType = "delete"
+)
+
+// Action is a single change of a Plan
+type Action struct {
+	Type      ActionType
+	Email     string
+	ContactID string   // empty for creates
+	Changes   []string // names of the fields that differ, for updates
+
+	create       *getresponse.CreateContactRequest
+	update       *getresponse.Contact
+	fields       []string // field mask of update, so an empty tag list is sent
+	customFields []getresponse.CustomField
+}
+
+func (a Action) String() string {
+	switch a.Type {
+	case ActionUpdate:
+		return fmt.Sprintf("%s %s (%s): %s", a.Type, a.Email, a.ContactID, strings.Join(a.Changes, ", "))
+	case ActionDelete:
+		return fmt.Sprintf("%s %s (%s)", a.Type, a.Email, a.ContactID)
+	}
+	return fmt.Sprintf("%s %s", a.Type, a.Email)
+}
+
+// Plan lists the actions Apply will perform
+type Plan struct {
+	Actions []Action
+}
+
+// String renders the plan for previews, one action per line
+func (p *Plan) String() string {
+	b := &strings.Builder{}
+	for _, a := range p.Actions {
+		b.WriteString(a.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Result reports what Apply did
+type Result struct {
+	Applied int
+	Errors  []ActionError
+}
+
+// ActionError is an action that failed to apply
+type ActionError struct {
+	Action Action
+	Err    error
+}
+
+func (e ActionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Action.String(), e.Err.Error())
+}
+
+// Syncer syncs the contacts of one campaign
+type Syncer struct {
+	Client     getresponse.Client
+	CampaignID string
+	// DeleteMissing removes contacts of the campaign that are not in the desired set
+	DeleteMissing bool
+}
+
+// Plan compares the desired contacts with the campaign and returns the actions needed to reconcile them.
+// It only reads from the API.
+func (s *Syncer) Plan(ctx context.Context, desired []Desired) (*Plan, error) {
+	current, err := s.currentContacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	wanted := map[string]bool{}
+	for _, d := range desired {
+		key := strings.ToLower(d.Email)
+		if wanted[key] {
+			return nil, fmt.Errorf("contactsync: %s is listed more than once", d.Email)
+		}
+		wanted[key] = true
+
+		c, ok := current[key]
+		if !ok {
+			plan.Actions = append(plan.Actions, s.createAction(d))
+			continue
+		}
+
+		if d.CustomFields != nil || d.Tags != nil {
+			// the list endpoint leaves out custom fields and tags
+			res, err := s.Client.Contacts().Get(ctx, &getresponse.GetContactRequest{ID: *c.ContactID})
+			if err != nil {
+				return nil, err
+			}
+			c = res.Contact
+		}
+		if a, changed := updateAction(d, c); changed {
+			plan.Actions = append(plan.Actions, a)
+		}
+	}
+
+	if s.DeleteMissing {
+		emails := make([]string, 0, len(current))
+		for key := range current {
+			if !wanted[key] {
+				emails = append(emails, key)
+			}
+		}
+		sort.Strings(emails)
+		for _, key := range emails {
+			c := current[key]
+			plan.Actions = append(plan.Actions, Action{Type: ActionDelete, Email: *c.Email, ContactID: *c.ContactID})
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply performs the plan's actions in order. Failed actions are reported in the result and do not stop the others.
+func (s *Syncer) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	res := &Result{}
+	for _, a := range plan.Actions {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+
+		err := s.apply(ctx, a)
+		if err != nil {
+			res.Errors = append(res.Errors, ActionError{Action: a, Err: err})
+			continue
+		}
+		res.Applied++
+	}
+	return res, nil
+}
+
+func (s *Syncer) apply(ctx context.Context, a Action) error {
+	switch a.Type {
+	case ActionCreate:
+		return s.Client.Contacts().Create(ctx, a.create)
+	case ActionDelete:
+		return s.Client.Contacts().Remove(ctx, &getresponse.RemoveContactRequest{ID: a.ContactID})
+	}
+
+	if a.update != nil {
+		_, err := s.Client.Contacts().Update(ctx, &getresponse.UpdateContactRequest{ID: a.ContactID, NewData: *a.update, Fields: a.fields})
+		if err != nil {
+			return err
+		}
+	}
+	if len(a.customFields) > 0 {
+		_, err := s.Client.Contacts().UpdateCustomFields(ctx, &getresponse.UpdateContactCustomFieldsRequest{ID: a.ContactID, CustomFields: a.customFields})
+		return err
+	}
+	return nil
+}
+
+func (s *Syncer) currentContacts(ctx context.Context) (map[string]getresponse.Contact, error) {
+	current := map[string]getresponse.Contact{}
+	req := &getresponse.GetContactsRequest{
+		QueryHash: map[string]string{"campaignId": s.CampaignID},
+		Page:      1,
+		PerPage:   listPerPage,
+	}
+	for {
+		res, err := s.Client.Contacts().List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range res.Contacts {
+			if c.Email != nil && c.ContactID != nil {
+				current[strings.ToLower(*c.Email)] = c
+			}
+		}
+		if len(res.Contacts) < listPerPage {
+			return current, nil
+		}
+		req.Page++
+	}
+}
+
+func (s *Syncer) createAction(d Desired) Action {
+	req := &getresponse.CreateContactRequest{
+		Email:        d.Email,
+		Name:         d.Name,
+		Campaign:     getresponse.Campaign{CampaignID: s.CampaignID},
+		CustomFields: customFieldValues(d.CustomFields),
+	}
+	for _, id := range d.Tags {
+		req.Tags = append(req.Tags, getresponse.Tag{TagID: id})
+	}
+	return Action{Type: ActionCreate, Email: d.Email, create: req}
+}
+
+func updateAction(d Desired, c getresponse.Contact) (Action, bool) {
+	a := Action{Type: ActionUpdate, Email: d.Email, ContactID: *c.ContactID}
+	update := getresponse.Contact{}
+
+	if d.Name != nil && (c.Name == nil || *c.Name != *d.Name) {
+		update.Name = d.Name
+		a.Changes = append(a.Changes, "name")
+		a.fields = append(a.fields, "name")
+		a.update = &update
+	}
+
+	if d.Tags != nil && !sameTags(d.Tags, c.Tags) {
+		update.Tags = []getresponse.Tag{}
+		for _, id := range d.Tags {
+			update.Tags = append(update.Tags, getresponse.Tag{TagID: id})
+		}
+		a.Changes = append(a.Changes, "tags")
+		a.fields = append(a.fields, "tags")
+		a.update = &update
+	}
+
+	currentFields := map[string][]string{}
+	for _, f := range c.CustomFieldValues {
+		currentFields[f.CustomFieldID] = f.Value
+	}
+	changedFields := map[string][]string{}
+	for id, values := range d.CustomFields {
+		if !sameStrings(values, currentFields[id]) {
+			changedFields[id] = values
+		}
+	}
+	if len(changedFields) > 0 {
+		a.customFields = customFieldValues(changedFields)
+		for _, f := range a.customFields {
+			a.Changes = append(a.Changes, "customField:"+f.CustomFieldID)
+		}
+	}
+
+	return a, len(a.Changes) > 0
+}
+
+// customFieldValues converts a custom field map in a stable order
+func customFieldValues(fields map[string][]string) []getresponse.CustomField {
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var ret []getresponse.CustomField
+	for _, id := range ids {
+		ret = append(ret, getresponse.CustomField{CustomFieldID: id, Value: fields[id]})
+	}
+	return ret
+}
+
+func sameTags(ids []string, tags []getresponse.Tag) bool {
+	current := make([]string, 0, len(tags))
+	for _, t := range tags {
+		current = append(current, t.TagID)
+	}
+	return sameSet(ids, current)
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := map[string]int{}
+	for _, v := range a {
+		seen[v]++
+	}
+	for _, v := range b {
+		seen[v]--
+		if seen[v] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package contactsync
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func makeStringPtr(v string) *string {
+	return &v
+}
+
+func TestUnit_Sync(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := ioutil.ReadAll(r.Body)
+			calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+			if r.Method == http.MethodPost && r.URL.Path != "/v3/contacts" {
+				fmt.Fprint(w, `{}`)
+			}
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/contacts":
+			fmt.Fprint(w, `[
+				{"contactId":"1","email":"same@example.com","name":"Same"},
+				{"contactId":"2","email":"Renamed@example.com","name":"Old"},
+				{"contactId":"3","email":"fields@example.com","name":"Fields"},
+				{"contactId":"4","email":"gone@example.com","name":"Gone"}
+			]`)
+		case "/v3/contacts/3":
+			fmt.Fprint(w, `{"contactId":"3","email":"fields@example.com","tags":[{"tagId":"t1"}],"customFieldValues":[{"customFieldId":"cf1","value":["a"]},{"customFieldId":"cf2","value":["b"]}]}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	s := &Syncer{Client: getresponse.NewClient(ts.URL, "", "", nil), CampaignID: "123", DeleteMissing: true}
+	plan, err := s.Plan(context.Background(), []Desired{
+		{Email: "same@example.com", Name: makeStringPtr("Same")},
+		{Email: "renamed@example.com", Name: makeStringPtr("New")},
+		{Email: "fields@example.com", CustomFields: map[string][]string{"cf1": {"a"}, "cf2": {"c"}}, Tags: []string{"t1"}},
+		{Email: "new@example.com", Tags: []string{"t2"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expectedPlan := "update renamed@example.com (2): name\n" +
+		"update fields@example.com (3): customField:cf2\n" +
+		"create new@example.com\n" +
+		"delete gone@example.com (4)\n"
+	if plan.String() != expectedPlan {
+		t.Fatalf("Actual plan (%s) did not match expected (%s)", plan.String(), expectedPlan)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("Planning must not write, got (%v)", calls)
+	}
+
+	res, err := s.Apply(context.Background(), plan)
+	if err != nil || res.Applied != 4 || len(res.Errors) != 0 {
+		t.Fatalf("Unexpected apply result (%#v, %#v)", res, err)
+	}
+	expectedCalls := []string{
+		`DELETE /v3/contacts/4 `,
+		`POST /v3/contacts {"email":"new@example.com","campaign":{"campaignId":"123"},"tags":[{"tagId":"t2"}]}`,
+		`POST /v3/contacts/2 {"name":"New"}`,
+		`POST /v3/contacts/3/custom-fields {"customFieldValues":[{"customFieldId":"cf2","value":["c"]}]}`,
+	}
+	sort.Strings(calls)
+	for i := range expectedCalls {
+		if calls[i] != expectedCalls[i] {
+			t.Fatalf("Actual call (%s) did not match expected (%s)", calls[i], expectedCalls[i])
+		}
+	}
+}
+
+func TestUnit_SyncRemovesTags(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			fmt.Fprint(w, `{}`)
+		case r.URL.Path == "/v3/contacts":
+			fmt.Fprint(w, `[{"contactId":"1","email":"tagged@example.com"}]`)
+		default:
+			fmt.Fprint(w, `{"contactId":"1","email":"tagged@example.com","tags":[{"tagId":"t1"},{"tagId":"t2"}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	s := &Syncer{Client: getresponse.NewClient(ts.URL, "", "", nil), CampaignID: "123"}
+	plan, err := s.Plan(context.Background(), []Desired{{Email: "tagged@example.com", Tags: []string{}}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	res, err := s.Apply(context.Background(), plan)
+	if err != nil || res.Applied != 1 || len(res.Errors) != 0 {
+		t.Fatalf("Unexpected apply result (%#v, %#v)", res, err)
+	}
+
+	expected := `{"tags":[]}`
+	if len(bodies) != 1 || bodies[0] != expected {
+		t.Errorf("Actual bodies (%v) did not match expected (%v)", bodies, expected)
+	}
+}
@@ -0,0 +1,63 @@
+package getresponse
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// mergeTagBreaker is put between the brackets of "[[" in escaped values. It is invisible in mail clients.
+const mergeTagBreaker = "\u200b"
+
+// MergeTag returns the placeholder GetResponse replaces with the contact's value of field when sending, e.g.
+// MergeTag("name") is "[[name]]"
+func MergeTag(field string) string {
+	return "[[" + field + "]]"
+}
+
+// EscapeMergeTags breaks up "[[" in s so that values such as user input are not taken for merge tags
+func EscapeMergeTags(s string) string {
+	return strings.Replace(s, "[[", "["+mergeTagBreaker+"[", -1)
+}
+
+// MergeTagFuncs returns the template functions mergeTag and escapeMergeTags, for use with Funcs of html/template
+// and text/template:
+//
+//	Hello {{mergeTag "name"}}, your order {{escapeMergeTags .Order}} has shipped.
+func MergeTagFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"mergeTag":        MergeTag,
+		"escapeMergeTags": EscapeMergeTags,
+	}
+}
+
+// MessageContent renders the html and plain text parts of a message from templates. Either may be nil.
+type MessageContent struct {
+	HTML  *htmltemplate.Template
+	Plain *texttemplate.Template
+}
+
+// Render executes the templates with data
+func (m *MessageContent) Render(data interface{}) (NewsletterContent, error) {
+	ret := NewsletterContent{}
+	b := &strings.Builder{}
+	if m.HTML != nil {
+		err := m.HTML.Execute(b, data)
+		if err != nil {
+			return ret, err
+		}
+		ret.HTML = b.String()
+		b.Reset()
+	}
+	if m.Plain != nil {
+		err := m.Plain.Execute(b, data)
+		if err != nil {
+			return ret, err
+		}
+		ret.Plain = b.String()
+	}
+	if ret.HTML == "" && ret.Plain == "" {
+		return ret, &ValidationError{Field: "content", Message: "needs html or plain text"}
+	}
+	return ret, nil
+}
@@ -0,0 +1,32 @@
+package getresponse
+
+import (
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestUnit_MessageContent(t *testing.T) {
+	m := &MessageContent{
+		HTML:  htmltemplate.Must(htmltemplate.New("html").Funcs(MergeTagFuncs()).Parse(`<p>Hi {{mergeTag "name"}}, {{escapeMergeTags .Note}}</p>`)),
+		Plain: texttemplate.Must(texttemplate.New("plain").Funcs(MergeTagFuncs()).Parse(`Hi {{mergeTag "name"}}, {{escapeMergeTags .Note}}`)),
+	}
+	content, err := m.Render(struct{ Note string }{Note: "<b>[[email]]</b>"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expectedHTML := "<p>Hi [[name]], &lt;b&gt;[\u200b[email]]&lt;/b&gt;</p>"
+	if content.HTML != expectedHTML {
+		t.Fatalf("Actual html (%q) did not match expected (%q)", content.HTML, expectedHTML)
+	}
+	expectedPlain := "Hi [[name]], <b>[\u200b[email]]</b>"
+	if content.Plain != expectedPlain {
+		t.Fatalf("Actual plain text (%q) did not match expected (%q)", content.Plain, expectedPlain)
+	}
+
+	_, err = (&MessageContent{}).Render(nil)
+	if err == nil {
+		t.Fatalf("Expected error did not occur")
+	}
+}
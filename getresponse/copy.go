@@ -0,0 +1,129 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+func (g *getResponseClient) copyContactToCampaign(ctx context.Context, contactID, targetCampaignID string) error {
+	if contactID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	if targetCampaignID == "" {
+		return &ValidationError{Field: "campaign.campaignId", Message: "is required"}
+	}
+
+	res, err := g.getContact(withoutCache(ctx), &GetContactRequest{ID: contactID})
+	if err != nil {
+		return err
+	}
+	source := res.Contact
+	if source.Email == nil {
+		return &ValidationError{Field: "email", Message: "is missing on the source contact"}
+	}
+
+	req := &CreateContactRequest{
+		Name:         source.Name,
+		Email:        *source.Email,
+		Campaign:     Campaign{CampaignID: targetCampaignID},
+		CustomFields: mergeCustomFields(nil, source.CustomFieldValues),
+		IPAddress:    source.IPAddress,
+	}
+	for _, t := range source.Tags {
+		req.Tags = append(req.Tags, Tag{TagID: t.TagID})
+	}
+
+	err = g.createContact(ctx, req)
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != ErrResourceAlreadyExists {
+		return err
+	}
+
+	// already on the target list, merge into that contact instead
+	return g.mergeIntoCampaignContact(ctx, req)
+}
+
+func (g *getResponseClient) mergeIntoCampaignContact(ctx context.Context, req *CreateContactRequest) error {
+	list, err := g.getContacts(withoutCache(ctx), &GetContactsRequest{
+		QueryHash:       map[string]string{"email": req.Email, "campaignId": req.Campaign.CampaignID},
+		Page:            1,
+		PerPage:         100,
+		AdditionalFlags: ContactFlags(FlagExactMatch),
+	})
+	if err != nil {
+		return err
+	}
+	targetID := ""
+	for _, c := range list.Contacts {
+		if c.Email != nil && c.ContactID != nil && strings.EqualFold(*c.Email, req.Email) {
+			targetID = *c.ContactID
+		}
+	}
+	if targetID == "" {
+		// still being added, nothing to merge into yet
+		return nil
+	}
+
+	res, err := g.getContact(withoutCache(ctx), &GetContactRequest{ID: targetID})
+	if err != nil {
+		return err
+	}
+	target := res.Contact
+
+	tags := map[string]bool{}
+	var merged []Tag
+	for _, t := range target.Tags {
+		tags[t.TagID] = true
+		merged = append(merged, Tag{TagID: t.TagID})
+	}
+	for _, t := range req.Tags {
+		if !tags[t.TagID] {
+			merged = append(merged, t)
+		}
+	}
+	if len(merged) > len(target.Tags) {
+		_, err = g.updateContact(ctx, &UpdateContactRequest{ID: targetID, NewData: Contact{Tags: merged}, Fields: []string{"tags"}})
+		if err != nil {
+			return err
+		}
+	}
+
+	customFields := changedCustomFields(target.CustomFieldValues, req.CustomFields)
+	if len(customFields) > 0 {
+		_, err = g.updateContactCustomFields(ctx, &UpdateContactCustomFieldsRequest{ID: targetID, CustomFields: customFields})
+	}
+	return err
+}
+
+// mergeCustomFields appends values to fields, folding entries for the same custom field into one since the API
+// rejects duplicates
+func mergeCustomFields(fields, values []CustomField) []CustomField {
+	index := map[string]int{}
+	for i, f := range fields {
+		index[f.CustomFieldID] = i
+	}
+	for _, f := range values {
+		i, ok := index[f.CustomFieldID]
+		if !ok {
+			index[f.CustomFieldID] = len(fields)
+			fields = append(fields, CustomField{CustomFieldID: f.CustomFieldID, Value: append([]string{}, f.Value...)})
+			continue
+		}
+		for _, v := range f.Value {
+			if !containsString(fields[i].Value, v) {
+				fields[i].Value = append(fields[i].Value, v)
+			}
+		}
+	}
+	return fields
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_CopyContactToCampaign(t *testing.T) {
+	source := `{"contactId":"c1","email":"foo@example.com","name":"Foo","campaign":{"campaignId":"a"},
+		"tags":[{"tagId":"t1","name":"vip"}],
+		"customFieldValues":[{"customFieldId":"f1","value":["x"]},{"customFieldId":"f1","value":["y"]},{"customFieldId":"f2","value":["z"]}]}`
+
+	type testcase struct {
+		name          string
+		existing      bool
+		expectedCalls []string
+	}
+
+	testcases := []testcase{
+		{
+			name: "creates the contact",
+			expectedCalls: []string{
+				`POST /v3/contacts {"name":"Foo","email":"foo@example.com","campaign":{"campaignId":"b"},` +
+					`"customFieldValues":[{"customFieldId":"f1","value":["x","y"]},{"customFieldId":"f2","value":["z"]}],"tags":[{"tagId":"t1"}]}`,
+			},
+		},
+		{
+			name:     "merges into the existing contact",
+			existing: true,
+			expectedCalls: []string{
+				`POST /v3/contacts {"name":"Foo","email":"foo@example.com","campaign":{"campaignId":"b"},` +
+					`"customFieldValues":[{"customFieldId":"f1","value":["x","y"]},{"customFieldId":"f2","value":["z"]}],"tags":[{"tagId":"t1"}]}`,
+				`POST /v3/contacts/c2 {"tags":[{"tagId":"t2"},{"tagId":"t1"}]}`,
+				`POST /v3/contacts/c2/custom-fields {"customFieldValues":[{"customFieldId":"f1","value":["x","y"]}]}`,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts/c1":
+					fmt.Fprint(w, source)
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts":
+					fmt.Fprint(w, `[{"contactId":"c2","email":"foo@example.com"}]`)
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts/c2":
+					fmt.Fprint(w, `{"contactId":"c2","email":"foo@example.com","tags":[{"tagId":"t2"}],"customFieldValues":[{"customFieldId":"f2","value":["z"]}]}`)
+				default:
+					body, _ := ioutil.ReadAll(r.Body)
+					calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+					if r.URL.Path == "/v3/contacts" && tc.existing {
+						w.WriteHeader(http.StatusConflict)
+						fmt.Fprint(w, `{"httpStatus":409,"code":1008,"message":"Contact already added"}`)
+						return
+					}
+					fmt.Fprint(w, `{"contactId":"c2"}`)
+				}
+			}))
+			defer ts.Close()
+
+			err := c.CopyContactToCampaign(context.Background(), "c1", "b")
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if len(calls) != len(tc.expectedCalls) {
+				t.Fatalf("Actual calls (%v) did not match expected (%v)", calls, tc.expectedCalls)
+			}
+			for i := range calls {
+				if calls[i] != tc.expectedCalls[i] {
+					t.Errorf("Actual call (%s) did not match expected (%s)", calls[i], tc.expectedCalls[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package getresponse
+
+import (
+	"context"
+)
+
+// DefaultCorrelationIDHeader is the header the correlation id is sent in unless changed with WithCorrelationIDHeader
+const DefaultCorrelationIDHeader = "X-Request-Id"
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a copy of ctx carrying a correlation id. Requests made with the context send it
+// in the correlation id header, and it is included in logs and on APIError so one user action can be traced
+// across services.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation id stored by ContextWithCorrelationID
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// WithCorrelationIDHeader changes the header used to send the correlation id
+func WithCorrelationIDHeader(name string) Option {
+	return func(g *getResponseClient) {
+		g.correlationIDHeader = name
+	}
+}
+
+// logCtxf is logf prefixed with the correlation id from ctx, if any
+func (g *getResponseClient) logCtxf(ctx context.Context, format string, v ...interface{}) {
+	if id, ok := CorrelationIDFromContext(ctx); ok {
+		format = "[" + id + "] " + format
+	}
+	g.logf(format, v...)
+}
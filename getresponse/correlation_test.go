@@ -0,0 +1,47 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnit_CorrelationID(t *testing.T) {
+	var received string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("X-Correlation-Id")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":1013,"message":"not found","uuid":"gr-uuid"}`)
+	}))
+	defer ts.Close()
+
+	logs := &bytes.Buffer{}
+	c := NewClient(ts.URL, "", "", nil, WithCorrelationIDHeader("X-Correlation-Id"), WithLogger(log.New(logs, "", 0)), WithDryRun(true))
+	ctx := ContextWithCorrelationID(context.Background(), "req-42")
+
+	_, err := c.GetContact(ctx, &GetContactRequest{ID: "foo"})
+	if received != "req-42" {
+		t.Fatalf("Expected the correlation id header to be sent, got (%s)", received)
+	}
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) || apiErr.CorrelationID != "req-42" || apiErr.UUID != "gr-uuid" {
+		t.Fatalf("Expected the correlation id on the error, got (%#v)", err)
+	}
+	if !strings.Contains(err.Error(), "(correlation id req-42)") {
+		t.Fatalf("Expected the correlation id in the error message, got (%s)", err.Error())
+	}
+
+	err = c.DeleteContact(ctx, &DeleteContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if !strings.HasPrefix(logs.String(), "[req-42] dry run") {
+		t.Fatalf("Expected the correlation id in the log, got (%s)", logs.String())
+	}
+}
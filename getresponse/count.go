@@ -0,0 +1,59 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// Pagination headers, described @ https://apidocs.getresponse.com/v3/pagination
+const (
+	TotalCountHeader  = "TotalCount"
+	TotalPagesHeader  = "TotalPages"
+	CurrentPageHeader = "CurrentPage"
+)
+
+// ErrNoTotalCount is returned by Count calls when the response did not tell the total
+var ErrNoTotalCount = errors.New("response has no " + TotalCountHeader + " header")
+
+type responseHeaderKey struct{}
+
+// withResponseHeader makes roundTrip store the headers of the final response in h
+func withResponseHeader(ctx context.Context, h *http.Header) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, responseHeaderKey{}, h)
+}
+
+func storeResponseHeader(ctx context.Context, h http.Header) {
+	if dst, ok := ctx.Value(responseHeaderKey{}).(*http.Header); ok {
+		*dst = h
+	}
+}
+
+func (g *getResponseClient) countContacts(ctx context.Context, query *GetContactsRequest) (int, error) {
+	req := GetContactsRequest{}
+	if query != nil {
+		req = *query
+	}
+	// a single contact with a single field keeps the transfer minimal, the total comes from the headers
+	req.SortHash, req.Sort = nil, nil
+	req.Fields = ContactFields(FieldContactID)
+	req.Page = 1
+	req.PerPage = 1
+
+	var header http.Header
+	ctx = withResponseHeader(withoutCache(ctx), &header)
+	_, err := g.getContacts(ctx, &req)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := strconv.Atoi(header.Get(TotalCountHeader))
+	if err != nil || total < 0 {
+		return 0, ErrNoTotalCount
+	}
+	return total, nil
+}
@@ -0,0 +1,50 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_CountContacts(t *testing.T) {
+	var queries []string
+	totalCount := "1234"
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if totalCount != "" {
+			w.Header().Set(TotalCountHeader, totalCount)
+		}
+		fmt.Fprint(w, `[{"contactId":"a"}]`)
+	}))
+	defer ts.Close()
+
+	count, err := c.Contacts().Count(context.Background(), &GetContactsRequest{
+		QueryHash: map[string]string{"campaignId": "c1"},
+		SortHash:  map[string]string{"createdOn": "DESC"},
+		Page:      3,
+		PerPage:   100,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if count != 1234 {
+		t.Fatalf("Actual count (%d) did not match expected (%d)", count, 1234)
+	}
+	expected := "fields=contactId&page=1&perPage=1&query%5BcampaignId%5D=c1"
+	if queries[0] != expected {
+		t.Fatalf("Actual query (%s) did not match expected (%s)", queries[0], expected)
+	}
+
+	count, err = c.Contacts().Count(context.Background(), nil)
+	if err != nil || count != 1234 || len(queries) != 2 {
+		t.Fatalf("Unexpected result (%d, %#v) after %d requests", count, err, len(queries))
+	}
+
+	totalCount = ""
+	_, err = c.Contacts().Count(context.Background(), nil)
+	if !errors.Is(err, ErrNoTotalCount) {
+		t.Fatalf("Expected ErrNoTotalCount, got (%#v)", err)
+	}
+}
@@ -0,0 +1,204 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoCredentials is returned when a CredentialsProvider has neither an API key nor an access token to offer
+var ErrNoCredentials = errors.New("no GetResponse credentials available")
+
+// Credentials authenticate a request
+type Credentials struct {
+	APIKey string
+	// AccessToken is an OAuth 2 access token, sent instead of APIKey when set
+	AccessToken string
+}
+
+// secret is the value sent in the request headers, which must never end up in logs or dumps
+func (c Credentials) secret() string {
+	if c.AccessToken != "" {
+		return c.AccessToken
+	}
+	return c.APIKey
+}
+
+// String hides the secrets, so credentials can be logged or end up in a panic safely
+func (c Credentials) String() string {
+	return fmt.Sprintf("{APIKey:%s AccessToken:%s}", redactSecret(c.APIKey), redactSecret(c.AccessToken))
+}
+
+// GoString hides the secrets from %#v
+func (c Credentials) GoString() string {
+	return "getresponse.Credentials" + c.String()
+}
+
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// CredentialsProvider returns the credentials to send with a request. It is asked on every attempt, so rotated keys
+// are picked up without recreating the client, and must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsFunc adapts a function to a CredentialsProvider
+type CredentialsFunc func(ctx context.Context) (Credentials, error)
+
+func (f CredentialsFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// WithCredentialsProvider takes the credentials from p instead of the API key passed to NewClient
+func WithCredentialsProvider(p CredentialsProvider) Option {
+	return func(g *getResponseClient) {
+		g.credentials = p
+	}
+}
+
+// StaticCredentials always returns the same API key, as NewClient does with its apiKey
+func StaticCredentials(apiKey string) CredentialsProvider {
+	return CredentialsFunc(func(context.Context) (Credentials, error) {
+		return Credentials{APIKey: apiKey}, nil
+	})
+}
+
+// EnvCredentials reads the API key from the environment variable on every request
+func EnvCredentials(name string) CredentialsProvider {
+	return CredentialsFunc(func(context.Context) (Credentials, error) {
+		key := strings.TrimSpace(os.Getenv(name))
+		if key == "" {
+			return Credentials{}, fmt.Errorf("%w: %s is not set", ErrNoCredentials, name)
+		}
+		return Credentials{APIKey: key}, nil
+	})
+}
+
+// FileCredentials reads the API key from a file, e.g. one rendered by a Vault agent. The file is read again when its
+// modification time changes.
+func FileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+type fileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	key     string
+}
+
+func (f *fileCredentials) String() string {
+	return "FileCredentials(" + f.path + ")"
+}
+
+func (f *fileCredentials) GoString() string {
+	return f.String()
+}
+
+func (f *fileCredentials) Credentials(context.Context) (Credentials, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.key == "" || !info.ModTime().Equal(f.modTime) {
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return Credentials{}, err
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return Credentials{}, fmt.Errorf("%w: %s is empty", ErrNoCredentials, f.path)
+		}
+		f.key, f.modTime = key, info.ModTime()
+	}
+	return Credentials{APIKey: f.key}, nil
+}
+
+// RefreshFunc fetches fresh credentials and says until when they may be used. A zero time means they never expire.
+type RefreshFunc func(ctx context.Context) (Credentials, time.Time, error)
+
+// RefreshingCredentials caches the credentials refresh returns and calls it again once they expired. Concurrent
+// requests wait for a single refresh.
+func RefreshingCredentials(refresh RefreshFunc) CredentialsProvider {
+	return &refreshingCredentials{refresh: refresh, now: time.Now}
+}
+
+type refreshingCredentials struct {
+	refresh RefreshFunc
+	now     func() time.Time
+
+	mu      sync.Mutex
+	creds   Credentials
+	expires time.Time
+	valid   bool
+}
+
+func (r *refreshingCredentials) String() string {
+	return "RefreshingCredentials"
+}
+
+func (r *refreshingCredentials) GoString() string {
+	return r.String()
+}
+
+func (r *refreshingCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.valid && (r.expires.IsZero() || r.now().Before(r.expires)) {
+		return r.creds, nil
+	}
+
+	creds, expires, err := r.refresh(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if creds.secret() == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	r.creds, r.expires, r.valid = creds, expires, true
+	return creds, nil
+}
+
+// credentialsError is returned when the provider fails, so callers such as Ping can tell it apart from transport
+// errors
+type credentialsError struct {
+	err error
+}
+
+func (e *credentialsError) Error() string {
+	return "getresponse: credentials: " + e.err.Error()
+}
+
+func (e *credentialsError) Unwrap() error {
+	return e.err
+}
+
+// authenticate sets the auth header of req from the current credentials and returns the secret sent, so dumps can
+// scrub it
+func (g *getResponseClient) authenticate(ctx context.Context, req *http.Request) (string, error) {
+	creds, err := g.credentials.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	if creds.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+creds.AccessToken)
+		return creds.AccessToken, nil
+	}
+	req.Header.Set(XAuthTokenHeader, "api-key "+creds.APIKey)
+	return creds.APIKey, nil
+}
@@ -0,0 +1,71 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUnit_CredentialsProviders(t *testing.T) {
+	var headers []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(XAuthTokenHeader)+r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"contactId":"foo"}`)
+	}))
+	defer ts.Close()
+
+	get := func(p CredentialsProvider) error {
+		c := NewClient(ts.URL, "unused", "", nil, WithCredentialsProvider(p))
+		_, err := c.Contacts().Get(context.Background(), &GetContactRequest{ID: "foo"})
+		return err
+	}
+
+	os.Setenv("GETRESPONSE_TEST_KEY", "env-key")
+	defer os.Unsetenv("GETRESPONSE_TEST_KEY")
+	if err := get(EnvCredentials("GETRESPONSE_TEST_KEY")); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err := get(EnvCredentials("GETRESPONSE_TEST_MISSING"))
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("Expected ErrNoCredentials, got (%#v)", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "key")
+	ioutil.WriteFile(path, []byte("file-key-1\n"), 0600)
+	file := FileCredentials(path)
+	if err := get(file); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	ioutil.WriteFile(path, []byte("file-key-2\n"), 0600)
+	os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if err := get(file); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	refreshes := 0
+	refreshing := RefreshingCredentials(func(context.Context) (Credentials, time.Time, error) {
+		refreshes++
+		return Credentials{AccessToken: fmt.Sprintf("token-%d", refreshes)}, time.Now().Add(time.Hour), nil
+	})
+	for i := 0; i < 2; i++ {
+		if err := get(refreshing); err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+	refreshing.(*refreshingCredentials).now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if err := get(refreshing); err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []string{"api-key env-key", "api-key file-key-1", "api-key file-key-2", "Bearer token-1", "Bearer token-1", "Bearer token-2"}
+	if fmt.Sprint(headers) != fmt.Sprint(expected) {
+		t.Fatalf("Actual headers (%v) did not match expected (%v)", headers, expected)
+	}
+}
@@ -0,0 +1,226 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const defaultExportPerPage = 100
+
+// contactCSVColumns are the columns written by ExportContactsCSV
+var contactCSVColumns = []string{"contactId", "email", "name", "campaignId", "dayOfCycle", "origin", "createdOn", "changedOn", "ipAddress"}
+
+// ImportResult summarizes an ImportContactsCSV run
+type ImportResult struct {
+	Created int
+	Errors  []ImportRowError
+}
+
+// ImportRowError is a row ImportContactsCSV could not import
+type ImportRowError struct {
+	Line  int
+	Email string
+	Err   error
+}
+
+func (e ImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err.Error())
+}
+
+func (g *getResponseClient) exportContactsCSV(ctx context.Context, w io.Writer, query *GetContactsRequest) error {
+	req := GetContactsRequest{}
+	if query != nil {
+		req = *query
+	}
+	// a run resumed from a PageError appends to the output of the failed one, which has the header already
+	resuming := req.Page > 1
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PerPage < 1 {
+		req.PerPage = defaultExportPerPage
+	}
+
+	var fields []CustomFieldDefinition
+	if req.Fields == nil || containsString(req.Fields, string(FieldCustomFieldValues)) {
+		var err error
+		fields, err = g.customFieldCatalog.definitions(ctx)
+		if err != nil {
+			return err
+		}
+		req.AdditionalFlags = withContactFlag(req.AdditionalFlags, FlagForceCustomFields)
+	}
+
+	cw := csv.NewWriter(w)
+	if !resuming {
+		header := append([]string{}, contactCSVColumns...)
+		for _, f := range fields {
+			header = append(header, f.Name)
+		}
+		err := cw.Write(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		var header http.Header
+		res, err := g.getContacts(withResponseHeader(ctx, &header), &req)
+		if err != nil {
+			return &PageError{Page: req.Page, Err: err}
+		}
+		for _, c := range res.Contacts {
+			err = cw.Write(contactCSVRecord(c, fields))
+			if err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		if err = cw.Error(); err != nil {
+			return err
+		}
+		// the API may return fewer contacts than PerPage on any page, when it caps the page size
+		if len(res.Contacts) == 0 {
+			return nil
+		}
+		if pages, err := strconv.Atoi(header.Get(TotalPagesHeader)); err == nil && int(req.Page) >= pages {
+			return nil
+		}
+		req.Page++
+	}
+}
+
+// contactCSVRecord returns the row of a contact, with a column per custom field after contactCSVColumns. Several
+// values of a custom field are joined with "|".
+func contactCSVRecord(c Contact, fields []CustomFieldDefinition) []string {
+	campaignID := ""
+	if c.Campaign != nil {
+		campaignID = c.Campaign.CampaignID
+	}
+	dayOfCycle := ""
+	if c.DayOfCycle != nil {
+		dayOfCycle = strconv.Itoa(int(*c.DayOfCycle))
+	}
+	record := []string{
+		stringValue(c.ContactID),
+		stringValue(c.Email),
+		stringValue(c.Name),
+		campaignID,
+		dayOfCycle,
+		stringValue(c.Origin),
+		stringValue(c.CreatedOn),
+		stringValue(c.ChangedOn),
+		stringValue(c.IPAddress),
+	}
+	for _, f := range fields {
+		value := ""
+		for _, v := range c.CustomFieldValues {
+			if v.CustomFieldID == f.CustomFieldID {
+				value = strings.Join(v.Value, "|")
+				break
+			}
+		}
+		record = append(record, value)
+	}
+	return record
+}
+
+// importContactsCSV creates a contact in the campaign for every row of r. The header row names the columns: email
+// is required, name, dayOfCycle and ipAddress are used when present, and fieldMapping maps further column names to
+// custom field ids. Other columns are ignored. Rows that fail are reported in the result and do not stop the import.
+func (g *getResponseClient) importContactsCSV(ctx context.Context, r io.Reader, campaignID string, fieldMapping map[string]string) (*ImportResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, &ValidationError{Field: "email", Message: "column is missing"}
+	}
+
+	result := &ImportResult{}
+	line := 1
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+		line++
+		if ctx != nil && ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		req, err := contactFromCSV(record, columns, campaignID, fieldMapping)
+		if err == nil {
+			err = g.createContact(ctx, req)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Line: line, Email: csvCell(record, columns, "email"), Err: err})
+			continue
+		}
+		result.Created++
+	}
+}
+
+func contactFromCSV(record []string, columns map[string]int, campaignID string, fieldMapping map[string]string) (*CreateContactRequest, error) {
+	req := &CreateContactRequest{
+		Email:    csvCell(record, columns, "email"),
+		Campaign: Campaign{CampaignID: campaignID},
+	}
+	if name := csvCell(record, columns, "name"); name != "" {
+		req.Name = &name
+	}
+	if ip := csvCell(record, columns, "ipAddress"); ip != "" {
+		req.IPAddress = &ip
+	}
+	if day := csvCell(record, columns, "dayOfCycle"); day != "" {
+		d, err := strconv.ParseInt(day, 10, 32)
+		if err != nil {
+			return nil, &ValidationError{Field: "dayOfCycle", Message: "must be a number"}
+		}
+		d32 := int32(d)
+		req.DayOfCycle = &d32
+	}
+	mapped := make([]string, 0, len(fieldMapping))
+	for column := range fieldMapping {
+		mapped = append(mapped, column)
+	}
+	sort.Strings(mapped)
+	for _, column := range mapped {
+		if v := csvCell(record, columns, column); v != "" {
+			req.CustomFields = append(req.CustomFields, CustomField{CustomFieldID: fieldMapping[column], Value: []string{v}})
+		}
+	}
+
+	return req, req.Validate()
+}
+
+func csvCell(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,92 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUnit_ExportContactsCSV(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/custom-fields" {
+			fmt.Fprint(w, `[{"customFieldId":"f2","name":"zip"},{"customFieldId":"f1","name":"interests"}]`)
+			return
+		}
+		if r.URL.Query().Get("additionalFlags") != "forceCustomFields" {
+			t.Errorf("Expected the custom field values to be requested (%s)", r.URL.RawQuery)
+		}
+		// the API caps the page size below the requested one
+		w.Header().Set(TotalPagesHeader, "2")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `[{"contactId":"a","email":"a@example.com","name":"A","campaign":{"campaignId":"123"},"dayOfCycle":3,`+
+				`"customFieldValues":[{"customFieldId":"f1","value":["go","mail"]}]},{"contactId":"b","email":"b@example.com"}]`)
+		case "2":
+			fmt.Fprint(w, `[{"contactId":"c","email":"c@example.com","name":"C, Jr.","customFieldValues":[{"customFieldId":"f2","value":["10115"]}]}]`)
+		default:
+			t.Errorf("Unexpected page requested (%s)", r.URL.RawQuery)
+		}
+	}))
+	defer ts.Close()
+
+	out := &bytes.Buffer{}
+	err := c.ExportContactsCSV(context.Background(), out, &GetContactsRequest{PerPage: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := "contactId,email,name,campaignId,dayOfCycle,origin,createdOn,changedOn,ipAddress,interests,zip\n" +
+		"a,a@example.com,A,123,3,,,,,go|mail,\n" +
+		"b,b@example.com,,,,,,,,,\n" +
+		"c,c@example.com,\"C, Jr.\",,,,,,,,10115\n"
+	if out.String() != expected {
+		t.Fatalf("Actual output (%s) did not match expected (%s)", out.String(), expected)
+	}
+}
+
+func TestUnit_ImportContactsCSV(t *testing.T) {
+	var created []CreateContactRequest
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := CreateContactRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Email == "taken@example.com" {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code":1008,"message":"Contact already added"}`)
+			return
+		}
+		created = append(created, req)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	input := "email,name,Favourite Color,dayOfCycle,unused\n" +
+		"a@example.com,A,blue,0,x\n" +
+		"taken@example.com,T,,,\n" +
+		",no email,,,\n" +
+		"b@example.com,,,,\n"
+	res, err := c.ImportContactsCSV(context.Background(), strings.NewReader(input), "123", map[string]string{"Favourite Color": "cf1"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	if res.Created != 2 || len(res.Errors) != 2 || res.Errors[0].Line != 3 || res.Errors[1].Line != 4 {
+		t.Fatalf("Unexpected result (%#v)", res)
+	}
+	first := created[0]
+	if first.Campaign.CampaignID != "123" || *first.Name != "A" || *first.DayOfCycle != 0 ||
+		len(first.CustomFields) != 1 || first.CustomFields[0].CustomFieldID != "cf1" || first.CustomFields[0].Value[0] != "blue" {
+		t.Fatalf("Unexpected contact created (%#v)", first)
+	}
+	if created[1].Name != nil || len(created[1].CustomFields) != 0 {
+		t.Fatalf("Expected empty cells to be skipped (%#v)", created[1])
+	}
+
+	_, err = c.ImportContactsCSV(context.Background(), strings.NewReader("name\nA\n"), "123", nil)
+	if vErr, ok := err.(*ValidationError); !ok || vErr.Field != "email" {
+		t.Fatalf("Expected a missing email column error, got (%#v)", err)
+	}
+}
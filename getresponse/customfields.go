@@ -0,0 +1,309 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Layouts of date and datetime custom field values
+const (
+	CustomFieldDateLayout     = "2006-01-02"
+	CustomFieldDateTimeLayout = "2006-01-02 15:04:05"
+)
+
+const defaultCustomFieldCatalogTTL = 10 * time.Minute
+
+// WithCustomFieldCatalogTTL sets how long the client's custom field catalog keeps definitions before listing them
+// again, 10 minutes by default. Unknown names always list them again.
+func WithCustomFieldCatalogTTL(ttl time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.customFieldCatalogTTL = ttl
+	}
+}
+
+// UnknownCustomFieldError is returned when a custom field name or id is not in the account
+type UnknownCustomFieldError struct {
+	Name string
+}
+
+func (e *UnknownCustomFieldError) Error() string {
+	return fmt.Sprintf("custom field %q does not exist", e.Name)
+}
+
+// CustomFieldCatalog maps custom field names to their definitions, so application code can use names instead of
+// custom field ids. The definitions are listed once and again when they are older than the catalog's TTL, or when a
+// name is not found.
+//
+//	catalog := getresponse.NewCustomFieldCatalog(client, time.Hour)
+//	fields, err := catalog.Fields(ctx, map[string]interface{}{"age": 42, "interests": []string{"go", "mail"}})
+//	...
+//	values, err := catalog.Values(ctx, contact.CustomFieldValues) // {"age": 42.0, "interests": ["go", "mail"]}
+type CustomFieldCatalog struct {
+	client Client
+	ttl    time.Duration
+
+	flight   flight
+	mu       sync.Mutex
+	byName   map[string]CustomFieldDefinition
+	byID     map[string]CustomFieldDefinition
+	loadedAt time.Time
+}
+
+// NewCustomFieldCatalog returns a catalog of the custom fields of the client's account, listed again after ttl.
+// A zero ttl keeps the definitions until a name is not found.
+func NewCustomFieldCatalog(c Client, ttl time.Duration) *CustomFieldCatalog {
+	return &CustomFieldCatalog{client: c, ttl: ttl}
+}
+
+// Refresh lists the custom field definitions again. Concurrent calls share a single listing.
+func (c *CustomFieldCatalog) Refresh(ctx context.Context) error {
+	return c.flight.do(ctx, func() error {
+		return c.refresh(ctx)
+	})
+}
+
+func (c *CustomFieldCatalog) refresh(ctx context.Context) error {
+	byName := map[string]CustomFieldDefinition{}
+	byID := map[string]CustomFieldDefinition{}
+	req := &GetCustomFieldsRequest{Page: 1, PerPage: 100}
+	for {
+		res, err := c.client.CustomFields().List(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, f := range res.CustomFields {
+			byName[f.Name] = f
+			byID[f.CustomFieldID] = f
+		}
+		if len(res.CustomFields) < int(req.PerPage) {
+			break
+		}
+		req.Page++
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byName, c.byID, c.loadedAt = byName, byID, time.Now()
+	return nil
+}
+
+// Lookup returns the definition of the named custom field
+func (c *CustomFieldCatalog) Lookup(ctx context.Context, name string) (CustomFieldDefinition, error) {
+	return c.lookup(ctx, name, func() map[string]CustomFieldDefinition { return c.byName })
+}
+
+// LookupID returns the definition of the custom field with the id
+func (c *CustomFieldCatalog) LookupID(ctx context.Context, id string) (CustomFieldDefinition, error) {
+	return c.lookup(ctx, id, func() map[string]CustomFieldDefinition { return c.byID })
+}
+
+func (c *CustomFieldCatalog) lookup(ctx context.Context, key string, index func() map[string]CustomFieldDefinition) (CustomFieldDefinition, error) {
+	c.mu.Lock()
+	stale := c.byName == nil || (c.ttl > 0 && time.Since(c.loadedAt) > c.ttl)
+	def, ok := index()[key]
+	c.mu.Unlock()
+	if ok && !stale {
+		return def, nil
+	}
+
+	// the field may have been created since the definitions were listed
+	err := c.Refresh(ctx)
+	if err != nil {
+		return CustomFieldDefinition{}, err
+	}
+	c.mu.Lock()
+	def, ok = index()[key]
+	c.mu.Unlock()
+	if !ok {
+		return def, &UnknownCustomFieldError{Name: key}
+	}
+	return def, nil
+}
+
+// definitions returns every custom field definition, sorted by name
+func (c *CustomFieldCatalog) definitions(ctx context.Context) ([]CustomFieldDefinition, error) {
+	c.mu.Lock()
+	stale := c.byName == nil || (c.ttl > 0 && time.Since(c.loadedAt) > c.ttl)
+	c.mu.Unlock()
+	if stale {
+		err := c.Refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defs := make([]CustomFieldDefinition, 0, len(c.byName))
+	for _, def := range c.byName {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs, nil
+}
+
+// Fields converts values keyed by custom field name to custom field values for contact requests. Values may be
+// strings, numbers, bools, time.Time, fmt.Stringers, or slices of strings for fields with several values; they are
+// checked against the field's type and, for select fields, its allowed values.
+func (c *CustomFieldCatalog) Fields(ctx context.Context, values map[string]interface{}) ([]CustomField, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := make([]CustomField, 0, len(values))
+	for _, name := range names {
+		def, err := c.Lookup(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		strs, err := encodeCustomFieldValue(def, values[name])
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, CustomField{CustomFieldID: def.CustomFieldID, Value: strs})
+	}
+	return ret, nil
+}
+
+// Values converts custom field values to a map keyed by custom field name. Numbers become float64, dates and
+// datetimes time.Time, fields with several values []string and the others string. Values that do not parse as their
+// type are kept as strings.
+func (c *CustomFieldCatalog) Values(ctx context.Context, fields []CustomField) (map[string]interface{}, error) {
+	ret := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		def, err := c.LookupID(ctx, f.CustomFieldID)
+		if err != nil {
+			return nil, err
+		}
+		ret[def.Name] = decodeCustomFieldValue(def, f.Value)
+	}
+	return ret, nil
+}
+
+// multiValued tells whether a custom field takes several values
+func (d CustomFieldDefinition) multiValued() bool {
+	return d.FieldType == "checkbox" || d.FieldType == "multi_select"
+}
+
+func encodeCustomFieldValue(def CustomFieldDefinition, v interface{}) ([]string, error) {
+	var strs []string
+	switch v := v.(type) {
+	case []string:
+		strs = v
+	case string:
+		strs = []string{v}
+	case time.Time:
+		layout := CustomFieldDateLayout
+		if def.ValueType == "datetime" {
+			layout = CustomFieldDateTimeLayout
+		}
+		strs = []string{v.UTC().Format(layout)}
+	case bool:
+		strs = []string{strconv.FormatBool(v)}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		strs = []string{fmt.Sprint(v)}
+	case float32:
+		strs = []string{strconv.FormatFloat(float64(v), 'f', -1, 32)}
+	case float64:
+		strs = []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case fmt.Stringer:
+		strs = []string{v.String()}
+	default:
+		return nil, &ValidationError{Field: def.Name, Message: fmt.Sprintf("has an unsupported value type %T", v)}
+	}
+
+	if len(strs) > 1 && !def.multiValued() {
+		return nil, &ValidationError{Field: def.Name, Message: "takes a single value"}
+	}
+	for _, s := range strs {
+		err := checkCustomFieldValue(def, s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return strs, nil
+}
+
+func checkCustomFieldValue(def CustomFieldDefinition, s string) error {
+	switch def.ValueType {
+	case "number":
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return &ValidationError{Field: def.Name, Message: "must be a number"}
+		}
+	case "date":
+		if _, err := time.Parse(CustomFieldDateLayout, s); err != nil {
+			return &ValidationError{Field: def.Name, Message: "must be a date (" + CustomFieldDateLayout + ")"}
+		}
+	case "datetime":
+		if _, err := time.Parse(CustomFieldDateTimeLayout, s); err != nil {
+			return &ValidationError{Field: def.Name, Message: "must be a datetime (" + CustomFieldDateTimeLayout + ")"}
+		}
+	}
+
+	if len(def.Values) > 0 {
+		for _, allowed := range def.Values {
+			if s == allowed {
+				return nil
+			}
+		}
+		return &ValidationError{Field: def.Name, Message: fmt.Sprintf("does not allow %q", s)}
+	}
+	return nil
+}
+
+func decodeCustomFieldValue(def CustomFieldDefinition, values []string) interface{} {
+	if def.multiValued() {
+		return append([]string{}, values...)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	s := values[0]
+	switch def.ValueType {
+	case "number":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "date":
+		if t, err := time.Parse(CustomFieldDateLayout, s); err == nil {
+			return t
+		}
+	case "datetime":
+		if t, err := time.Parse(CustomFieldDateTimeLayout, s); err == nil {
+			return t
+		}
+	}
+	return s
+}
+
+// resolveCustomFields returns a copy of the request with its named custom fields added to CustomFields, or the
+// request itself when it has none
+func (g *getResponseClient) resolveCustomFields(ctx context.Context, request *CreateContactRequest) (*CreateContactRequest, error) {
+	if request == nil || len(request.NamedCustomFields) == 0 {
+		return request, nil
+	}
+	named, err := g.customFieldCatalog.Fields(ctx, request.NamedCustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *request
+	resolved.CustomFields = append([]CustomField{}, request.CustomFields...)
+	for _, f := range named {
+		for _, existing := range request.CustomFields {
+			if existing.CustomFieldID == f.CustomFieldID {
+				return nil, &ValidationError{Field: "customFieldValues", Message: fmt.Sprintf("sets %s both by name and by id", f.CustomFieldID)}
+			}
+		}
+		resolved.CustomFields = append(resolved.CustomFields, f)
+	}
+	resolved.NamedCustomFields = nil
+	return &resolved, nil
+}
@@ -0,0 +1,155 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_CustomFieldCatalog(t *testing.T) {
+	lists := 0
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		fmt.Fprint(w, `[
+			{"customFieldId":"f1","name":"age","fieldType":"number","valueType":"number"},
+			{"customFieldId":"f2","name":"interests","fieldType":"multi_select","valueType":"string","values":["go","mail"]},
+			{"customFieldId":"f3","name":"birthday","fieldType":"date","valueType":"date"}
+		]`)
+	}))
+	defer ts.Close()
+	catalog := NewCustomFieldCatalog(c, 0)
+	ctx := context.Background()
+
+	birthday := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	fields, err := catalog.Fields(ctx, map[string]interface{}{"age": 42, "interests": []string{"go", "mail"}, "birthday": birthday})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := []CustomField{
+		{CustomFieldID: "f1", Value: []string{"42"}},
+		{CustomFieldID: "f3", Value: []string{"1990-05-17"}},
+		{CustomFieldID: "f2", Value: []string{"go", "mail"}},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Fatalf("Actual fields (%v) did not match expected (%v)", fields, expected)
+	}
+
+	values, err := catalog.Values(ctx, fields)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expectedValues := map[string]interface{}{"age": 42.0, "interests": []string{"go", "mail"}, "birthday": birthday}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Fatalf("Actual values (%v) did not match expected (%v)", values, expectedValues)
+	}
+	if lists != 1 {
+		t.Fatalf("Actual list calls (%v) did not match expected (%v)", lists, 1)
+	}
+
+	type testcase struct {
+		values map[string]interface{}
+		field  string
+	}
+	for _, tc := range []testcase{
+		{map[string]interface{}{"age": "old"}, "age"},
+		{map[string]interface{}{"age": []string{"1", "2"}}, "age"},
+		{map[string]interface{}{"interests": "cooking"}, "interests"},
+		{map[string]interface{}{"birthday": "17/05/1990"}, "birthday"},
+	} {
+		_, err := catalog.Fields(ctx, tc.values)
+		vErr := &ValidationError{}
+		if !errors.As(err, &vErr) || vErr.Field != tc.field {
+			t.Fatalf("Expected a validation error for %s, got (%#v)", tc.field, err)
+		}
+	}
+
+	_, err = catalog.Fields(ctx, map[string]interface{}{"shoe size": 42})
+	uErr := &UnknownCustomFieldError{}
+	if !errors.As(err, &uErr) || uErr.Name != "shoe size" {
+		t.Fatalf("Expected an unknown custom field error, got (%#v)", err)
+	}
+	if lists != 2 {
+		t.Fatalf("Expected an unknown name to list the fields again, got %d calls", lists)
+	}
+}
+
+func TestUnit_CreateContactNamedCustomFields(t *testing.T) {
+	var body string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"f1","name":"age","fieldType":"number","valueType":"number"}]`)
+		case "/v3/contacts":
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer ts.Close()
+
+	req := &CreateContactRequest{
+		Email:             "a@example.com",
+		Campaign:          Campaign{CampaignID: "c"},
+		CustomFields:      []CustomField{{CustomFieldID: "f2", Value: []string{"x"}}},
+		NamedCustomFields: map[string]interface{}{"age": 42},
+	}
+	err := c.Contacts().Create(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := `{"email":"a@example.com","campaign":{"campaignId":"c"},"customFieldValues":[{"customFieldId":"f2","value":["x"]},{"customFieldId":"f1","value":["42"]}]}`
+	if body != expected {
+		t.Fatalf("Actual body (%v) did not match expected (%v)", body, expected)
+	}
+	if len(req.CustomFields) != 1 {
+		t.Fatalf("The request was modified (%#v)", req.CustomFields)
+	}
+
+	req.NamedCustomFields = map[string]interface{}{"height": 180}
+	err = c.Contacts().Create(context.Background(), req)
+	uErr := &UnknownCustomFieldError{}
+	if !errors.As(err, &uErr) || uErr.Name != "height" {
+		t.Fatalf("Expected an unknown custom field error, got (%#v)", err)
+	}
+}
+
+func TestUnit_GetCustomFieldValue(t *testing.T) {
+	var sent string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := ioutil.ReadAll(r.Body)
+			sent = string(body)
+		}
+		fmt.Fprint(w, `{"contactId":"c1","customFieldValues":[
+			{"customFieldId":"a","name":"interests","fieldType":"multi_select","valueType":"string","type":"multi_select","value":["go","mail"]},
+			{"customFieldId":"b","name":"age","fieldType":"number","valueType":"number","type":"number","value":["42"]}]}`)
+	}))
+	defer ts.Close()
+
+	contact, err := c.Contacts().UpdateIf(context.Background(), "c1", func(contact *Contact) error {
+		values, ok := contact.GetCustomFieldValue("interests")
+		if !ok || !reflect.DeepEqual(values, []string{"go", "mail"}) || !contact.CustomFieldValues[0].MultiValued() {
+			t.Errorf("Actual interests (%v, %v) did not match expected ([go mail], true)", values, ok)
+		}
+		contact.CustomFieldValues[0].Value = append(values, "api")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if contact.CustomFieldValues[1].MultiValued() {
+		t.Errorf("Expected %s not to be multi valued", contact.CustomFieldValues[1].Name)
+	}
+	if _, ok := contact.GetCustomFieldValue("missing"); ok {
+		t.Errorf("Expected no value of a missing field")
+	}
+	expected := `{"customFieldValues":[{"customFieldId":"a","value":["go","mail","api"]}]}`
+	if sent != expected {
+		t.Errorf("Actual update (%s) did not match expected (%s)", sent, expected)
+	}
+}
@@ -0,0 +1,85 @@
+package getresponse
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// TriggerOnDay is the trigger type of the autoresponders sent on a day of the cycle
+const TriggerOnDay = "onday"
+
+const cyclePerPage = 100
+
+// AutoresponderCycle holds the autoresponders of a campaign, see CampaignsClient.Cycle
+type AutoresponderCycle struct {
+	CampaignID string
+	// Autoresponders sorted by day of cycle, followed by those sent on other triggers
+	Autoresponders []Autoresponder
+}
+
+// Received returns the enabled autoresponders a contact at dayOfCycle was sent by the cycle, the one of
+// dayOfCycle included as it is sent on that day. Autoresponders added or enabled since the contact passed their day
+// are included although the contact did not get them.
+func (c *AutoresponderCycle) Received(dayOfCycle int) []Autoresponder {
+	return c.onDays(func(day int) bool { return day <= dayOfCycle })
+}
+
+// Remaining returns the enabled autoresponders a contact at dayOfCycle will still be sent by the cycle
+func (c *AutoresponderCycle) Remaining(dayOfCycle int) []Autoresponder {
+	return c.onDays(func(day int) bool { return day > dayOfCycle })
+}
+
+func (c *AutoresponderCycle) onDays(match func(day int) bool) []Autoresponder {
+	var ret []Autoresponder
+	for _, a := range c.Autoresponders {
+		if day, ok := cycleDay(a); ok && a.Status != "disabled" && match(day) {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
+
+// cycleDay returns the day of cycle an autoresponder is sent on, false when another trigger sends it
+func cycleDay(a Autoresponder) (int, bool) {
+	t := a.TriggerSettings
+	if t == nil || t.Type != TriggerOnDay || t.DayOfCycle == nil {
+		return 0, false
+	}
+	return *t.DayOfCycle, true
+}
+
+func (g *getResponseClient) getCycle(ctx context.Context, campaignID string) (*AutoresponderCycle, error) {
+	ctx = withOperation(ctx, OpGetAutoresponders)
+
+	if campaignID == "" {
+		return nil, &ValidationError{Field: "campaignId", Message: "is required"}
+	}
+
+	cycle := &AutoresponderCycle{CampaignID: campaignID}
+	query := url.Values{}
+	query.Set("query[campaignId]", campaignID)
+	query.Set("perPage", strconv.Itoa(cyclePerPage))
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		result, _, err := doGet[[]Autoresponder](ctx, g, "/v3/autoresponders", query)
+		if err != nil {
+			return nil, &PageError{Page: int32(page), Err: err}
+		}
+		cycle.Autoresponders = append(cycle.Autoresponders, result...)
+		if len(result) < cyclePerPage {
+			break
+		}
+	}
+
+	sort.SliceStable(cycle.Autoresponders, func(i, j int) bool {
+		di, oki := cycleDay(cycle.Autoresponders[i])
+		dj, okj := cycleDay(cycle.Autoresponders[j])
+		if oki != okj {
+			return oki
+		}
+		return di < dj
+	})
+	return cycle, nil
+}
@@ -0,0 +1,55 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_Cycle(t *testing.T) {
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/autoresponders" || r.URL.Query().Get("query[campaignId]") != "c" {
+			t.Errorf("Unexpected request (%s)", r.URL)
+		}
+		fmt.Fprint(w, `[
+			{"autoresponderId":"click","status":"enabled","triggerSettings":{"type":"click"}},
+			{"autoresponderId":"d3","status":"enabled","triggerSettings":{"type":"onday","dayOfCycle":"3"}},
+			{"autoresponderId":"d0","status":"enabled","triggerSettings":{"type":"onday","dayOfCycle":0}},
+			{"autoresponderId":"d1","status":"disabled","triggerSettings":{"type":"onday","dayOfCycle":1}},
+			{"autoresponderId":"d7","status":"enabled","triggerSettings":{"type":"onday","dayOfCycle":7}}
+		]`)
+	})
+	defer ts.Close()
+
+	cycle, err := c.Campaigns().Cycle(context.Background(), "c")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	ids := func(list []Autoresponder) string {
+		s := ""
+		for _, a := range list {
+			s += a.AutoresponderID + " "
+		}
+		return s
+	}
+	type testcase struct {
+		actual   string
+		expected string
+	}
+	for _, tc := range []testcase{
+		{ids(cycle.Autoresponders), "d0 d1 d3 d7 click "},
+		{ids(cycle.Received(3)), "d0 d3 "},
+		{ids(cycle.Remaining(3)), "d7 "},
+		{ids(cycle.Remaining(7)), ""},
+	} {
+		if tc.actual != tc.expected {
+			t.Fatalf("Actual autoresponders (%v) did not match expected (%v)", tc.actual, tc.expected)
+		}
+	}
+
+	_, err = c.Campaigns().Cycle(context.Background(), "")
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Expected a validation error, got (%#v)", err)
+	}
+}
@@ -0,0 +1,105 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+)
+
+// WithDebug dumps every request and response to w with the API key and personal data redacted.
+// Dumps can be switched on and off later with Client.SetDebug.
+func WithDebug(w io.Writer) Option {
+	return func(g *getResponseClient) {
+		g.debugOut = w
+	}
+}
+
+// DebugRequest describes an attempt at a request, see WithDebugHook
+type DebugRequest struct {
+	Operation Operation // empty for requests sent with Do, Get or Post
+	Method    string
+	// URL is the URL sent, with the query parameters sorted by name except sort parameters, which come last in
+	// priority order (see SortTerm), so the same call always gives the same URL. Personal data is redacted unless
+	// the client is built WithoutRedaction.
+	URL string
+}
+
+// DebugHook is called before every attempt at a request, e.g. for golden tests asserting on the URLs sent
+type DebugHook func(ctx context.Context, r DebugRequest)
+
+// WithDebugHook registers a hook called with every request about to be sent
+func WithDebugHook(h DebugHook) Option {
+	return func(g *getResponseClient) {
+		g.debugHook = h
+	}
+}
+
+func (g *getResponseClient) SetDebug(w io.Writer) {
+	g.debugMu.Lock()
+	defer g.debugMu.Unlock()
+	g.debugOut = w
+}
+
+func (g *getResponseClient) debugEnabled() bool {
+	g.debugMu.Lock()
+	defer g.debugMu.Unlock()
+	return g.debugOut != nil
+}
+
+func (g *getResponseClient) callDebugHook(ctx context.Context, req *http.Request) error {
+	if g.debugHook == nil {
+		return nil
+	}
+	r := DebugRequest{
+		Operation: operationFromContext(ctx),
+		Method:    req.Method,
+		URL:       g.redactString(req.URL.String()),
+	}
+	return g.callHook(ctx, "debug", func() {
+		g.debugHook(ctx, r)
+	})
+}
+
+// dumpRequest writes the request to the debug output. Streamed bodies are left out, dumping them would read them
+// into memory.
+func (g *getResponseClient) dumpRequest(req *http.Request, withBody bool, secret string) {
+	if !g.debugEnabled() {
+		return
+	}
+	dump, err := httputil.DumpRequestOut(req, withBody)
+	if err != nil {
+		g.writeDebug(fmt.Sprintf("could not dump request: %s", err), secret)
+		return
+	}
+	g.writeDebug(string(dump), secret)
+}
+
+// dumpResponse writes the response headers and the body read by readBody to the debug output. The body is not
+// dumped from resp, which would read it without the stall timeout.
+func (g *getResponseClient) dumpResponse(resp *http.Response, body []byte, secret string) {
+	if !g.debugEnabled() {
+		return
+	}
+	dump, err := httputil.DumpResponse(resp, false)
+	if err != nil {
+		g.writeDebug(fmt.Sprintf("could not dump response: %s", err), secret)
+		return
+	}
+	g.writeDebug(string(dump)+string(body), secret)
+}
+
+func (g *getResponseClient) writeDebug(dump, secret string) {
+	if secret != "" {
+		dump = strings.Replace(dump, secret, redactedValue, -1)
+	}
+	dump = g.redactString(dump)
+
+	g.debugMu.Lock()
+	defer g.debugMu.Unlock()
+	if g.debugOut != nil {
+		fmt.Fprintf(g.debugOut, "%s\n\n", strings.TrimRight(dump, "\r\n"))
+	}
+}
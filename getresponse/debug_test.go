@@ -0,0 +1,85 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnit_Debug(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contactId":"foo","name":"John Smith","email":"jsmith@example.com"}`)
+	}))
+	defer ts.Close()
+
+	out := &bytes.Buffer{}
+	c := NewClient(ts.URL, "secret-api-key", "", nil, WithDebug(out))
+
+	_, err := c.UpdateContact(context.Background(), &UpdateContactRequest{ID: "foo", NewData: Contact{Email: makeStringPtr("jsmith@example.com")}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	dump := out.String()
+	for _, expected := range []string{"POST /v3/contacts/foo HTTP/1.1", "X-Auth-Token: api-key [REDACTED]", "HTTP/1.1 200 OK", `"contactId":"foo"`} {
+		if !strings.Contains(dump, expected) {
+			t.Fatalf("Expected dump to contain (%s), got (%s)", expected, dump)
+		}
+	}
+	for _, secret := range []string{"secret-api-key", "jsmith@example.com", "John Smith"} {
+		if strings.Contains(dump, secret) {
+			t.Fatalf("Expected dump not to contain (%s), got (%s)", secret, dump)
+		}
+	}
+
+	c.SetDebug(nil)
+	out.Reset()
+	_, err = c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("Expected no dump once disabled, got (%s)", out.String())
+	}
+}
+
+func TestUnit_DebugHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	}))
+	defer ts.Close()
+
+	urls := []string{}
+	c := NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithoutRedaction(), WithDebugHook(func(ctx context.Context, r DebugRequest) {
+		if r.Operation != OpGetCampaigns || r.Method != http.MethodGet {
+			t.Errorf("Unexpected request (%#v)", r)
+		}
+		urls = append(urls, strings.TrimPrefix(r.URL, ts.URL))
+	}))
+
+	query := map[string]string{}
+	for _, k := range []string{"name", "isDefault", "createdOn][from", "createdOn][to", "languageCode"} {
+		query[k] = "x"
+	}
+	for i := 0; i < 5; i++ {
+		_, err := c.Campaigns().List(context.Background(), &GetCampaignsRequest{QueryHash: query, Fields: []string{"name"}, PerPage: 10})
+		if err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+
+	expected := "/v3/campaigns?fields=name&perPage=10&query%5BcreatedOn%5D%5Bfrom%5D=x&query%5BcreatedOn%5D%5Bto%5D=x" +
+		"&query%5BisDefault%5D=x&query%5BlanguageCode%5D=x&query%5Bname%5D=x"
+	for _, u := range urls {
+		if u != expected {
+			t.Fatalf("Actual URL (%v) did not match expected (%v)", u, expected)
+		}
+	}
+	if len(urls) != 5 {
+		t.Fatalf("Actual calls (%d) did not match expected (%d)", len(urls), 5)
+	}
+}
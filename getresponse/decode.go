@@ -0,0 +1,203 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldsHook is called with the response fields the client's types do not model, e.g. "[].newField" or
+// "profile.extra", so API changes are noticed before they matter
+type UnknownFieldsHook func(ctx context.Context, op Operation, fields []string)
+
+// UnknownFieldsError is returned in strict mode when a response has fields the client's types do not model.
+// It wraps ErrCouldNotUnmarshal.
+type UnknownFieldsError struct {
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return "unknown fields in response: " + strings.Join(e.Fields, ", ")
+}
+
+func (e *UnknownFieldsError) Unwrap() error {
+	return ErrCouldNotUnmarshal
+}
+
+// WithStrictDecoding makes responses with unknown fields fail with an *UnknownFieldsError, which is useful in tests
+// against recorded responses. By default unknown fields are ignored so new API fields do not break production code.
+func WithStrictDecoding() Option {
+	return func(g *getResponseClient) {
+		g.strictDecoding = true
+	}
+}
+
+// WithUnknownFieldsHook reports unknown response fields without failing the call
+func WithUnknownFieldsHook(h UnknownFieldsHook) Option {
+	return func(g *getResponseClient) {
+		g.unknownFieldsHook = h
+	}
+}
+
+// WithRawResponses keeps the response body on the Raw field of response wrappers such as GetContactResponse, so fields
+// the types do not model yet can be read without another request. Models returned as they are, e.g. by
+// Campaigns().Get, have no Raw field, which keeps them comparable.
+func WithRawResponses() Option {
+	return func(g *getResponseClient) {
+		g.rawResponses = true
+	}
+}
+
+// raw copies the body for a Raw field, the body itself may be shared with the cache
+func (g *getResponseClient) raw(data []byte) json.RawMessage {
+	if !g.rawResponses {
+		return nil
+	}
+	return append(json.RawMessage(nil), data...)
+}
+
+// decode unmarshals a successful response, checking for unknown fields when strict mode or the hook asks for it
+func (g *getResponseClient) decode(ctx context.Context, data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err != nil || (!g.strictDecoding && g.unknownFieldsHook == nil) {
+		return err
+	}
+
+	var raw interface{}
+	if json.Unmarshal(data, &raw) != nil {
+		return nil
+	}
+	var fields []string
+	unknownFields(raw, reflect.TypeOf(v), "", &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+
+	if g.unknownFieldsHook != nil {
+		err := g.callHook(ctx, "unknown fields", func() {
+			g.unknownFieldsHook(ctx, operationFromContext(ctx), fields)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if g.strictDecoding {
+		return &UnknownFieldsError{Fields: fields}
+	}
+	return nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields walks decoded JSON alongside the type it was unmarshaled into and collects the object keys
+// encoding/json ignored. Types with their own UnmarshalJSON are trusted to handle their input.
+func unknownFields(raw interface{}, t reflect.Type, path string, out *[]string) {
+	for t.Kind() == reflect.Ptr {
+		if reflect.PtrTo(t).Implements(unmarshalerType) || t.Implements(unmarshalerType) {
+			return
+		}
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, value := range obj {
+			f, ok := fields[key]
+			if !ok {
+				f, ok = fields[strings.ToLower(key)]
+			}
+			if !ok {
+				*out = append(*out, joinFieldPath(path, key))
+				continue
+			}
+			unknownFields(value, f, joinFieldPath(path, key), out)
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := raw.([]interface{})
+		if !ok {
+			return
+		}
+		for _, value := range list {
+			unknownFields(value, t.Elem(), path+"[]", out)
+		}
+		dedupeStrings(out)
+	case reflect.Map:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, value := range obj {
+			unknownFields(value, t.Elem(), joinFieldPath(path, key), out)
+		}
+	}
+}
+
+// jsonFields maps the JSON names of a struct's fields, and their lower case forms for encoding/json's case
+// insensitive matching, to the field types. Embedded structs are flattened.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = f.Type
+		}
+	}
+	return fields
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// dedupeStrings drops repeated paths, which every element of a list reports
+func dedupeStrings(s *[]string) {
+	seen := map[string]bool{}
+	ret := (*s)[:0]
+	for _, v := range *s {
+		if !seen[v] {
+			seen[v] = true
+			ret = append(ret, v)
+		}
+	}
+	*s = ret
+}
@@ -0,0 +1,119 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnit_UnknownFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"contactId":"a","email":"a@example.com","score":1,"campaign":{"campaignId":"c","color":"red"}},
+			{"contactId":"b","EMAIL":"b@example.com","score":2,"tags":[{"tagId":"t","weight":1}]}
+		]`)
+	}))
+	defer ts.Close()
+
+	expected := []string{"[].campaign.color", "[].score", "[].tags[].weight"}
+
+	type testcase struct {
+		name   string
+		strict bool
+	}
+
+	testcases := []testcase{
+		{name: "lenient"},
+		{name: "strict", strict: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reported []string
+			var reportedOp Operation
+			opts := []Option{WithUnknownFieldsHook(func(ctx context.Context, op Operation, fields []string) {
+				reportedOp, reported = op, fields
+			})}
+			if tc.strict {
+				opts = append(opts, WithStrictDecoding())
+			}
+			c := NewClient(ts.URL, "", "", nil, opts...)
+
+			res, err := c.GetContacts(context.Background(), &GetContactsRequest{Page: 1, PerPage: 10})
+			if fmt.Sprint(reported) != fmt.Sprint(expected) || reportedOp != OpGetContacts {
+				t.Fatalf("Actual report (%s %v) did not match expected (%s %v)", reportedOp, reported, OpGetContacts, expected)
+			}
+			if !tc.strict {
+				if err != nil || len(res.Contacts) != 2 {
+					t.Fatalf("Unexpected result (%#v, %#v)", res, err)
+				}
+				return
+			}
+
+			ufErr := &UnknownFieldsError{}
+			if !errors.As(err, &ufErr) || fmt.Sprint(ufErr.Fields) != fmt.Sprint(expected) {
+				t.Fatalf("Expected an UnknownFieldsError, got (%#v)", err)
+			}
+			if !errors.Is(err, ErrCouldNotUnmarshal) {
+				t.Fatalf("Expected error to wrap ErrCouldNotUnmarshal, got (%#v)", err)
+			}
+		})
+	}
+}
+
+func TestUnit_StrictDecodingKnownFields(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contactId":"foo","name":"Foo","customFieldValues":[{"customFieldId":"f","value":["x"]}]}`)
+	}))
+	defer ts.Close()
+	c := NewClient(ts.URL, "", "", nil, WithStrictDecoding())
+
+	_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+}
+
+func TestUnit_RawResponses(t *testing.T) {
+	body := `{"contactId":"c","email":"c@example.com","future":{"enabled":true}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil)
+	contact, err := c.GetContact(context.Background(), &GetContactRequest{ID: "c"})
+	if err != nil || contact.Raw != nil {
+		t.Fatalf("Unexpected result without WithRawResponses (%#v, %#v)", contact, err)
+	}
+
+	c = NewClient(ts.URL, "", "", nil, WithRawResponses())
+	contact, err = c.GetContact(context.Background(), &GetContactRequest{ID: "c"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if string(contact.Raw) != body {
+		t.Fatalf("Actual raw body (%s) did not match expected (%s)", contact.Raw, body)
+	}
+	future := struct {
+		Future struct {
+			Enabled bool `json:"enabled"`
+		} `json:"future"`
+	}{}
+	err = json.Unmarshal(contact.Raw, &future)
+	if err != nil || !future.Future.Enabled {
+		t.Fatalf("Unexpected result decoding the raw body (%#v, %#v)", future, err)
+	}
+
+	body = `[{"campaignId":"c"}]`
+	res, err := c.GetCampaigns(context.Background(), &GetCampaignsRequest{Page: 1, PerPage: 10})
+	if err != nil || string(res.Raw) != body {
+		t.Fatalf("Unexpected list result (%#v, %#v)", res, err)
+	}
+	// models stay comparable, e.g. as map keys
+	_ = map[Campaign]bool{res.Campaigns[0]: true}
+}
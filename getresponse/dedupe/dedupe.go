@@ -0,0 +1,83 @@
+// Package dedupe finds contacts that are on more than one campaign (list), matching them by email.
+//
+//	err := dedupe.Find(ctx, client, func(d dedupe.Duplicate) error {
+//		fmt.Println(d.Email, d.CampaignIDs())
+//		return nil
+//	})
+package dedupe
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+const listPerPage = 100
+
+// Duplicate is an email found on more than one campaign
+type Duplicate struct {
+	Email    string
+	Contacts []getresponse.Contact // one per campaign
+}
+
+// CampaignIDs returns the campaigns the email is on, sorted
+func (d Duplicate) CampaignIDs() []string {
+	ids := []string{}
+	for _, c := range d.Contacts {
+		if c.Campaign != nil {
+			ids = append(ids, c.Campaign.CampaignID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Find scans the contacts of every campaign and calls fn for each email on more than one of them. The contacts are
+// listed sorted by email, so duplicates are reported as the scan goes and only one email is held in memory at a time.
+// Returning an error from fn stops the scan.
+func Find(ctx context.Context, c getresponse.Client, fn func(Duplicate) error) error {
+	var group []getresponse.Contact
+	key := ""
+	flush := func() error {
+		if len(group) > 1 {
+			err := fn(Duplicate{Email: *group[0].Email, Contacts: group})
+			if err != nil {
+				return err
+			}
+		}
+		group = nil
+		return nil
+	}
+
+	req := &getresponse.GetContactsRequest{
+		SortHash: map[string]string{"email": "asc"},
+		Page:     1,
+		PerPage:  listPerPage,
+	}
+	for {
+		res, err := c.Contacts().List(ctx, req)
+		if err != nil {
+			return &getresponse.PageError{Page: req.Page, Err: err}
+		}
+		for _, contact := range res.Contacts {
+			if contact.Email == nil {
+				continue
+			}
+			k := strings.ToLower(*contact.Email)
+			if k != key {
+				err = flush()
+				if err != nil {
+					return err
+				}
+				key = k
+			}
+			group = append(group, contact)
+		}
+		if len(res.Contacts) < listPerPage {
+			return flush()
+		}
+		req.Page++
+	}
+}
@@ -0,0 +1,59 @@
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_Find(t *testing.T) {
+	pages := map[string][]string{"1": {}, "2": {}}
+	emails := []string{"a@example.com", "b@example.com", "b@example.com", "c@example.com"}
+	for i := 0; i < listPerPage; i++ {
+		// page 1 ends with a duplicate continuing on page 2
+		email := fmt.Sprintf("x%03d@example.com", i)
+		if i == listPerPage-1 {
+			email = "y@example.com"
+		}
+		pages["1"] = append(pages["1"], fmt.Sprintf(`{"contactId":"p%d","email":"%s","campaign":{"campaignId":"c1"}}`, i, email))
+	}
+	pages["2"] = append(pages["2"], `{"contactId":"y2","email":"Y@example.com","campaign":{"campaignId":"c3"}}`)
+	for i, email := range emails {
+		pages["2"] = append(pages["2"], fmt.Sprintf(`{"contactId":"q%d","email":"%s","campaign":{"campaignId":"c%d"}}`, i, email, i))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sort[email]") != "asc" {
+			t.Errorf("Expected contacts sorted by email (%s)", r.URL.RawQuery)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(pages[r.URL.Query().Get("page")], ","))
+	}))
+	defer ts.Close()
+
+	var found []string
+	err := Find(context.Background(), getresponse.NewClient(ts.URL, "", "", nil), func(d Duplicate) error {
+		found = append(found, fmt.Sprintf("%s %v", d.Email, d.CampaignIDs()))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := []string{"y@example.com [c1 c3]", "b@example.com [c1 c2]"}
+	if fmt.Sprint(found) != fmt.Sprint(expected) {
+		t.Fatalf("Actual duplicates (%v) did not match expected (%v)", found, expected)
+	}
+
+	stop := errors.New("stop")
+	err = Find(context.Background(), getresponse.NewClient(ts.URL, "", "", nil), func(d Duplicate) error {
+		return stop
+	})
+	if err != stop {
+		t.Fatalf("Expected the callback error, got (%#v)", err)
+	}
+}
@@ -0,0 +1,269 @@
+// Package deliverability processes the bounces and complaints of sent messages in one call: it fetches them from the
+// message activities, classifies bounces as hard or soft and adds the hard bounced addresses to a suppression list,
+// so they are not mailed again:
+//
+//	p := &deliverability.Processor{Client: client, SuppressionID: "S1", Since: lastRun}
+//	report, err := p.Process(ctx, deliverability.Newsletter("N1"), deliverability.Autoresponder("A1"))
+package deliverability
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+const listPerPage = 100
+
+// Kinds of events
+const (
+	KindBounce    = "bounce"
+	KindComplaint = "complaint"
+)
+
+// BounceClass tells whether a bounce is permanent
+type BounceClass string
+
+// Classes of bounces
+const (
+	Hard BounceClass = "hard" // the address does not exist or refuses mail for good
+	Soft BounceClass = "soft" // a temporary failure such as a full mailbox
+)
+
+// Message references a message whose activities are processed
+type Message struct {
+	Collection string // "newsletters" or "autoresponders"
+	ID         string
+}
+
+// Newsletter references a newsletter
+func Newsletter(id string) Message {
+	return Message{Collection: "newsletters", ID: id}
+}
+
+// Autoresponder references an autoresponder
+func Autoresponder(id string) Message {
+	return Message{Collection: "autoresponders", ID: id}
+}
+
+// Event is a bounce or complaint
+type Event struct {
+	Kind      string
+	Class     BounceClass // empty for complaints
+	Email     string
+	ContactID string
+	Message   Message
+	Reason    string // the diagnostic of a bounce, as reported
+	CreatedOn time.Time
+}
+
+// Report lists what Process found and did
+type Report struct {
+	Events     []Event
+	Hard       int
+	Soft       int
+	Complaints int
+	// Suppressed are the addresses added to the suppression list, already suppressed ones are left out
+	Suppressed []string
+}
+
+// Processor fetches and classifies bounces and complaints
+type Processor struct {
+	Client getresponse.Client
+	// SuppressionID is the suppression list hard bounces are added to, nothing is suppressed when empty
+	SuppressionID string
+	// SuppressComplaints also adds the addresses that complained to the suppression list
+	SuppressComplaints bool
+	// Since skips older activities, all of them when not set
+	Since time.Time
+	// Location is the time zone the API counts the days of Since in, read with getresponse.AccountLocation when nil
+	Location *time.Location
+}
+
+// activity is a message activity as the API returns it
+type activity struct {
+	Activity   string  `json:"activity"`
+	Email      string  `json:"email"`
+	ContactID  string  `json:"contactId"`
+	BounceType string  `json:"bounceType"`
+	Reason     string  `json:"reason"`
+	CreatedOn  *string `json:"createdOn"`
+	Contact    struct {
+		ContactID string `json:"contactId"`
+		Email     string `json:"email"`
+	} `json:"contact"`
+}
+
+// suppression is a suppression list as the API returns it
+type suppression struct {
+	SuppressionID string   `json:"suppressionId,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Masks         []string `json:"masks"`
+}
+
+// Process fetches the bounces and complaints of the messages and suppresses the hard bounces, see Processor
+func (p *Processor) Process(ctx context.Context, messages ...Message) (*Report, error) {
+	r := &Report{}
+	loc := p.Location
+	if loc == nil && !p.Since.IsZero() {
+		var err error
+		loc, err = getresponse.AccountLocation(ctx, p.Client)
+		if err != nil {
+			return r, err
+		}
+	}
+	for _, m := range messages {
+		for _, kind := range []string{KindBounce, KindComplaint} {
+			events, err := p.fetch(ctx, m, kind, loc)
+			if err != nil {
+				return r, err
+			}
+			for _, e := range events {
+				switch {
+				case e.Kind == KindComplaint:
+					r.Complaints++
+				case e.Class == Hard:
+					r.Hard++
+				default:
+					r.Soft++
+				}
+			}
+			r.Events = append(r.Events, events...)
+		}
+	}
+	sort.SliceStable(r.Events, func(i, j int) bool {
+		return r.Events[i].CreatedOn.Before(r.Events[j].CreatedOn)
+	})
+
+	if p.SuppressionID == "" {
+		return r, nil
+	}
+	var emails []string
+	for _, e := range r.Events {
+		if e.Email != "" && (e.Class == Hard || (e.Kind == KindComplaint && p.SuppressComplaints)) {
+			emails = append(emails, e.Email)
+		}
+	}
+	suppressed, err := p.suppress(ctx, emails)
+	r.Suppressed = suppressed
+	return r, err
+}
+
+func (p *Processor) fetch(ctx context.Context, m Message, kind string, loc *time.Location) ([]Event, error) {
+	path := "/v3/" + m.Collection + "/" + url.PathEscape(m.ID) + "/activities"
+	query := url.Values{}
+	query.Set("query[activity]", kind)
+	getresponse.TimeRange{From: p.Since, Location: loc}.SetQuery(query, "createdOn")
+	query.Set("perPage", strconv.Itoa(listPerPage))
+
+	var events []Event
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		activities, err := getresponse.Get[[]activity](ctx, p.Client, path, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range activities {
+			if a.Activity != "" && a.Activity != kind {
+				continue
+			}
+			e := Event{Kind: kind, Email: a.Email, ContactID: a.ContactID, Message: m, Reason: a.Reason}
+			if e.Email == "" {
+				e.Email = a.Contact.Email
+			}
+			if e.ContactID == "" {
+				e.ContactID = a.Contact.ContactID
+			}
+			if a.CreatedOn != nil {
+				e.CreatedOn, _ = time.Parse("2006-01-02T15:04:05-0700", *a.CreatedOn)
+			}
+			if !p.Since.IsZero() && !e.CreatedOn.IsZero() && e.CreatedOn.Before(p.Since) {
+				continue
+			}
+			if kind == KindBounce {
+				e.Class = Classify(a.BounceType, a.Reason)
+			}
+			events = append(events, e)
+		}
+		if len(activities) < listPerPage {
+			return events, nil
+		}
+	}
+}
+
+// suppress adds the emails missing from the suppression list and returns them
+func (p *Processor) suppress(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	path := "/v3/suppressions/" + url.PathEscape(p.SuppressionID)
+	s, err := getresponse.Get[suppression](ctx, p.Client, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	have := map[string]bool{}
+	for _, mask := range s.Masks {
+		have[strings.ToLower(mask)] = true
+	}
+	var added []string
+	for _, email := range emails {
+		if !have[strings.ToLower(email)] {
+			have[strings.ToLower(email)] = true
+			added = append(added, email)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	// the masks of a suppression list are replaced as a whole
+	update := suppression{Name: s.Name, Masks: append(s.Masks, added...)}
+	_, err = getresponse.Post[suppression, suppression](ctx, p.Client, path, update)
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// enhancedStatus matches an RFC 3463 enhanced status code such as 5.1.1
+var enhancedStatus = regexp.MustCompile(`\b([245])\.\d{1,3}\.\d{1,3}\b`)
+
+// basicStatus matches an SMTP reply code such as 550
+var basicStatus = regexp.MustCompile(`\b([45])\d\d\b`)
+
+// softReasons are diagnostics of temporary failures some servers report with a permanent code
+var softReasons = []string{"mailbox full", "quota exceeded", "over quota", "insufficient storage", "try again", "temporarily"}
+
+// Classify tells a hard bounce from a soft one, by the bounce type the API reports when it does, and by the SMTP
+// status of the diagnostic otherwise. Unknown bounces are soft, so addresses are never suppressed by guess.
+func Classify(bounceType, reason string) BounceClass {
+	switch strings.ToLower(bounceType) {
+	case "hard", "permanent":
+		return Hard
+	case "soft", "transient", "temporary":
+		return Soft
+	}
+
+	lower := strings.ToLower(reason)
+	for _, s := range softReasons {
+		if strings.Contains(lower, s) {
+			return Soft
+		}
+	}
+	if m := enhancedStatus.FindStringSubmatch(reason); m != nil {
+		if m[1] == "5" {
+			return Hard
+		}
+		return Soft
+	}
+	if m := basicStatus.FindStringSubmatch(reason); m != nil && m[1] == "5" {
+		return Hard
+	}
+	return Soft
+}
@@ -0,0 +1,87 @@
+package deliverability
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_Classify(t *testing.T) {
+	type testcase struct {
+		bounceType string
+		reason     string
+		expected   BounceClass
+	}
+	testcases := []testcase{
+		{bounceType: "hard", expected: Hard},
+		{bounceType: "Soft", reason: "550 5.1.1 user unknown", expected: Soft},
+		{reason: "550 5.1.1 <a@example.com>: Recipient address rejected: User unknown", expected: Hard},
+		{reason: "452 4.2.2 Mailbox full", expected: Soft},
+		{reason: "552 5.2.2 mailbox full", expected: Soft},
+		{reason: "554 delivery error: This user doesn't have an account", expected: Hard},
+		{reason: "421 Service not available", expected: Soft},
+		{reason: "no such user", expected: Soft},
+	}
+	for _, tc := range testcases {
+		if actual := Classify(tc.bounceType, tc.reason); actual != tc.expected {
+			t.Fatalf("Actual class of (%s, %s) (%s) did not match expected (%s)", tc.bounceType, tc.reason, actual, tc.expected)
+		}
+	}
+}
+
+func TestUnit_Process(t *testing.T) {
+	var update string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path + " " + r.URL.Query().Get("query[activity]") {
+		case "GET /v3/accounts ":
+			fmt.Fprint(w, `{"accountId":"a","timeZone":{"name":"America/New_York","offset":"-05:00"}}`)
+		case "GET /v3/newsletters/n1/activities bounce":
+			// midnight UTC is still the day before in New York
+			if r.URL.Query().Get("query[createdOn][from]") != "2026-02-28" {
+				t.Errorf("Unexpected query (%v)", r.URL.Query())
+			}
+			fmt.Fprint(w, `[
+				{"activity":"bounce","email":"gone@example.com","reason":"550 5.1.1 user unknown","createdOn":"2026-03-02T10:00:00+0000"},
+				{"activity":"bounce","contact":{"contactId":"c2","email":"full@example.com"},"reason":"452 4.2.2 mailbox full","createdOn":"2026-03-02T09:00:00+0000"},
+				{"activity":"bounce","email":"kept@example.com","bounceType":"hard","createdOn":"2026-03-02T11:00:00+0000"},
+				{"activity":"bounce","email":"old@example.com","bounceType":"hard","createdOn":"2026-02-27T11:00:00+0000"}]`)
+		case "GET /v3/newsletters/n1/activities complaint":
+			fmt.Fprint(w, `[{"activity":"complaint","email":"angry@example.com","createdOn":"2026-03-03T10:00:00+0000"}]`)
+		case "GET /v3/suppressions/s1 ":
+			fmt.Fprint(w, `{"suppressionId":"s1","name":"bounces","masks":["KEPT@example.com"]}`)
+		case "POST /v3/suppressions/s1 ":
+			body, _ := ioutil.ReadAll(r.Body)
+			update = string(body)
+			fmt.Fprint(w, `{"suppressionId":"s1"}`)
+		default:
+			t.Errorf("Unexpected request (%s %s)", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+	client := getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling())
+
+	p := &Processor{Client: client, SuppressionID: "s1", Since: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}
+	r, err := p.Process(context.Background(), Newsletter("n1"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if r.Hard != 2 || r.Soft != 1 || r.Complaints != 1 || len(r.Events) != 4 {
+		t.Fatalf("Unexpected report (%+v)", r)
+	}
+	if first := r.Events[0]; first.Email != "full@example.com" || first.ContactID != "c2" || first.Class != Soft {
+		t.Fatalf("Unexpected first event (%+v)", first)
+	}
+	if fmt.Sprint(r.Suppressed) != "[gone@example.com]" {
+		t.Fatalf("Actual suppressed (%v) did not match expected ([gone@example.com])", r.Suppressed)
+	}
+	expected := `{"name":"bounces","masks":["KEPT@example.com","gone@example.com"]}`
+	if update != expected {
+		t.Fatalf("Actual update (%s) did not match expected (%s)", update, expected)
+	}
+}
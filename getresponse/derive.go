@@ -0,0 +1,43 @@
+package getresponse
+
+import "net/http"
+
+// WithDomain sets the domain of a GetResponse MAX account, e.g. to derive a per-tenant client with Client.With
+func WithDomain(domain string) Option {
+	return func(g *getResponseClient) {
+		g.domain = domain
+	}
+}
+
+// constructor holds the NewClient arguments a client was built with, so derived clients can be built the same way
+type constructor struct {
+	apiUrl, domain string
+	credentials    CredentialsProvider // from the API key, before options
+	client         *http.Client        // before middlewares
+	opts           []Option
+}
+
+// sharedThrottler makes a derived client wait on the throttler of its parent: both spend the budget of the same
+// API key unless the derivation changes it, in which case it should set its own throttler
+func sharedThrottler(t *Throttler) Option {
+	return func(g *getResponseClient) {
+		g.throttler = t
+	}
+}
+
+// withoutInheritedCache drops the cache of the parent, whose entries are keyed by path alone and would leak between
+// accounts
+func withoutInheritedCache() Option {
+	return func(g *getResponseClient) {
+		g.cache = nil
+	}
+}
+
+func (g *getResponseClient) With(opts ...Option) Client {
+	all := make([]Option, 0, 1+len(g.constructor.opts)+2+len(opts))
+	all = append(all, WithCredentialsProvider(g.constructor.credentials))
+	all = append(all, g.constructor.opts...)
+	all = append(all, sharedThrottler(g.throttler), withoutInheritedCache())
+	all = append(all, opts...)
+	return NewClient(g.constructor.apiUrl, "", g.constructor.domain, g.constructor.client, all...)
+}
@@ -0,0 +1,49 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnit_ClientWith(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "api-key key" {
+			t.Errorf("Unexpected auth header (%s)", r.Header.Get("X-Auth-Token"))
+		}
+		fmt.Fprintf(w, `[{"campaignId":"%s"}]`, r.Header.Get("X-Domain"))
+	}))
+	defer ts.Close()
+
+	parent := NewClient(ts.URL, "key", "", nil,
+		WithMaxIdleConnsPerHost(5),
+		WithCache(NewLRUCache(10), time.Minute),
+	).(*getResponseClient)
+
+	derived := parent.With(WithDomain("tenant.example.com"), WithReadOnly()).(*getResponseClient)
+	if derived.c != parent.c || derived.throttler != parent.throttler {
+		t.Fatalf("Expected the transport and throttler to be shared")
+	}
+	if derived.cache != nil || derived.domain != "tenant.example.com" || parent.domain != "" {
+		t.Fatalf("Unexpected derived configuration (%#v)", derived)
+	}
+
+	res, err := derived.Campaigns().List(context.Background(), &GetCampaignsRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(res.Campaigns) != 1 || res.Campaigns[0].CampaignID != "tenant.example.com" {
+		t.Fatalf("Unexpected campaigns (%#v)", res)
+	}
+	_, err = derived.Campaigns().Create(context.Background(), &CreateCampaignRequest{Name: "list"})
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("Actual error (%#v) did not match expected (%v)", err, ErrPermissionDenied)
+	}
+	if len(parent.deniedPermissions) != 0 {
+		t.Fatalf("Expected the parent to be left untouched")
+	}
+}
@@ -0,0 +1,114 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// do sends a request and checks the response for API errors. Successful writes invalidate the cached responses of
+// path and are reported to the audit hook.
+func (g *getResponseClient) do(ctx context.Context, method, path string, query url.Values, body []byte) (int, []byte, error) {
+	return g.doBody(ctx, method, path, query, bytesBody(body))
+}
+
+// doBody is do with a body that may be streamed
+func (g *getResponseClient) doBody(ctx context.Context, method, path string, query url.Values, body *requestBody) (int, []byte, error) {
+	ctx, done, err := g.lifecycle.begin(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer done()
+
+	status, ret, err := g.roundTrip(ctx, method, path, query, body)
+	err = g.checkGetResponseError(ctx, method, path, status, ret, err)
+	if err != nil {
+		return status, ret, err
+	}
+	if !isRead(method, path) {
+		g.invalidate(path)
+		var data []byte
+		if body != nil {
+			data = body.data
+		}
+		err = g.audit(ctx, method, path, data, ret)
+	}
+	return status, ret, err
+}
+
+// doJSON sends a request and decodes the response into a Resp. The raw body is returned for Raw fields.
+func doJSON[Resp any](ctx context.Context, g *getResponseClient, method, path string, query url.Values, body []byte) (Resp, json.RawMessage, error) {
+	var result Resp
+	status, ret, err := g.do(ctx, method, path, query, body)
+	if err != nil {
+		return result, nil, err
+	}
+
+	jErr := g.decode(ctx, ret, &result)
+	if jErr != nil {
+		return result, nil, g.decodeError(ctx, method, path, status, ret, jErr)
+	}
+	return result, g.raw(ret), nil
+}
+
+// doGet fetches path and decodes the response into a T
+func doGet[T any](ctx context.Context, g *getResponseClient, path string, query url.Values) (T, json.RawMessage, error) {
+	return doJSON[T](ctx, g, http.MethodGet, path, query, nil)
+}
+
+// doPost posts req as JSON to path and decodes the response into a Resp
+func doPost[Req, Resp any](ctx context.Context, g *getResponseClient, path string, req Req) (Resp, json.RawMessage, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		var result Resp
+		return result, nil, err
+	}
+	return doJSON[Resp](ctx, g, http.MethodPost, path, nil, body)
+}
+
+func (g *getResponseClient) Do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var reqBody *requestBody
+	switch body := body.(type) {
+	case nil:
+	case io.Reader:
+		var err error
+		reqBody, err = readerBody(body)
+		if err != nil {
+			return err
+		}
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytesBody(data)
+	}
+
+	status, ret, err := g.doBody(ctx, method, path, query, reqBody)
+	if err != nil || out == nil || len(ret) == 0 {
+		return err
+	}
+	jErr := g.decode(ctx, ret, out)
+	if jErr != nil {
+		return g.decodeError(ctx, method, path, status, ret, jErr)
+	}
+	return nil
+}
+
+// Get fetches an endpoint the client has no method for and decodes the response into a T, e.g.
+//
+//	webforms, err := getresponse.Get[[]Webform](ctx, client, "/v3/webforms", nil)
+func Get[T any](ctx context.Context, c Client, path string, query url.Values) (T, error) {
+	var result T
+	err := c.Do(ctx, http.MethodGet, path, query, nil, &result)
+	return result, err
+}
+
+// Post posts req as JSON to an endpoint the client has no method for and decodes the response into a Resp
+func Post[Req, Resp any](ctx context.Context, c Client, path string, req Req) (Resp, error) {
+	var result Resp
+	err := c.Do(ctx, http.MethodPost, path, nil, req, &result)
+	return result, err
+}
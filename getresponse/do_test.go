@@ -0,0 +1,191 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testWebform struct {
+	WebformID string `json:"webformId"`
+	Name      string `json:"name"`
+}
+
+func TestUnit_GenericHelpers(t *testing.T) {
+	var calls []string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		calls = append(calls, fmt.Sprintf("%s %s %s %s", r.Method, r.URL.Path, r.URL.RawQuery, body))
+		switch r.URL.Path {
+		case "/v3/webforms":
+			fmt.Fprint(w, `[{"webformId":"w1","name":"Signup"}]`)
+		case "/v3/webforms/w1":
+			fmt.Fprint(w, `{"webformId":"w1","name":"Renamed"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":1013,"message":"not found"}`)
+		}
+	}))
+	defer ts.Close()
+
+	webforms, err := Get[[]testWebform](context.Background(), c, "/v3/webforms", map[string][]string{"page": {"1"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(webforms) != 1 || webforms[0].Name != "Signup" {
+		t.Fatalf("Actual webforms (%#v) did not match expected", webforms)
+	}
+
+	webform, err := Post[testWebform, testWebform](context.Background(), c, "/v3/webforms/w1", testWebform{Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if webform.Name != "Renamed" {
+		t.Fatalf("Actual webform (%#v) did not match expected", webform)
+	}
+
+	_, err = Get[testWebform](context.Background(), c, "/v3/webforms/missing", nil)
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != 1013 || apiErr.Path != "/v3/webforms/missing" {
+		t.Fatalf("Expected an APIError, got (%#v)", err)
+	}
+
+	expected := []string{
+		"GET /v3/webforms page=1 ",
+		`POST /v3/webforms/w1  {"webformId":"","name":"Renamed"}`,
+		"GET /v3/webforms/missing  ",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Fatalf("Actual calls (%q) did not match expected (%q)", calls, expected)
+	}
+}
+
+func TestUnit_DoStreamsReaders(t *testing.T) {
+	var bodies []string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, fmt.Sprintf("%d %s", r.ContentLength, body))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"code":1,"message":"unavailable"}`)
+	}))
+	defer ts.Close()
+	c = NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithDefaultPolicy(Policy{MaxRetries: 1}))
+
+	payload := `{"contacts":[]}`
+	err := c.Do(context.Background(), http.MethodPost, "/v3/imports", nil, strings.NewReader(payload), nil)
+	if err == nil {
+		t.Fatalf("Expected error did not occur")
+	}
+	// a reader that cannot seek is sent once, with an unknown length
+	err = c.Do(context.Background(), http.MethodPost, "/v3/imports", nil, io.MultiReader(strings.NewReader(payload)), nil)
+	if err == nil {
+		t.Fatalf("Expected error did not occur")
+	}
+
+	expected := []string{"15 " + payload, "15 " + payload, "-1 " + payload}
+	if fmt.Sprint(bodies) != fmt.Sprint(expected) {
+		t.Fatalf("Actual bodies (%v) did not match expected (%v)", bodies, expected)
+	}
+}
+
+func TestUnit_RetriesReplayLargeBodies(t *testing.T) {
+	payload := `{"data":"` + strings.Repeat("x", 4<<20) + `"}`
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	var received []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.URL.Path]++
+		first := attempts[r.URL.Path] == 1
+		mu.Unlock()
+		if first {
+			// fail before reading the body, while the client is still sending it
+			io.CopyN(ioutil.Discard, r.Body, 1024)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"code":1,"message":"unavailable"}`)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, fmt.Sprintf("%s %d %v", r.URL.Path, len(body), string(body) == payload))
+		mu.Unlock()
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	// a middleware retrying on its own replays the body with GetBody
+	replay := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(req.URL.Path, "/middleware") {
+				return next.RoundTrip(req)
+			}
+			res, err := next.RoundTrip(req)
+			if err == nil && res.StatusCode != http.StatusServiceUnavailable {
+				return res, nil
+			}
+			if res != nil {
+				res.Body.Close()
+			}
+			retry := req.Clone(req.Context())
+			retry.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(retry)
+		})
+	}
+	c := NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithMiddleware(replay),
+		WithDefaultPolicy(Policy{MaxRetries: 1}))
+
+	bodies := map[string]interface{}{
+		"/v3/bytes":      json.RawMessage(payload),
+		"/v3/reader":     bytes.NewReader([]byte(payload)),
+		"/v3/buffer":     bytes.NewBufferString(payload),
+		"/v3/middleware": bytes.NewReader([]byte(payload)),
+	}
+	for path, body := range bodies {
+		err := c.Do(context.Background(), http.MethodPost, path, nil, body, nil)
+		if err != nil {
+			t.Errorf("%s: Unexpected error occurred (%#v)", path, err)
+		}
+	}
+
+	sort.Strings(received)
+	expected := []string{"/v3/buffer", "/v3/bytes", "/v3/middleware", "/v3/reader"}
+	for i := range expected {
+		expected[i] = fmt.Sprintf("%s %d true", expected[i], len(payload))
+	}
+	if fmt.Sprint(received) != fmt.Sprint(expected) {
+		t.Fatalf("Actual bodies (%v) did not match expected (%v)", received, expected)
+	}
+}
+
+func TestUnit_SeekableBodyAttemptsDoNotOverlap(t *testing.T) {
+	body, err := readerBody(strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	first, _ := body.open()
+	buf := make([]byte, 4)
+	first.Read(buf)
+
+	second, _ := body.open()
+	// the first attempt is still being written by the transport when the second one starts
+	if _, err := first.Read(buf); err != errBodyConsumed {
+		t.Fatalf("Actual error (%v) did not match expected (%v)", err, errBodyConsumed)
+	}
+	data, err := ioutil.ReadAll(second)
+	if err != nil || string(data) != "0123456789" {
+		t.Fatalf("Actual body (%q, %v) did not match expected (%q)", data, err, "0123456789")
+	}
+}
@@ -0,0 +1,30 @@
+package getresponse
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type validator interface {
+	Validate() error
+}
+
+// validateDryRun checks requests locally when in dry-run mode, since the API is not there to reject them
+func (g *getResponseClient) validateDryRun(v validator) error {
+	if !g.dryRun {
+		return nil
+	}
+	return v.Validate()
+}
+
+// dryRunResponse logs a skipped mutating request and fakes a successful response for it. The request body is
+// echoed back so update calls return the data they would have written.
+func (g *getResponseClient) dryRunResponse(ctx context.Context, method string, u *url.URL, body []byte) (int, []byte, error) {
+	g.logCtxf(ctx, "dry run: skipping %s %s %s", method, u.String(), body)
+
+	if len(body) == 0 {
+		return http.StatusNoContent, nil, nil
+	}
+	return http.StatusOK, body, nil
+}
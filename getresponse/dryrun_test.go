@@ -0,0 +1,65 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnit_DryRun(t *testing.T) {
+	var methods []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		fmt.Fprint(w, `{"contactId":"foo","name":"foobar","email":"foo@bar.baz"}`)
+	}))
+	defer ts.Close()
+
+	logs := &bytes.Buffer{}
+	c := NewClient(ts.URL, "", "", nil, WithDryRun(true), WithLogger(log.New(logs, "", 0)))
+	ctx := context.Background()
+
+	err := c.CreateContact(ctx, &CreateContactRequest{Email: "foo@bar.baz", Campaign: Campaign{CampaignID: "123"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	err = c.CreateContact(ctx, &CreateContactRequest{Email: "foo@bar.baz"})
+	if vErr, ok := err.(*ValidationError); !ok || vErr.Field != "campaign.campaignId" {
+		t.Fatalf("Expected validation error, got (%#v)", err)
+	}
+
+	updated, err := c.UpdateContact(ctx, &UpdateContactRequest{ID: "foo", NewData: Contact{Name: makeStringPtr("new name")}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if *updated.Contact.Name != "new name" {
+		t.Fatalf("Expected the update to be echoed back, got (%#v)", updated.Contact)
+	}
+
+	_, err = c.UpdateContactCustomFields(ctx, &UpdateContactCustomFieldsRequest{ID: "foo", CustomFields: []CustomField{{CustomFieldID: "a", Value: []string{"b"}}}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	err = c.DeleteContact(ctx, &DeleteContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	_, err = c.GetContact(ctx, &GetContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	if len(methods) != 1 || methods[0] != http.MethodGet {
+		t.Fatalf("Only the read should reach the API, got (%v)", methods)
+	}
+	if strings.Count(logs.String(), "dry run: skipping") != 4 {
+		t.Fatalf("Expected 4 skipped requests to be logged, got (%s)", logs.String())
+	}
+}
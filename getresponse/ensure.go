@@ -0,0 +1,99 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnsureCustomFieldOptions controls how CustomFieldsClient.Ensure checks a custom field that already exists
+type EnsureCustomFieldOptions struct {
+	// AllowExtraValues accepts an existing field with values besides the requested ones, e.g. options added by hand
+	AllowExtraValues bool
+	// IgnoreHidden accepts an existing field whatever its visibility
+	IgnoreHidden bool
+}
+
+// CustomFieldMismatchError is returned by CustomFieldsClient.Ensure when the custom field exists with another
+// definition, which GetResponse does not allow changing
+type CustomFieldMismatchError struct {
+	Name  string
+	Field string // "type", "hidden" or "values"
+	Want  string
+	Have  string
+}
+
+func (e *CustomFieldMismatchError) Error() string {
+	return fmt.Sprintf("custom field %q exists with %s %q, want %q", e.Name, e.Field, e.Have, e.Want)
+}
+
+func (g *getResponseClient) ensureCustomField(ctx context.Context, request *CreateCustomFieldRequest, opts *EnsureCustomFieldOptions) (string, error) {
+	if request == nil || request.Name == "" {
+		return "", &ValidationError{Field: "name", Message: "is required"}
+	}
+	o := EnsureCustomFieldOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	def, err := g.customFieldCatalog.Lookup(ctx, request.Name)
+	var unknown *UnknownCustomFieldError
+	if errors.As(err, &unknown) {
+		create := *request
+		if create.Values == nil {
+			create.Values = []string{}
+		}
+		created, createErr := g.createCustomField(ctx, &create)
+		if createErr == nil {
+			return created.CustomFieldID, nil
+		}
+		// another process may have created it in between
+		if g.customFieldCatalog.Refresh(ctx) != nil {
+			return "", createErr
+		}
+		def, err = g.customFieldCatalog.Lookup(ctx, request.Name)
+		if err != nil {
+			return "", createErr
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return def.CustomFieldID, checkCustomFieldDefinition(def, request, o)
+}
+
+// checkCustomFieldDefinition compares an existing definition with the requested one
+func checkCustomFieldDefinition(def CustomFieldDefinition, request *CreateCustomFieldRequest, o EnsureCustomFieldOptions) error {
+	mismatch := func(field, want, have string) error {
+		return &CustomFieldMismatchError{Name: request.Name, Field: field, Want: want, Have: have}
+	}
+	if request.Type != "" && def.Type != request.Type {
+		return mismatch("type", request.Type, def.Type)
+	}
+	if !o.IgnoreHidden && def.Hidden != "" && def.Hidden != fmt.Sprint(request.Hidden) {
+		return mismatch("hidden", fmt.Sprint(request.Hidden), def.Hidden)
+	}
+
+	have := map[string]bool{}
+	for _, v := range def.Values {
+		have[v] = true
+	}
+	want := map[string]bool{}
+	missing := false
+	for _, v := range request.Values {
+		want[v] = true
+		missing = missing || !have[v]
+	}
+	if missing || (!o.AllowExtraValues && len(have) != len(want)) {
+		return mismatch("values", joinSorted(request.Values), joinSorted(def.Values))
+	}
+	return nil
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,55 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_EnsureCustomField(t *testing.T) {
+	created := 0
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			created++
+			fmt.Fprint(w, `{"customFieldId":"new","name":"company"}`)
+			return
+		}
+		fmt.Fprint(w, `[{"customFieldId":"f1","name":"plan","type":"single_select","hidden":"false","values":["pro","free","legacy"]}]`)
+	})
+	defer ts.Close()
+	ctx := context.Background()
+
+	id, err := c.CustomFields().Ensure(ctx, &CreateCustomFieldRequest{Name: "company", Type: "text"}, nil)
+	if err != nil || id != "new" || created != 1 {
+		t.Fatalf("Unexpected result (%v, %#v, %d)", id, err, created)
+	}
+
+	type testcase struct {
+		request *CreateCustomFieldRequest
+		opts    *EnsureCustomFieldOptions
+		field   string
+	}
+	plan := func(typ string, hidden bool, values ...string) *CreateCustomFieldRequest {
+		return &CreateCustomFieldRequest{Name: "plan", Type: typ, Hidden: hidden, Values: values}
+	}
+	for _, tc := range []testcase{
+		{plan("single_select", false, "free", "legacy", "pro"), nil, ""},
+		{plan("single_select", false, "free", "pro"), &EnsureCustomFieldOptions{AllowExtraValues: true}, ""},
+		{plan("single_select", true, "free", "legacy", "pro"), &EnsureCustomFieldOptions{IgnoreHidden: true}, ""},
+		{plan("single_select", false, "free", "pro"), nil, "values"},
+		{plan("single_select", false, "free", "pro", "enterprise"), &EnsureCustomFieldOptions{AllowExtraValues: true}, "values"},
+		{plan("multi_select", false, "free", "legacy", "pro"), nil, "type"},
+		{plan("single_select", true, "free", "legacy", "pro"), nil, "hidden"},
+	} {
+		id, err := c.CustomFields().Ensure(ctx, tc.request, tc.opts)
+		var mismatch *CustomFieldMismatchError
+		if id != "f1" || (tc.field == "" && err != nil) || (tc.field != "" && (!errors.As(err, &mismatch) || mismatch.Field != tc.field)) {
+			t.Fatalf("Actual result (%v, %v) did not match expected mismatch (%v)", id, err, tc.field)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("Actual creations (%d) did not match expected (%d)", created, 1)
+	}
+}
@@ -1,20 +1,179 @@
 package getresponse
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultErrorBodyLimit = 1024
+
+// errorCodeDescriptions are the descriptions of the error codes documented @ https://apidocs.getresponse.com/v3/errors
+var errorCodeDescriptions = map[int]string{
+	ErrInternalError:           "internal error",
+	ErrValidationError:         "validation error",
+	ErrRelatedResourceNotFound: "related resource not found",
+	ErrForbidden:               "forbidden",
+	ErrInvalidParameterFormat:  "invalid parameter format",
+	ErrInvalidHash:             "invalid hash",
+	ErrMissingParameter:        "missing parameter",
+	ErrInvalidParameterType:    "invalid parameter type",
+	ErrInvalidParameterLength:  "invalid parameter length",
+	ErrResourceAlreadyExists:   "resource already exists",
+	ErrResourceInUse:           "resource in use",
+	ErrExternalError:           "external error",
+	ErrMessageAlreadySending:   "message is already sending",
+	ErrMessageParsing:          "message parsing error",
+	ErrResourceNotFound:        "resource not found",
+	ErrAuthenticationFailure:   "authentication failure",
+	ErrequestQuotaReached:      "request quota reached",
+	ErrTemporarilyBlocked:      "temporarily blocked",
+	ErrPermanentlyBlocked:      "permanently blocked",
+	ErrIPBlocked:               "IP blocked",
+	ErrInvalidRequestHeaders:   "invalid request headers",
+	ErrRequestForbidden:        "request forbidden",
+}
+
+// ErrorCodeDescription returns the documented description of a GetResponse error code, "" for unknown codes
+func ErrorCodeDescription(code int) string {
+	return errorCodeDescriptions[code]
+}
+
 // GetResponseError holds an API error
 type GetResponseError struct {
-	HTTPStatus      int      `json:"httpStatus"`
-	ErrorCode       int      `json:"code"`
-	CodeDescription string   `json:"codeDescription"`
-	Message         string   `json:"message"`
-	MoreInfo        string   `json:"moreInfo"`
-	Context         []string `json:"context"`
-	UUID            string   `json:"uuid"`
+	HTTPStatus      int          `json:"httpStatus"`
+	ErrorCode       int          `json:"code"`
+	CodeDescription string       `json:"codeDescription"`
+	Message         string       `json:"message"`
+	MoreInfo        string       `json:"moreInfo"`
+	Context         ErrorContext `json:"context"`
+	UUID            string       `json:"uuid"`
 }
 
 func (g *GetResponseError) Error() string {
 	return g.Message
 }
 
+// UnmarshalJSON decodes an error response leniently: fields of unexpected types, e.g. a numeric message, a code sent
+// as a string or a context object, are converted instead of failing the whole error. Only a body that is not a JSON
+// object is rejected.
+func (g *GetResponseError) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	*g = GetResponseError{}
+	for key, value := range fields {
+		switch strings.ToLower(key) {
+		case "httpstatus":
+			g.HTTPStatus = lenientInt(value)
+		case "code":
+			g.ErrorCode = lenientInt(value)
+		case "codedescription":
+			g.CodeDescription = lenientString(value)
+		case "message":
+			g.Message = lenientString(value)
+		case "moreinfo":
+			g.MoreInfo = lenientString(value)
+		case "uuid":
+			g.UUID = lenientString(value)
+		case "context":
+			err = g.Context.UnmarshalJSON(value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ErrorContext holds the context of an API error as it was sent. It is usually a list of strings, but some endpoints
+// send an object or a list of objects.
+type ErrorContext struct {
+	Raw json.RawMessage // nil if the error had no context
+}
+
+// Strings returns the elements of a list context as strings, objects and other values as compact JSON. Any other
+// context is returned as a single element.
+func (c ErrorContext) Strings() []string {
+	if len(c.Raw) == 0 {
+		return nil
+	}
+	return lenientStrings(c.Raw)
+}
+
+// Decode unmarshals the context into v, for callers that know the shape an endpoint sends
+func (c ErrorContext) Decode(v interface{}) error {
+	if len(c.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(c.Raw, v)
+}
+
+func (c *ErrorContext) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		c.Raw = nil
+		return nil
+	}
+	c.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func (c ErrorContext) MarshalJSON() ([]byte, error) {
+	if len(c.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return c.Raw, nil
+}
+
+// lenientString returns a JSON string's value and any other JSON value as compact JSON text, "" for null
+func lenientString(data json.RawMessage) string {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return ""
+	}
+	b := &bytes.Buffer{}
+	if json.Compact(b, data) != nil {
+		return string(data)
+	}
+	return b.String()
+}
+
+// lenientInt returns a JSON number or numeric string as an int, 0 otherwise
+func lenientInt(data json.RawMessage) int {
+	var f float64
+	if json.Unmarshal(data, &f) == nil && f >= -1<<31 && f <= 1<<31-1 {
+		return int(f)
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(lenientString(data)))
+	return n
+}
+
+// lenientStrings returns the elements of a JSON array as strings, and any other value but null as a single element
+func lenientStrings(data json.RawMessage) []string {
+	var list []json.RawMessage
+	if json.Unmarshal(data, &list) != nil {
+		if s := lenientString(data); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	ret := make([]string, 0, len(list))
+	for _, v := range list {
+		ret = append(ret, lenientString(v))
+	}
+	return ret
+}
+
 // GetResponseErrorRaw holds an API response that could not be unmarshaled
 type GetResponseErrorRaw struct {
 	Err        error
@@ -25,3 +184,103 @@ type GetResponseErrorRaw struct {
 func (g *GetResponseErrorRaw) Error() string {
 	return g.Err.Error()
 }
+
+// Unwrap allows errors.Is/errors.As to inspect the underlying error, e.g. errors.Is(err, ErrCouldNotUnmarshal)
+func (g *GetResponseErrorRaw) Unwrap() error {
+	return g.Err
+}
+
+// ValidationError is returned when a request is rejected locally before being sent
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (v *ValidationError) Error() string {
+	return v.Field + " " + v.Message
+}
+
+// APIError wraps every error returned from an API call with the context needed to trace it.
+// The underlying *GetResponseError, *GetResponseErrorRaw or transport error is available through errors.As.
+type APIError struct {
+	Method     string
+	Path       string
+	HTTPStatus int    // 0 if no response was received
+	ErrorCode  int    // GetResponse error code, if the API returned one
+	UUID       string // GetResponse error UUID, if the API returned one
+	// CodeDescription describes ErrorCode, see ErrorCodeDescription. Codes missing from the table keep the
+	// description sent by the API.
+	CodeDescription string
+	// CorrelationID is the id attached to the request context with ContextWithCorrelationID
+	CorrelationID string
+	Body          []byte // response body, truncated according to WithErrorBodyLimit and redacted, see WithRedactor
+	Truncated     bool
+	Err           error
+
+	redact Redactor
+}
+
+func (e *APIError) Error() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s %s", e.Method, e.Path)
+	if e.HTTPStatus != 0 {
+		fmt.Fprintf(b, ": %d", e.HTTPStatus)
+	}
+	if e.ErrorCode != 0 {
+		fmt.Fprintf(b, ": code %d", e.ErrorCode)
+		if e.CodeDescription != "" {
+			fmt.Fprintf(b, " %s", e.CodeDescription)
+		}
+	}
+	if e.UUID != "" {
+		fmt.Fprintf(b, " (uuid %s)", e.UUID)
+	}
+	if e.CorrelationID != "" {
+		fmt.Fprintf(b, " (correlation id %s)", e.CorrelationID)
+	}
+	fmt.Fprintf(b, ": %s", e.Err.Error())
+	raw := &GetResponseErrorRaw{}
+	if errors.As(e.Err, &raw) && len(e.Body) > 0 {
+		fmt.Fprintf(b, ": body %q", e.Body)
+		if e.Truncated {
+			b.WriteString("...")
+		}
+	}
+	if e.redact != nil {
+		return e.redact(b.String())
+	}
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+func (g *getResponseClient) newAPIError(ctx context.Context, method, path string, status int, body []byte, err error) error {
+	apiErr := &APIError{
+		Method:     method,
+		Path:       path,
+		HTTPStatus: status,
+		Body:       body,
+		Err:        err,
+		redact:     g.redact,
+	}
+	apiErr.CorrelationID, _ = CorrelationIDFromContext(ctx)
+	if g.errorBodyLimit > 0 && len(body) > g.errorBodyLimit {
+		apiErr.Body = body[:g.errorBodyLimit]
+		apiErr.Truncated = true
+	}
+	if g.redact != nil {
+		apiErr.Body = []byte(g.redact(string(apiErr.Body)))
+	}
+	if grErr, ok := err.(*GetResponseError); ok {
+		apiErr.ErrorCode = grErr.ErrorCode
+		apiErr.UUID = grErr.UUID
+		apiErr.CodeDescription = ErrorCodeDescription(grErr.ErrorCode)
+		if apiErr.CodeDescription == "" {
+			apiErr.CodeDescription = grErr.CodeDescription
+		}
+	}
+
+	return apiErr
+}
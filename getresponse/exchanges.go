@@ -1,5 +1,11 @@
 package getresponse
 
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
 type (
 	CreateContactRequest struct {
 		Name         *string       `json:"name,omitempty"`
@@ -8,16 +14,33 @@ type (
 		Campaign     Campaign      `json:"campaign"`
 		CustomFields []CustomField `json:"customFieldValues,omitempty"`
 		IPAddress    *string       `json:"ipAddress,omitempty"`
+		Tags         []Tag         `json:"tags,omitempty"`
+		// NamedCustomFields are custom field values keyed by field name, resolved with the client's catalog and
+		// sent along CustomFields, see CustomFieldCatalog.Fields for the accepted values
+		NamedCustomFields map[string]interface{} `json:"-"`
+		// TagNames are tags added by name, resolved with the client's catalog, see WithTagAutoCreate
+		TagNames []string `json:"-"`
 	}
 	UpdateContactResponse struct {
 		Contact Contact
+		Raw     json.RawMessage // response body, set with WithRawResponses
 	}
 	UpdateContactRequest struct {
 		ID      string
 		NewData Contact
+		// Fields, when not nil, is a field mask: only the listed JSON names of NewData are sent, so a contact read
+		// with GetContact can be modified and sent back without overwriting what other updaters changed meanwhile.
+		// See ChangedContactFields. Names of fields that cannot be updated are rejected.
+		Fields []string
+		// Clear lists the JSON names of fields to set to null, e.g. "name" or "dayOfCycle". Nil fields of NewData
+		// are left unchanged, so this is the only way to clear one.
+		Clear []string
+		// TagNames are tags set by name along NewData.Tags, resolved with the client's catalog, see WithTagAutoCreate
+		TagNames []string
 	}
 	GetContactResponse struct {
 		Contact Contact
+		Raw     json.RawMessage // response body, set with WithRawResponses
 	}
 	GetContactRequest struct {
 		ID     string
@@ -25,13 +48,15 @@ type (
 	}
 	GetContactsResponse struct {
 		Contacts []Contact
+		Raw      json.RawMessage // response body, set with WithRawResponses
 	}
 	GetContactsRequest struct {
 		QueryHash       map[string]string
 		Fields          []string
 		SortHash        map[string]string
-		Page            int32
-		PerPage         int32
+		Sort            []SortTerm // sent before the SortHash entries, in priority order
+		Page            int32      // 0 leaves it out, the API then returns the first page
+		PerPage         int32      // 0 leaves it out, the API then returns 100 contacts
 		AdditionalFlags *string
 	}
 	UpdateContactCustomFieldsRequest struct {
@@ -40,10 +65,389 @@ type (
 	}
 	UpdateContactCustomFieldsResponse struct {
 		Contact Contact
+		Raw     json.RawMessage // response body, set with WithRawResponses
 	}
 	DeleteContactRequest struct {
 		ID        string
 		MessageID string
 		IpAddress string
 	}
+	UnsubscribeContactRequest struct {
+		ID        string
+		MessageID string // the message the contact unsubscribed from, required
+		IPAddress string
+	}
+	RemoveContactRequest struct {
+		ID        string
+		IPAddress string
+	}
+	GetCampaignsRequest struct {
+		QueryHash map[string]string
+		Fields    []string
+		SortHash  map[string]string
+		Sort      []SortTerm // sent before the SortHash entries, in priority order
+		Page      int32
+		PerPage   int32
+	}
+	GetCampaignsResponse struct {
+		Campaigns []Campaign
+		Raw       json.RawMessage // response body, set with WithRawResponses
+	}
+	GetCustomFieldsRequest struct {
+		QueryHash map[string]string
+		Fields    []string
+		SortHash  map[string]string
+		Sort      []SortTerm // sent before the SortHash entries, in priority order
+		Page      int32
+		PerPage   int32
+	}
+	GetCustomFieldsResponse struct {
+		CustomFields []CustomFieldDefinition
+		Raw          json.RawMessage // response body, set with WithRawResponses
+	}
+	GetTagsRequest struct {
+		QueryHash map[string]string
+		Fields    []string
+		SortHash  map[string]string
+		Sort      []SortTerm // sent before the SortHash entries, in priority order
+		Page      int32
+		PerPage   int32
+	}
+	GetTagsResponse struct {
+		Tags []Tag
+		Raw  json.RawMessage // response body, set with WithRawResponses
+	}
+	GetNewslettersRequest struct {
+		QueryHash map[string]string
+		Fields    []string
+		SortHash  map[string]string
+		Sort      []SortTerm // sent before the SortHash entries, in priority order
+		Page      int32
+		PerPage   int32
+	}
+	GetNewslettersResponse struct {
+		Newsletters []Newsletter
+		Raw         json.RawMessage // response body, set with WithRawResponses
+	}
+	CreateCampaignRequest struct {
+		Name         string  `json:"name"`
+		LanguageCode *string `json:"languageCode,omitempty"`
+	}
+	CreateCustomFieldRequest struct {
+		Name   string   `json:"name"`
+		Type   string   `json:"type"`
+		Hidden bool     `json:"hidden"`
+		Values []string `json:"values"`
+	}
+	CreateTagRequest struct {
+		Name  string `json:"name"`
+		Color string `json:"color,omitempty"`
+	}
+	GetFromFieldsRequest struct {
+		QueryHash map[string]string
+		Fields    []string
+		SortHash  map[string]string
+		Sort      []SortTerm // sent before the SortHash entries, in priority order
+		Page      int32
+		PerPage   int32
+	}
+	GetFromFieldsResponse struct {
+		FromFields []FromField
+		Raw        json.RawMessage // response body, set with WithRawResponses
+	}
+	CreateFromFieldRequest struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	GetCampaignRequest struct {
+		ID string
+	}
+	UpdateCampaignSettingsRequest struct {
+		ID       string
+		Settings CampaignSettings
+	}
+	GetSearchContactRequest struct {
+		ID string
+	}
+	CreateNewsletterRequest struct {
+		Name         string            `json:"name,omitempty"`
+		Subject      string            `json:"subject"`
+		Campaign     Campaign          `json:"campaign"`
+		FromField    FromFieldRef      `json:"fromField"`
+		ReplyTo      *FromFieldRef     `json:"replyTo,omitempty"`
+		Content      NewsletterContent `json:"content"`
+		SendOn       *time.Time        `json:"-"` // sent straight away when nil, the time's zone is kept
+		SendSettings SendSettings      `json:"sendSettings"`
+		Attachments  []Attachment      `json:"attachments,omitempty"`
+	}
+	SendTransactionalEmailRequest struct {
+		FromField   FromFieldRef            `json:"fromField"`
+		ReplyTo     *FromFieldRef           `json:"replyTo,omitempty"`
+		Tag         *TransactionalEmailTag  `json:"tag,omitempty"`
+		Recipients  TransactionalRecipients `json:"recipients"`
+		Subject     string                  `json:"subject"`
+		Content     NewsletterContent       `json:"content"`
+		Attachments []Attachment            `json:"attachments,omitempty"`
+	}
 )
+
+// Validate checks the fields the API requires
+func (r *CreateContactRequest) Validate() error {
+	if r.Email == "" {
+		return &ValidationError{Field: "email", Message: "is required"}
+	}
+	if r.Campaign.CampaignID == "" {
+		return &ValidationError{Field: "campaign.campaignId", Message: "is required"}
+	}
+	if r.IPAddress != nil {
+		return validateIP(*r.IPAddress)
+	}
+	return nil
+}
+
+// updatableContactFields are the contact fields the update endpoint accepts, and whether they can be cleared
+var updatableContactFields = map[string]bool{
+	"name":              true,
+	"note":              true,
+	"dayOfCycle":        true,
+	"ipAddress":         true,
+	"scoring":           true,
+	"campaign":          false,
+	"tags":              false,
+	"customFieldValues": false,
+}
+
+// Validate checks the fields the API requires
+func (r *UpdateContactRequest) Validate() error {
+	if r.ID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	for _, field := range r.Fields {
+		if _, ok := updatableContactFields[field]; !ok {
+			return &ValidationError{Field: field, Message: "cannot be updated"}
+		}
+	}
+	for _, field := range r.Clear {
+		if !updatableContactFields[field] {
+			return &ValidationError{Field: field, Message: "cannot be cleared"}
+		}
+	}
+	if r.NewData.IPAddress != nil {
+		return validateIP(*r.NewData.IPAddress)
+	}
+	return nil
+}
+
+// body encodes the masked fields of NewData with the cleared fields set to null. A masked "tags" field is sent even
+// when NewData.Tags is empty but not nil.
+func (r *UpdateContactRequest) body() ([]byte, error) {
+	body, err := json.Marshal(r.NewData)
+	if err != nil || (r.Fields == nil && len(r.Clear) == 0) {
+		return body, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return nil, err
+	}
+	if r.Fields != nil {
+		masked := map[string]json.RawMessage{}
+		for _, field := range r.Fields {
+			if v, ok := fields[field]; ok {
+				masked[field] = v
+			}
+		}
+		// omitempty leaves out an empty tag list, which is how every tag of the contact is removed
+		if r.NewData.Tags != nil && len(r.NewData.Tags) == 0 && containsString(r.Fields, "tags") {
+			masked["tags"] = json.RawMessage("[]")
+		}
+		fields = masked
+	}
+	for _, field := range r.Clear {
+		if _, ok := fields[field]; ok {
+			return nil, &ValidationError{Field: field, Message: "is both set and cleared"}
+		}
+		fields[field] = json.RawMessage("null")
+	}
+	return json.Marshal(fields)
+}
+
+// ChangedContactFields returns the JSON names of the updatable fields that differ between two versions of a contact,
+// to be used as UpdateContactRequest.Fields. Fields set in before but nil in after are not reported, see Clear.
+func ChangedContactFields(before, after Contact) []string {
+	b, errB := json.Marshal(before)
+	a, errA := json.Marshal(after)
+	if errB != nil || errA != nil {
+		return nil
+	}
+	bFields := map[string]json.RawMessage{}
+	aFields := map[string]json.RawMessage{}
+	if json.Unmarshal(b, &bFields) != nil || json.Unmarshal(a, &aFields) != nil {
+		return nil
+	}
+
+	changed := []string{}
+	for field, v := range aFields {
+		if _, ok := updatableContactFields[field]; ok && string(bFields[field]) != string(v) {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Validate checks the fields the API requires
+func (r *UpdateContactCustomFieldsRequest) Validate() error {
+	if r.ID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	if len(r.CustomFields) == 0 {
+		return &ValidationError{Field: "customFieldValues", Message: "must not be empty"}
+	}
+	return nil
+}
+
+// Validate checks the fields the API requires
+func (r *DeleteContactRequest) Validate() error {
+	if r.ID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	return validateIP(r.IpAddress)
+}
+
+// Validate checks the fields the API requires
+func (r *UnsubscribeContactRequest) Validate() error {
+	if r.ID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	if r.MessageID == "" {
+		return &ValidationError{Field: "messageId", Message: "is required to unsubscribe, use RemoveContact to remove the contact"}
+	}
+	return validateIP(r.IPAddress)
+}
+
+// Validate checks the fields the API requires
+func (r *RemoveContactRequest) Validate() error {
+	if r.ID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	return validateIP(r.IPAddress)
+}
+
+// Validate checks the fields the API requires
+func (r *CreateCampaignRequest) Validate() error {
+	if r.Name == "" {
+		return &ValidationError{Field: "name", Message: "is required"}
+	}
+	return nil
+}
+
+// Validate checks the fields the API requires
+func (r *UpdateCampaignSettingsRequest) Validate() error {
+	if r.ID == "" {
+		return &ValidationError{Field: "id", Message: "is required"}
+	}
+	if o := r.Settings.OptinTypes; o != nil {
+		for field, v := range map[string]string{"email": o.Email, "api": o.API, "import": o.Import, "webform": o.Webform} {
+			if v != "" && v != OptinSingle && v != OptinDouble {
+				return &ValidationError{Field: "optinTypes." + field, Message: "must be single or double"}
+			}
+		}
+	}
+	if n := r.Settings.SubscriptionNotifications; n != nil && n.Status != "" && n.Status != NotificationsEnabled && n.Status != NotificationsDisabled {
+		return &ValidationError{Field: "subscriptionNotifications.status", Message: "must be enabled or disabled"}
+	}
+	return nil
+}
+
+// Validate checks the fields the API requires
+func (r *CreateCustomFieldRequest) Validate() error {
+	if r.Name == "" {
+		return &ValidationError{Field: "name", Message: "is required"}
+	}
+	if r.Type == "" {
+		return &ValidationError{Field: "type", Message: "is required"}
+	}
+	return nil
+}
+
+// Validate checks the fields the API requires
+func (r *CreateTagRequest) Validate() error {
+	if r.Name == "" {
+		return &ValidationError{Field: "name", Message: "is required"}
+	}
+	return nil
+}
+
+// Validate checks the fields the API requires
+func (r *CreateFromFieldRequest) Validate() error {
+	if r.Name == "" {
+		return &ValidationError{Field: "name", Message: "is required"}
+	}
+	if r.Email == "" {
+		return &ValidationError{Field: "email", Message: "is required"}
+	}
+	return nil
+}
+
+// Validate checks the fields the API requires
+func (r *CreateNewsletterRequest) Validate() error {
+	if r.Subject == "" {
+		return &ValidationError{Field: "subject", Message: "is required"}
+	}
+	if r.Campaign.CampaignID == "" {
+		return &ValidationError{Field: "campaign.campaignId", Message: "is required"}
+	}
+	if r.FromField.FromFieldID == "" {
+		return &ValidationError{Field: "fromField.fromFieldId", Message: "is required"}
+	}
+	if r.Content.HTML == "" && r.Content.Plain == "" {
+		return &ValidationError{Field: "content", Message: "needs html or plain text"}
+	}
+	if len(r.SendSettings.SelectedCampaigns) == 0 && len(r.SendSettings.SelectedSegments) == 0 && len(r.SendSettings.SelectedContacts) == 0 {
+		return &ValidationError{Field: "sendSettings", Message: "must select campaigns, segments or contacts"}
+	}
+	if r.SendSettings.TimeTravel && r.SendSettings.PerfectTiming {
+		return &ValidationError{Field: "sendSettings", Message: "timeTravel and perfectTiming are exclusive"}
+	}
+	if r.SendOn == nil && (r.SendSettings.TimeTravel || r.SendSettings.PerfectTiming) {
+		return &ValidationError{Field: "sendOn", Message: "is required with timeTravel or perfectTiming"}
+	}
+	if r.SendOn != nil && r.SendOn.Before(time.Now()) {
+		return &ValidationError{Field: "sendOn", Message: "is in the past"}
+	}
+	return validateAttachments(r.Attachments)
+}
+
+// Validate checks the fields the API requires
+func (r *SendTransactionalEmailRequest) Validate() error {
+	if r.FromField.FromFieldID == "" {
+		return &ValidationError{Field: "fromField.fromFieldId", Message: "is required"}
+	}
+	if r.Recipients.To.Email == "" {
+		return &ValidationError{Field: "recipients.to.email", Message: "is required"}
+	}
+	if r.Subject == "" {
+		return &ValidationError{Field: "subject", Message: "is required"}
+	}
+	if r.Content.HTML == "" && r.Content.Plain == "" {
+		return &ValidationError{Field: "content", Message: "needs html or plain text"}
+	}
+	return validateAttachments(r.Attachments)
+}
+
+// sendOnLayout is the ISO 8601 form the newsletters endpoint expects
+const sendOnLayout = "2006-01-02T15:04:05-0700"
+
+func (r CreateNewsletterRequest) MarshalJSON() ([]byte, error) {
+	type request CreateNewsletterRequest
+	v := struct {
+		request
+		SendOn string `json:"sendOn,omitempty"`
+	}{request: request(r)}
+	if r.SendOn != nil {
+		v.SendOn = r.SendOn.Format(sendOnLayout)
+	}
+	return json.Marshal(v)
+}
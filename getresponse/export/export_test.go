@@ -0,0 +1,81 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/watch"
+)
+
+func TestUnit_Export(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/accounts":
+			fmt.Fprint(w, `{"accountId":"x","timeZone":{"name":"Pacific/Kiritimati","offset":"+14:00"}}`)
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"f1","name":"color"}]`)
+		case "/v3/contacts":
+			if r.URL.Query().Get("query[changedOn][from]") != "2020-01-03" {
+				t.Errorf("Unexpected query (%v)", r.URL.Query())
+			}
+			fmt.Fprint(w, `[{"contactId":"a","email":"a@example.com","createdOn":"2020-01-01T08:00:00+0200","changedOn":"2020-01-02T11:00:00+0000"}]`)
+		case "/v3/contacts/a":
+			fmt.Fprint(w, `{"contactId":"a","email":"a@example.com","campaign":{"campaignId":"c","name":"list"},
+				"createdOn":"2020-01-01T08:00:00+0200","changedOn":"2020-01-02T11:00:00+0000",
+				"tags":[{"tagId":"t1","name":"vip"}],"customFieldValues":[{"customFieldId":"f1","value":["red","blue"]}]}`)
+		case "/v3/contacts/a/activities":
+			// the account's day has already changed at 10:00 UTC
+			if r.URL.Query().Get("query[createdOn][from]") != "2020-01-03" {
+				t.Errorf("Unexpected query (%v)", r.URL.Query())
+			}
+			fmt.Fprint(w, `[{"activity":"open","subject":"Hi","createdOn":"2020-01-02T12:00:00+0000","resource":{"resourceId":"n1","resourceType":"newsletters"}},
+				{"activity":"send","subject":"Hi","createdOn":"2020-01-02T09:00:00+0000","resource":{"resourceId":"n1","resourceType":"newsletters"}}]`)
+		default:
+			t.Errorf("Unexpected request (%s)", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	client := getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling())
+
+	cp := &watch.MemoryCheckpoints{}
+	e := &Exporter{Client: client, Checkpoints: cp, Activities: true, Since: time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)}
+	contacts, activities := &bytes.Buffer{}, &bytes.Buffer{}
+	n, err := e.Export(context.Background(), NewNDJSONWriter(contacts, activities))
+	if err != nil || n != 1 {
+		t.Fatalf("Unexpected result (%d, %#v)", n, err)
+	}
+
+	row := ContactRow{}
+	err = json.Unmarshal(contacts.Bytes(), &row)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if row.CampaignName != "list" || row.CreatedOn != "2020-01-01T06:00:00Z" || len(row.Tags) != 1 || row.Tags[0] != "vip" {
+		t.Fatalf("Unexpected contact row (%#v)", row)
+	}
+	expected := []CustomFieldValue{{"f1", "color", "red"}, {"f1", "color", "blue"}}
+	if fmt.Sprint(row.CustomFields) != fmt.Sprint(expected) {
+		t.Fatalf("Actual custom fields (%v) did not match expected (%v)", row.CustomFields, expected)
+	}
+
+	activity := ActivityRow{}
+	dec := json.NewDecoder(activities)
+	err = dec.Decode(&activity)
+	if err != nil || activity.Activity != "open" || activity.ResourceID != "n1" || dec.More() {
+		t.Fatalf("Unexpected activities (%#v, %#v, %s)", activity, err, activities)
+	}
+
+	// nothing changed since
+	contacts.Reset()
+	n, err = e.Export(context.Background(), NewNDJSONWriter(contacts, activities))
+	if err != nil || n != 0 || contacts.Len() != 0 {
+		t.Fatalf("Unexpected second export (%d, %#v, %s)", n, err, contacts)
+	}
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+// timeLayout is how GetResponse formats times
+const timeLayout = "2006-01-02T15:04:05-0700"
+
+// ContactRow is a contact flattened for warehouse loaders. The schema is stable: columns are only ever added, times
+// are RFC 3339 in UTC and missing values are empty rather than absent.
+type ContactRow struct {
+	ContactID    string             `json:"contact_id" parquet:"contact_id"`
+	Email        string             `json:"email" parquet:"email"`
+	Name         string             `json:"name" parquet:"name"`
+	CampaignID   string             `json:"campaign_id" parquet:"campaign_id"`
+	CampaignName string             `json:"campaign_name" parquet:"campaign_name"`
+	DayOfCycle   *int32             `json:"day_of_cycle" parquet:"day_of_cycle,optional"`
+	Origin       string             `json:"origin" parquet:"origin"`
+	TimeZone     string             `json:"time_zone" parquet:"time_zone"`
+	IPAddress    string             `json:"ip_address" parquet:"ip_address"`
+	Scoring      *int64             `json:"scoring" parquet:"scoring,optional"`
+	CreatedOn    string             `json:"created_on" parquet:"created_on"`
+	ChangedOn    string             `json:"changed_on" parquet:"changed_on"`
+	Tags         []string           `json:"tags" parquet:"tags,list"`
+	CustomFields []CustomFieldValue `json:"custom_fields" parquet:"custom_fields,list"`
+	ExportedAt   string             `json:"exported_at" parquet:"exported_at"`
+}
+
+// CustomFieldValue is one value of a custom field. Fields with several values give several entries.
+type CustomFieldValue struct {
+	ID    string `json:"id" parquet:"id"`
+	Name  string `json:"name" parquet:"name"`
+	Value string `json:"value" parquet:"value"`
+}
+
+// ActivityRow is an activity of a contact, such as a send, open or click
+type ActivityRow struct {
+	ContactID    string `json:"contact_id" parquet:"contact_id"`
+	Activity     string `json:"activity" parquet:"activity"`
+	Subject      string `json:"subject" parquet:"subject"`
+	ResourceID   string `json:"resource_id" parquet:"resource_id"`
+	ResourceType string `json:"resource_type" parquet:"resource_type"`
+	CreatedOn    string `json:"created_on" parquet:"created_on"`
+	ExportedAt   string `json:"exported_at" parquet:"exported_at"`
+}
+
+// activity is an entry of /v3/contacts/{id}/activities
+type activity struct {
+	Activity  string  `json:"activity"`
+	Subject   string  `json:"subject"`
+	CreatedOn *string `json:"createdOn"`
+	Resource  struct {
+		ResourceID   string `json:"resourceId"`
+		ResourceType string `json:"resourceType"`
+	} `json:"resource"`
+}
+
+// NewContactRow flattens c, naming custom fields with fieldNames (custom field id -> name)
+func NewContactRow(c getresponse.Contact, fieldNames map[string]string, exportedAt time.Time) ContactRow {
+	row := ContactRow{
+		ContactID:    stringValue(c.ContactID),
+		Email:        stringValue(c.Email),
+		Name:         stringValue(c.Name),
+		DayOfCycle:   c.DayOfCycle,
+		Origin:       stringValue(c.Origin),
+		TimeZone:     stringValue(c.TimeZone),
+		IPAddress:    stringValue(c.IPAddress),
+		Scoring:      c.Scoring,
+		CreatedOn:    normalizeTime(c.CreatedOn),
+		ChangedOn:    normalizeTime(c.ChangedOn),
+		Tags:         []string{},
+		CustomFields: []CustomFieldValue{},
+		ExportedAt:   exportedAt.UTC().Format(time.RFC3339),
+	}
+	if c.Campaign != nil {
+		row.CampaignID = c.Campaign.CampaignID
+		row.CampaignName = c.Campaign.Name
+	}
+	for _, t := range c.Tags {
+		name := t.Name
+		if name == "" {
+			name = t.TagID
+		}
+		row.Tags = append(row.Tags, name)
+	}
+	for _, f := range c.CustomFieldValues {
+		for _, v := range f.Value {
+			row.CustomFields = append(row.CustomFields, CustomFieldValue{ID: f.CustomFieldID, Name: fieldNames[f.CustomFieldID], Value: v})
+		}
+	}
+	return row
+}
+
+func newActivityRow(contactID string, a activity, exportedAt time.Time) ActivityRow {
+	return ActivityRow{
+		ContactID:    contactID,
+		Activity:     a.Activity,
+		Subject:      a.Subject,
+		ResourceID:   a.Resource.ResourceID,
+		ResourceType: a.Resource.ResourceType,
+		CreatedOn:    normalizeTime(a.CreatedOn),
+		ExportedAt:   exportedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// normalizeTime converts a GetResponse time to RFC 3339 in UTC, leaving values it cannot parse as they are
+func normalizeTime(s *string) string {
+	if s == nil {
+		return ""
+	}
+	t, err := time.Parse(timeLayout, *s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, *s)
+	}
+	if err != nil {
+		return *s
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
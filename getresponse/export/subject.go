@@ -0,0 +1,111 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+// consentFields are the contact fields holding consents, kept apart in SubjectContact.Consents
+var consentFields = []string{"consents", "gdprFields"}
+
+// SubjectData is everything the account holds about an email address, for data subject access requests
+type SubjectData struct {
+	Email      string           `json:"email"`
+	ExportedAt string           `json:"exported_at"`
+	Contacts   []SubjectContact `json:"contacts"`
+}
+
+// SubjectContact is one contact of the address; there is one per campaign it is on
+type SubjectContact struct {
+	Contact ContactRow `json:"contact"`
+	// Consents are the consent fields of the contact as the API returns them, when it has any
+	Consents   map[string]json.RawMessage `json:"consents,omitempty"`
+	Activities []ActivityRow              `json:"activities"`
+	// Document is the contact as the API returns it, so fields the rows do not model are disclosed as well
+	Document json.RawMessage `json:"document"`
+}
+
+// ExportContactData gathers the contacts of the email address in every campaign with their custom fields, tags,
+// consents and whole activity history. An address without contacts gives no contacts rather than an error.
+func ExportContactData(ctx context.Context, c getresponse.Client, email string) (*SubjectData, error) {
+	fieldNames, err := customFieldNames(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := contactIDsByEmail(ctx, c, email)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedAt := time.Now()
+	data := &SubjectData{Email: email, ExportedAt: exportedAt.UTC().Format(time.RFC3339), Contacts: []SubjectContact{}}
+	for _, id := range ids {
+		doc, err := getresponse.Get[json.RawMessage](ctx, c, "/v3/contacts/"+url.PathEscape(id), nil)
+		if err != nil {
+			return nil, err
+		}
+		var contact getresponse.Contact
+		err = json.Unmarshal(doc, &contact)
+		if err != nil {
+			return nil, err
+		}
+
+		sc := SubjectContact{
+			Contact:    NewContactRow(contact, fieldNames, exportedAt),
+			Activities: []ActivityRow{},
+			Document:   doc,
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(doc, &fields) == nil {
+			for _, name := range consentFields {
+				if v, ok := fields[name]; ok {
+					if sc.Consents == nil {
+						sc.Consents = map[string]json.RawMessage{}
+					}
+					sc.Consents[name] = v
+				}
+			}
+		}
+		err = forEachActivity(ctx, c, id, time.Time{}, nil, exportedAt, func(row ActivityRow) error {
+			sc.Activities = append(sc.Activities, row)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		data.Contacts = append(data.Contacts, sc)
+	}
+	return data, nil
+}
+
+// contactIDsByEmail returns the ids of the contacts with the address, in any campaign
+func contactIDsByEmail(ctx context.Context, c getresponse.Client, email string) ([]string, error) {
+	req := &getresponse.GetContactsRequest{
+		QueryHash:       map[string]string{"email": email},
+		Page:            1,
+		PerPage:         listPerPage,
+		AdditionalFlags: getresponse.ContactFlags(getresponse.FlagExactMatch),
+	}
+	var ids []string
+	for {
+		res, err := c.Contacts().List(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, contact := range res.Contacts {
+			// the search matches substrings without exactMatch support, keep only the address itself
+			if contact.ContactID != nil && contact.Email != nil && strings.EqualFold(*contact.Email, email) {
+				ids = append(ids, *contact.ContactID)
+			}
+		}
+		if len(res.Contacts) < listPerPage {
+			return ids, nil
+		}
+		req.Page++
+	}
+}
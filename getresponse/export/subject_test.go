@@ -0,0 +1,75 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_ExportContactData(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"f1","name":"color"}]`)
+		case "/v3/contacts":
+			q := r.URL.Query()
+			if q.Get("query[email]") != "ann@example.com" || q.Get("additionalFlags") != "exactMatch" {
+				t.Errorf("Unexpected query (%v)", q)
+			}
+			fmt.Fprint(w, `[{"contactId":"a","email":"Ann@example.com"},{"contactId":"b","email":"ann@example.com"},
+				{"contactId":"x","email":"joann@example.com"}]`)
+		case "/v3/contacts/a":
+			fmt.Fprint(w, `{"contactId":"a","email":"Ann@example.com","campaign":{"campaignId":"c1","name":"news"},
+				"tags":[{"tagId":"t1","name":"vip"}],"customFieldValues":[{"customFieldId":"f1","value":["red"]}],
+				"consents":[{"name":"marketing","given":true}],"engagementScore":3}`)
+		case "/v3/contacts/b":
+			fmt.Fprint(w, `{"contactId":"b","email":"ann@example.com","campaign":{"campaignId":"c2","name":"offers"}}`)
+		case "/v3/contacts/a/activities":
+			if r.URL.Query().Get("query[createdOn][from]") != "" {
+				t.Errorf("Expected the whole history, got (%v)", r.URL.Query())
+			}
+			fmt.Fprint(w, `[{"activity":"open","subject":"Hi","createdOn":"2020-01-02T12:00:00+0000","resource":{"resourceId":"n1","resourceType":"newsletters"}}]`)
+		case "/v3/contacts/b/activities":
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("Unexpected request (%s)", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	client := getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling())
+
+	data, err := ExportContactData(context.Background(), client, "ann@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(data.Contacts) != 2 {
+		t.Fatalf("Actual contacts (%d) did not match expected (%d)", len(data.Contacts), 2)
+	}
+	a, b := data.Contacts[0], data.Contacts[1]
+	if a.Contact.CampaignName != "news" || fmt.Sprint(a.Contact.Tags) != "[vip]" || len(a.Contact.CustomFields) != 1 || a.Contact.CustomFields[0].Name != "color" {
+		t.Fatalf("Unexpected contact (%#v)", a.Contact)
+	}
+	if string(a.Consents["consents"]) != `[{"name":"marketing","given":true}]` || b.Consents != nil {
+		t.Fatalf("Unexpected consents (%s, %s)", a.Consents, b.Consents)
+	}
+	if len(a.Activities) != 1 || a.Activities[0].Activity != "open" || len(b.Activities) != 0 {
+		t.Fatalf("Unexpected activities (%#v, %#v)", a.Activities, b.Activities)
+	}
+	doc := map[string]interface{}{}
+	if json.Unmarshal(a.Document, &doc) != nil || doc["engagementScore"] != 3.0 {
+		t.Fatalf("Expected the whole contact document, got (%s)", a.Document)
+	}
+
+	out, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if !json.Valid(out) {
+		t.Fatalf("Invalid document (%s)", out)
+	}
+}
@@ -0,0 +1,164 @@
+package getresponse
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// DefaultCustomFieldValueLength is the longest custom field value the API accepts, in characters
+const DefaultCustomFieldValueLength = 255
+
+// OversizeStrategy tells what is done with custom field values over their limits
+type OversizeStrategy int
+
+const (
+	// OversizeReject fails the request with a *CustomFieldLimitError
+	OversizeReject OversizeStrategy = iota
+	// OversizeTruncate cuts long values to the maximum length and drops the values over the maximum count
+	OversizeTruncate
+	// OversizeSplit cuts long values into several values of the maximum length, for fields taking more than one
+	// value. The request still fails when the pieces are more values than the field takes.
+	OversizeSplit
+)
+
+// CustomFieldLimit bounds the values sent for a custom field
+type CustomFieldLimit struct {
+	MaxLength int // characters per value, DefaultCustomFieldValueLength when 0
+	MaxValues int // values per field, 0 for no limit
+	Strategy  OversizeStrategy
+}
+
+// CustomFieldLimitError tells which custom field value exceeded its limit. It is returned before the request is sent,
+// instead of the API's 1007 error.
+type CustomFieldLimitError struct {
+	CustomFieldID string
+	// Index is the position of the value that is too long, -1 when the field has too many values
+	Index     int
+	Length    int
+	MaxLength int
+	Values    int
+	MaxValues int
+}
+
+func (e *CustomFieldLimitError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("custom field %s has %d values, at most %d are accepted", e.CustomFieldID, e.Values, e.MaxValues)
+	}
+	return fmt.Sprintf("custom field %s value %d is %d characters long, at most %d are accepted", e.CustomFieldID, e.Index, e.Length, e.MaxLength)
+}
+
+// WithCustomFieldLimit sets the limit of a single custom field, e.g. splitting long notes over a multi value field
+func WithCustomFieldLimit(customFieldID string, l CustomFieldLimit) Option {
+	return func(g *getResponseClient) {
+		if g.customFieldLimits == nil {
+			g.customFieldLimits = map[string]CustomFieldLimit{}
+		}
+		g.customFieldLimits[customFieldID] = l
+	}
+}
+
+// WithDefaultCustomFieldLimit sets the limit of every custom field without one of its own. Without it values longer
+// than DefaultCustomFieldValueLength are rejected.
+func WithDefaultCustomFieldLimit(l CustomFieldLimit) Option {
+	return func(g *getResponseClient) {
+		g.defaultCustomFieldLimit = l
+	}
+}
+
+func (g *getResponseClient) customFieldLimitFor(id string) CustomFieldLimit {
+	l, ok := g.customFieldLimits[id]
+	if !ok {
+		l = g.defaultCustomFieldLimit
+	}
+	if l.MaxLength <= 0 {
+		l.MaxLength = DefaultCustomFieldValueLength
+	}
+	return l
+}
+
+// limitCustomFields checks the values against their limits, changed tells whether any had to be cut
+func (g *getResponseClient) limitCustomFields(fields []CustomField) (limited []CustomField, changed bool, err error) {
+	for i, f := range fields {
+		values, err := limitValues(f, g.customFieldLimitFor(f.CustomFieldID))
+		if err != nil {
+			return nil, false, err
+		}
+		if limited == nil && !sameValues(values, f.Value) {
+			limited = append([]CustomField{}, fields...)
+		}
+		if limited != nil {
+			limited[i].Value = values
+		}
+	}
+	if limited == nil {
+		return fields, false, nil
+	}
+	return limited, true, nil
+}
+
+func limitValues(f CustomField, l CustomFieldLimit) ([]string, error) {
+	values := make([]string, 0, len(f.Value))
+	for i, v := range f.Value {
+		n := utf8.RuneCountInString(v)
+		switch {
+		case n <= l.MaxLength:
+			values = append(values, v)
+		case l.Strategy == OversizeTruncate:
+			values = append(values, truncateRunes(v, l.MaxLength))
+		case l.Strategy == OversizeSplit:
+			for v != "" {
+				piece := truncateRunes(v, l.MaxLength)
+				values = append(values, piece)
+				v = v[len(piece):]
+			}
+		default:
+			return nil, &CustomFieldLimitError{CustomFieldID: f.CustomFieldID, Index: i, Length: n, MaxLength: l.MaxLength}
+		}
+	}
+	if l.MaxValues > 0 && len(values) > l.MaxValues {
+		if l.Strategy != OversizeTruncate {
+			return nil, &CustomFieldLimitError{CustomFieldID: f.CustomFieldID, Index: -1, Values: len(values), MaxValues: l.MaxValues}
+		}
+		values = values[:l.MaxValues]
+	}
+	return values, nil
+}
+
+// truncateRunes returns the first n characters of s
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// limitCreateCustomFields applies limitCustomFields to the request, copying it when values change
+func (g *getResponseClient) limitCreateCustomFields(request *CreateContactRequest) (*CreateContactRequest, error) {
+	if request == nil {
+		return request, nil
+	}
+	fields, changed, err := g.limitCustomFields(request.CustomFields)
+	if err != nil || !changed {
+		return request, err
+	}
+	limited := *request
+	limited.CustomFields = fields
+	return &limited, nil
+}
+
+// limitUpdateCustomFields applies limitCustomFields to the request, copying it when values change
+func (g *getResponseClient) limitUpdateCustomFields(request *UpdateContactRequest) (*UpdateContactRequest, error) {
+	if request == nil {
+		return request, nil
+	}
+	fields, changed, err := g.limitCustomFields(request.NewData.CustomFieldValues)
+	if err != nil || !changed {
+		return request, err
+	}
+	limited := *request
+	limited.NewData.CustomFieldValues = fields
+	return &limited, nil
+}
@@ -0,0 +1,68 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnit_CustomFieldLimits(t *testing.T) {
+	type testcase struct {
+		name     string
+		limit    CustomFieldLimit
+		values   []string
+		expected []string
+		err      *CustomFieldLimitError
+	}
+	long := strings.Repeat("é", 5)
+	tests := []testcase{
+		{name: "within", limit: CustomFieldLimit{MaxLength: 5}, values: []string{long}, expected: []string{long}},
+		{name: "reject", limit: CustomFieldLimit{MaxLength: 4}, values: []string{"a", long},
+			err: &CustomFieldLimitError{CustomFieldID: "f", Index: 1, Length: 5, MaxLength: 4}},
+		{name: "truncate", limit: CustomFieldLimit{MaxLength: 2, MaxValues: 1, Strategy: OversizeTruncate},
+			values: []string{long, "b"}, expected: []string{"éé"}},
+		{name: "split", limit: CustomFieldLimit{MaxLength: 2, Strategy: OversizeSplit},
+			values: []string{"a", long}, expected: []string{"a", "éé", "éé", "é"}},
+		{name: "split over count", limit: CustomFieldLimit{MaxLength: 2, MaxValues: 2, Strategy: OversizeSplit},
+			values: []string{long}, err: &CustomFieldLimitError{CustomFieldID: "f", Index: -1, Values: 3, MaxValues: 2}},
+	}
+
+	for _, test := range tests {
+		var sent []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body UpdateContactCustomFieldsRequest
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				t.Errorf("Unexpected error occurred (%#v)", err)
+			}
+			sent = body.CustomFields[0].Value
+			w.Write([]byte(`{"contactId":"c"}`))
+		}))
+		c := NewClient(ts.URL, "", "", nil, WithCustomFieldLimit("f", test.limit))
+		request := &UpdateContactCustomFieldsRequest{ID: "c", CustomFields: []CustomField{{CustomFieldID: "f", Value: test.values}}}
+		_, err := c.Contacts().UpdateCustomFields(context.Background(), request)
+		ts.Close()
+
+		var limitErr *CustomFieldLimitError
+		if test.err != nil {
+			if !errors.As(err, &limitErr) || *limitErr != *test.err {
+				t.Errorf("%s: Actual error (%v) did not match expected (%v)", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Unexpected error occurred (%#v)", test.name, err)
+		}
+		if !reflect.DeepEqual(sent, test.expected) {
+			t.Errorf("%s: Actual values (%q) did not match expected (%q)", test.name, sent, test.expected)
+		}
+		if !reflect.DeepEqual(request.CustomFields[0].Value, test.values) {
+			t.Errorf("%s: Expected the request not to be modified (%q)", test.name, request.CustomFields[0].Value)
+		}
+	}
+}
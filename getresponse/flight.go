@@ -0,0 +1,48 @@
+package getresponse
+
+import (
+	"context"
+	"sync"
+)
+
+// flight deduplicates concurrent calls: calls made while one is running wait for it and share its error instead of
+// running again, so a cold catalog read by many goroutines is listed once
+type flight struct {
+	mu   sync.Mutex
+	call *flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	err  error
+}
+
+// do runs fn unless a call is already running, and returns its error. A waiting caller whose context is done
+// returns the context's error without stopping the running call.
+func (f *flight) do(ctx context.Context, fn func() error) error {
+	f.mu.Lock()
+	c := f.call
+	if c == nil {
+		c = &flightCall{done: make(chan struct{})}
+		f.call = c
+		f.mu.Unlock()
+
+		c.err = fn()
+		f.mu.Lock()
+		f.call = nil
+		f.mu.Unlock()
+		close(c.done)
+		return c.err
+	}
+	f.mu.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	select {
+	case <-c.done:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_CatalogSingleFlight(t *testing.T) {
+	var lists int32
+	entered := make(chan struct{}, 10)
+	release := make(chan struct{})
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&lists, 1)
+		entered <- struct{}{}
+		<-release
+		switch r.URL.Path {
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"f1","name":"age"}]`)
+		case "/v3/tags":
+			fmt.Fprint(w, `[{"tagId":"t1","name":"vip"}]`)
+		case "/v3/campaigns":
+			fmt.Fprint(w, `[{"campaignId":"c1","name":"newsletter"}]`)
+		}
+	}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	lookups := []func() error{
+		func() error { _, err := c.CustomFields().Catalog().Lookup(ctx, "age"); return err },
+		func() error { _, err := c.Tags().Catalog().Lookup(ctx, "vip"); return err },
+		func() error { _, err := c.Campaigns().ResolveByName(ctx, "newsletter"); return err },
+	}
+	for _, lookup := range lookups {
+		atomic.StoreInt32(&lists, 0)
+		wg := sync.WaitGroup{}
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- lookup()
+			}()
+		}
+		<-entered
+		// let the other goroutines reach the running listing
+		time.Sleep(20 * time.Millisecond)
+		release <- struct{}{}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+		}
+		if n := atomic.LoadInt32(&lists); n != 1 {
+			t.Fatalf("Actual list calls (%v) did not match expected (%v)", n, 1)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// fuzzClient answers every request with status and body
+func fuzzClient(status int, body []byte, opts ...Option) Client {
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{XRateLimitRemainingHeader: {string(body)}, XRateLimitResetHeader: {string(body)}},
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	return NewClient("http://example.com", "", "", &http.Client{Transport: transport}, append([]Option{WithoutThrottling()}, opts...)...)
+}
+
+var fuzzSeeds = []string{
+	``,
+	`null`,
+	`[]`,
+	`{}`,
+	`[{"contactId":"a","customFieldValues":[{"customFieldId":"f","value":["1"]}]}]`,
+	`{"contactId":"a","campaign":{"campaignId":"c"},"tags":[{"tagId":"t"}]}`,
+	`{"httpStatus":404,"code":1013,"message":"not found","context":["id"],"uuid":"u"}`,
+	`{"httpStatus":"400","code":"1000","message":1234,"context":{"field":"email"}}`,
+	`{"code":1e400,"message":null,"context":[1,{"a":[]},null]}`,
+	`{"contactId":{"nested":true},"name":[1,2]}`,
+	`<html>bad gateway</html>`,
+	`{"unterminated":`,
+}
+
+func FuzzResponseDecoding(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		for _, status := range []int{http.StatusOK, http.StatusNotFound, http.StatusTooManyRequests, http.StatusBadGateway} {
+			f.Add(status, []byte(seed))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		if status < 100 || status > 999 {
+			return
+		}
+		ctx := context.Background()
+		for _, opts := range [][]Option{nil, {WithStrictDecoding(), WithRawResponses()}} {
+			c := fuzzClient(status, body, opts...)
+			calls := map[string]func() (interface{}, error){
+				"GetContacts": func() (interface{}, error) {
+					return c.GetContacts(ctx, &GetContactsRequest{Page: 1, PerPage: 10})
+				},
+				"GetContact": func() (interface{}, error) {
+					return c.GetContact(ctx, &GetContactRequest{ID: "a"})
+				},
+				"GetCampaign": func() (interface{}, error) {
+					return c.GetCampaign(ctx, &GetCampaignRequest{ID: "c"})
+				},
+				"CreateTag": func() (interface{}, error) {
+					return c.Tags().Create(ctx, &CreateTagRequest{Name: "t"})
+				},
+				"Do": func() (interface{}, error) {
+					var out map[string]interface{}
+					return &out, c.Do(ctx, http.MethodGet, "/v3/webforms", nil, nil, &out)
+				},
+			}
+			for name, call := range calls {
+				res, err := call()
+				if err != nil {
+					_ = err.Error()
+					apiErr := &APIError{}
+					if !errors.As(err, &apiErr) {
+						t.Fatalf("%s: expected an APIError, got (%#v)", name, err)
+					}
+					continue
+				}
+				if res == nil || reflect.ValueOf(res).IsNil() {
+					t.Fatalf("%s: returned neither a result nor an error", name)
+				}
+				if status >= http.StatusBadRequest {
+					t.Fatalf("%s: status %d did not fail", name, status)
+				}
+			}
+		}
+	})
+}
+
+func FuzzErrorParsing(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	g := NewClient("", "", "", nil, WithErrorBodyLimit(16)).(*getResponseClient)
+	f.Fuzz(func(t *testing.T, body []byte) {
+		err := g.checkGetResponseError(context.Background(), http.MethodGet, "/v3/contacts", http.StatusBadRequest, body, nil)
+		if err == nil {
+			t.Fatalf("Expected an error for an error status")
+		}
+		_ = err.Error()
+		_ = Retryable(err)
+	})
+}
+
+func TestUnit_LenientErrorParsing(t *testing.T) {
+	type testcase struct {
+		name     string
+		body     string
+		expected GetResponseError
+		context  []string
+	}
+	for _, tc := range []testcase{
+		{
+			name:     "documented shape",
+			body:     `{"httpStatus":400,"code":1000,"message":"invalid","context":["email"],"uuid":"u"}`,
+			expected: GetResponseError{HTTPStatus: 400, ErrorCode: 1000, Message: "invalid", UUID: "u"},
+			context:  []string{"email"},
+		},
+		{
+			name:     "numeric message and string code",
+			body:     `{"httpStatus":"400","code":" 1000","message":1234}`,
+			expected: GetResponseError{HTTPStatus: 400, ErrorCode: 1000, Message: "1234"},
+		},
+		{
+			name:     "context object",
+			body:     `{"code":1000,"message":"invalid","context":{"field": "email"}}`,
+			expected: GetResponseError{ErrorCode: 1000, Message: "invalid"},
+			context:  []string{`{"field":"email"}`},
+		},
+		{
+			name:    "mixed context and out of range code",
+			body:    `{"code":1e400,"message":null,"context":["a",1,null]}`,
+			context: []string{"a", "1", ""},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fuzzClient(http.StatusBadRequest, []byte(tc.body))
+			_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "a"})
+			grErr := &GetResponseError{}
+			if !errors.As(err, &grErr) {
+				t.Fatalf("Expected a GetResponseError, got (%#v)", err)
+			}
+			if context := grErr.Context.Strings(); !reflect.DeepEqual(context, tc.context) {
+				t.Fatalf("Actual context (%q) did not match expected (%q)", context, tc.context)
+			}
+			grErr.Context = ErrorContext{}
+			if !reflect.DeepEqual(*grErr, tc.expected) {
+				t.Fatalf("Actual error (%#v) did not match expected (%#v)", *grErr, tc.expected)
+			}
+		})
+	}
+}
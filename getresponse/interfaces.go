@@ -0,0 +1,88 @@
+package getresponse
+
+import "context"
+
+// The interfaces below have a single method each, so code depending on one capability can take it instead of the
+// whole Client and be tested with a one-method fake:
+//
+//	func welcome(ctx context.Context, contacts getresponse.ContactCreator, email string) error
+//	...
+//	welcome(ctx, client.Contacts(), email)
+//
+// Unlike Client and the per-resource interfaces they never gain methods, so implementing them is safe across
+// releases.
+
+// ContactCreator creates contacts, see ContactsClient.Create
+type ContactCreator interface {
+	Create(ctx context.Context, request *CreateContactRequest) error
+}
+
+// ContactGetter reads a contact, see ContactsClient.Get
+type ContactGetter interface {
+	Get(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error)
+}
+
+// ContactLister lists contacts, see ContactsClient.List
+type ContactLister interface {
+	List(ctx context.Context, request *GetContactsRequest) (*GetContactsResponse, error)
+}
+
+// ContactUpdater updates a contact, see ContactsClient.Update
+type ContactUpdater interface {
+	Update(ctx context.Context, request *UpdateContactRequest) (*UpdateContactResponse, error)
+}
+
+// ContactDeleter removes a contact, see ContactsClient.Remove
+type ContactDeleter interface {
+	Remove(ctx context.Context, request *RemoveContactRequest) error
+}
+
+// ContactUnsubscriber unsubscribes a contact, see ContactsClient.Unsubscribe
+type ContactUnsubscriber interface {
+	Unsubscribe(ctx context.Context, request *UnsubscribeContactRequest) error
+}
+
+// CampaignGetter reads a campaign, see CampaignsClient.Get
+type CampaignGetter interface {
+	Get(ctx context.Context, request *GetCampaignRequest) (*Campaign, error)
+}
+
+// CampaignLister lists campaigns, see CampaignsClient.List
+type CampaignLister interface {
+	List(ctx context.Context, request *GetCampaignsRequest) (*GetCampaignsResponse, error)
+}
+
+// CustomFieldLister lists custom field definitions, see CustomFieldsClient.List
+type CustomFieldLister interface {
+	List(ctx context.Context, request *GetCustomFieldsRequest) (*GetCustomFieldsResponse, error)
+}
+
+// TagLister lists tags, see TagsClient.List
+type TagLister interface {
+	List(ctx context.Context, request *GetTagsRequest) (*GetTagsResponse, error)
+}
+
+// NewsletterCreator creates newsletters, see NewslettersClient.Create
+type NewsletterCreator interface {
+	Create(ctx context.Context, request *CreateNewsletterRequest) (*Newsletter, error)
+}
+
+// TransactionalEmailSender sends transactional emails, see Client.SendTransactionalEmail
+type TransactionalEmailSender interface {
+	SendTransactionalEmail(ctx context.Context, request *SendTransactionalEmailRequest) (*TransactionalEmail, error)
+}
+
+var (
+	_ ContactCreator           = ContactsClient(nil)
+	_ ContactGetter            = ContactsClient(nil)
+	_ ContactLister            = ContactsClient(nil)
+	_ ContactUpdater           = ContactsClient(nil)
+	_ ContactDeleter           = ContactsClient(nil)
+	_ ContactUnsubscriber      = ContactsClient(nil)
+	_ CampaignGetter           = CampaignsClient(nil)
+	_ CampaignLister           = CampaignsClient(nil)
+	_ CustomFieldLister        = CustomFieldsClient(nil)
+	_ TagLister                = TagsClient(nil)
+	_ NewsletterCreator        = NewslettersClient(nil)
+	_ TransactionalEmailSender = Client(nil)
+)
@@ -0,0 +1,89 @@
+package getresponse
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// WithIPAnonymization truncates the IP addresses sent with contact requests before they leave the process: the last
+// octet of IPv4 addresses and the last 80 bits of IPv6 addresses are zeroed, the usual truncation for analytics
+// under privacy rules.
+func WithIPAnonymization() Option {
+	return func(g *getResponseClient) {
+		g.anonymizeIP = true
+	}
+}
+
+// validateIP checks that ip is empty or an IPv4 or IPv6 address
+func validateIP(ip string) error {
+	_, err := parseIP(ip)
+	return err
+}
+
+func parseIP(ip string) (netip.Addr, error) {
+	if ip == "" {
+		return netip.Addr{}, nil
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, &ValidationError{Field: "ipAddress", Message: fmt.Sprintf("%q is not an IP address", ip)}
+	}
+	return addr.Unmap(), nil
+}
+
+// normalizeIP validates ip and returns it in canonical form, anonymized with WithIPAnonymization
+func (g *getResponseClient) normalizeIP(ip string) (string, error) {
+	addr, err := parseIP(ip)
+	if err != nil || !addr.IsValid() {
+		return "", err
+	}
+	if g.anonymizeIP {
+		bits := 24
+		if addr.Is6() {
+			bits = 48
+		}
+		prefix, _ := addr.Prefix(bits)
+		return prefix.Addr().String(), nil
+	}
+	return addr.String(), nil
+}
+
+// normalizeIPPtr is normalizeIP for an optional field, returning ip itself when it is unchanged
+func (g *getResponseClient) normalizeIPPtr(ip *string) (*string, error) {
+	if ip == nil {
+		return nil, nil
+	}
+	normalized, err := g.normalizeIP(*ip)
+	if err != nil || normalized == *ip {
+		return ip, err
+	}
+	return &normalized, nil
+}
+
+// normalizeCreateIP applies normalizeIP to the request, copying it when the address changes
+func (g *getResponseClient) normalizeCreateIP(request *CreateContactRequest) (*CreateContactRequest, error) {
+	if request == nil {
+		return request, nil
+	}
+	ip, err := g.normalizeIPPtr(request.IPAddress)
+	if err != nil || ip == request.IPAddress {
+		return request, err
+	}
+	normalized := *request
+	normalized.IPAddress = ip
+	return &normalized, nil
+}
+
+// normalizeUpdateIP applies normalizeIP to the request, copying it when the address changes
+func (g *getResponseClient) normalizeUpdateIP(request *UpdateContactRequest) (*UpdateContactRequest, error) {
+	if request == nil {
+		return request, nil
+	}
+	ip, err := g.normalizeIPPtr(request.NewData.IPAddress)
+	if err != nil || ip == request.NewData.IPAddress {
+		return request, err
+	}
+	normalized := *request
+	normalized.NewData.IPAddress = ip
+	return &normalized, nil
+}
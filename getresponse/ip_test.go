@@ -0,0 +1,83 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnit_ContactIPAddresses(t *testing.T) {
+	type testcase struct {
+		name      string
+		ip        string
+		anonymize bool
+		expected  string
+		invalid   bool
+	}
+
+	testcases := []testcase{
+		{name: "ipv4", ip: "203.0.113.57", expected: "203.0.113.57"},
+		{name: "ipv4 anonymized", ip: "203.0.113.57", anonymize: true, expected: "203.0.113.0"},
+		{name: "ipv6 canonical", ip: "2001:DB8:0:0:8:800:200C:417A", expected: "2001:db8::8:800:200c:417a"},
+		{name: "ipv6 anonymized", ip: "2001:db8:85a3:8d3:1319:8a2e:370:7348", anonymize: true, expected: "2001:db8:85a3::"},
+		{name: "ipv4 mapped", ip: "::ffff:198.51.100.7", anonymize: true, expected: "198.51.100.0"},
+		{name: "hostname", ip: "example.com", invalid: true},
+		{name: "zone", ip: "fe80::1%eth0", invalid: true},
+		{name: "truncated", ip: "203.0.113", invalid: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created, deleted string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					deleted = r.URL.Query().Get("ipAddress")
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				body, _ := ioutil.ReadAll(r.Body)
+				req := map[string]interface{}{}
+				_ = json.Unmarshal(body, &req)
+				created, _ = req["ipAddress"].(string)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer ts.Close()
+			opts := []Option{WithoutThrottling()}
+			if tc.anonymize {
+				opts = append(opts, WithIPAnonymization())
+			}
+			c := NewClient(ts.URL, "", "", nil, opts...)
+
+			ip := tc.ip
+			req := &CreateContactRequest{Email: "a@example.com", Campaign: Campaign{CampaignID: "c"}, IPAddress: &ip}
+			createErr := c.Contacts().Create(context.Background(), req)
+			removeErr := c.Contacts().Remove(context.Background(), &RemoveContactRequest{ID: "a", IPAddress: tc.ip})
+
+			if tc.invalid {
+				for _, err := range []error{createErr, removeErr, req.Validate()} {
+					vErr := &ValidationError{}
+					if !errors.As(err, &vErr) || vErr.Field != "ipAddress" {
+						t.Fatalf("Expected a ValidationError, got (%#v)", err)
+					}
+				}
+				if created != "" || deleted != "" {
+					t.Fatalf("Invalid addresses were sent (%s, %s)", created, deleted)
+				}
+				return
+			}
+			if createErr != nil || removeErr != nil {
+				t.Fatalf("Unexpected error occurred (%#v, %#v)", createErr, removeErr)
+			}
+			if created != tc.expected || deleted != tc.expected {
+				t.Fatalf("Actual addresses (%s, %s) did not match expected (%s)", created, deleted, tc.expected)
+			}
+			if *req.IPAddress != tc.ip {
+				t.Fatalf("The caller's request was modified (%s)", *req.IPAddress)
+			}
+		})
+	}
+}
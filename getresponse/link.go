@@ -0,0 +1,46 @@
+package getresponse
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FollowLink fetches the resource an href of a response links to, with elem appended to its path, and decodes it
+// into a T, so related resources are reached without rebuilding their paths:
+//
+//	res, err := client.Contacts().Get(ctx, &getresponse.GetContactRequest{ID: id})
+//	campaign, err := getresponse.FollowLink[getresponse.Campaign](ctx, client, res.Contact.Campaign.Href)
+//	stats, err := getresponse.FollowLink[[]json.RawMessage](ctx, client, newsletter.Href, "statistics")
+//
+// Only the path and query of href are used: the request goes to the client's API URL, so a link can't send the
+// credentials to another host.
+func FollowLink[T any](ctx context.Context, c Client, href *string, elem ...string) (T, error) {
+	var result T
+	path, query, err := linkPath(href, elem...)
+	if err != nil {
+		return result, err
+	}
+	err = c.Do(ctx, http.MethodGet, path, query, nil, &result)
+	return result, err
+}
+
+// linkPath splits href into the path and query Do takes
+func linkPath(href *string, elem ...string) (string, url.Values, error) {
+	if href == nil || *href == "" {
+		return "", nil, &ValidationError{Field: "href", Message: "is required"}
+	}
+	u, err := url.Parse(*href)
+	if err != nil || !strings.HasPrefix(u.Path, "/") || strings.Contains(u.Path+"/", "/../") {
+		return "", nil, &ValidationError{Field: "href", Message: "is not a link to a resource"}
+	}
+	path := strings.TrimRight(u.EscapedPath(), "/")
+	for _, e := range elem {
+		if e == "" || e == "." || e == ".." {
+			return "", nil, &ValidationError{Field: "href", Message: "can't be followed to " + e}
+		}
+		path += "/" + url.PathEscape(e)
+	}
+	return path, u.Query(), nil
+}
@@ -0,0 +1,58 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_FollowLink(t *testing.T) {
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/v3/contacts/a":
+			fmt.Fprint(w, `{"contactId":"a","campaign":{"campaignId":"c","href":"https://api.getresponse.com/v3/campaigns/c"}}`)
+		case "/v3/campaigns/c":
+			fmt.Fprint(w, `{"campaignId":"c","name":"list"}`)
+		case "/v3/newsletters/n%201/statistics?query%5BgroupBy%5D=total":
+			fmt.Fprint(w, `[{"sent":"2"}]`)
+		default:
+			t.Errorf("Unexpected request (%s)", r.URL.RequestURI())
+		}
+	})
+	defer ts.Close()
+	ctx := context.Background()
+
+	res, err := c.Contacts().Get(ctx, &GetContactRequest{ID: "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	campaign, err := FollowLink[Campaign](ctx, c, res.Contact.Campaign.Href)
+	if err != nil || campaign.Name != "list" {
+		t.Fatalf("Unexpected campaign (%#v, %#v)", campaign, err)
+	}
+
+	href := "https://api.getresponse.com/v3/newsletters/n%201?query[groupBy]=total"
+	stats, err := FollowLink[[]json.RawMessage](ctx, c, &href, "statistics")
+	if err != nil || len(stats) != 1 {
+		t.Fatalf("Unexpected statistics (%s, %#v)", stats, err)
+	}
+
+	type testcase struct {
+		href string
+		elem []string
+	}
+	for _, tc := range []testcase{
+		{"", nil},
+		{"campaigns/c", nil},
+		{"https://api.getresponse.com/v3/../admin", nil},
+		{"https://api.getresponse.com/v3/campaigns/c", []string{".."}},
+	} {
+		href := tc.href
+		_, err := FollowLink[Campaign](ctx, c, &href, tc.elem...)
+		if _, ok := err.(*ValidationError); !ok {
+			t.Fatalf("Expected a validation error for (%v), got (%#v)", tc.href, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package getresponse
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PredefinedMergeTags are the merge tags available in every account, on top of one per custom field
+var PredefinedMergeTags = []string{
+	"name", "firstname", "lastname", "email",
+	"ip", "geo_city", "geo_country", "geo_region", "geo_postal",
+	"campaign_name", "responder", "responder_email",
+	"date", "time", "day", "month", "year",
+	"unsubscribe", "view", "forward", "remove",
+}
+
+var mergeTagPattern = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+
+func (g *getResponseClient) GetMergeTags(ctx context.Context) ([]string, error) {
+	tags := append([]string{}, PredefinedMergeTags...)
+	req := &GetCustomFieldsRequest{Page: 1, PerPage: 100}
+	for {
+		res, err := g.getCustomFields(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range res.CustomFields {
+			tags = append(tags, f.Name)
+		}
+		if len(res.CustomFields) < int(req.PerPage) {
+			break
+		}
+		req.Page++
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// FindMergeTags returns the names of the merge tags referenced in s, in order of first use. Arguments such as in
+// [[date "Y-m-d"]] are not part of the name.
+func FindMergeTags(s string) []string {
+	var ret []string
+	seen := map[string]bool{}
+	for _, m := range mergeTagPattern.FindAllStringSubmatch(s, -1) {
+		fields := strings.Fields(m[1])
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		if !seen[name] {
+			seen[name] = true
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// ValidateMergeTags checks that the content only references merge tags listed in available, see GetMergeTags
+func ValidateMergeTags(content NewsletterContent, available []string) error {
+	known := map[string]bool{}
+	for _, t := range available {
+		known[strings.ToLower(t)] = true
+	}
+
+	var unknown []string
+	for _, t := range FindMergeTags(content.HTML + "\n" + content.Plain) {
+		if !known[t] {
+			unknown = append(unknown, t)
+		}
+	}
+	if len(unknown) > 0 {
+		return &ValidationError{Field: "content", Message: "references unknown merge tags " + strings.Join(unknown, ", ")}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_MergeTags(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"customFieldId":"f1","name":"city"},{"customFieldId":"f2","name":"plan"}]`)
+	}))
+	defer ts.Close()
+
+	available, err := c.GetMergeTags(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	found := FindMergeTags(`Hi [[name]], [[date "Y-m-d"]] [[Plan]] [[name]] [[ ]]`)
+	expected := []string{"name", "date", "plan"}
+	if fmt.Sprint(found) != fmt.Sprint(expected) {
+		t.Fatalf("Actual tags (%v) did not match expected (%v)", found, expected)
+	}
+
+	err = ValidateMergeTags(NewsletterContent{HTML: "<p>[[city]]</p>", Plain: "[[firstname]] [[plan]]"}, available)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	err = ValidateMergeTags(NewsletterContent{Plain: "[[firstnam]] [[plan]] [[coupon]]"}, available)
+	vErr := &ValidationError{}
+	if !errors.As(err, &vErr) || vErr.Message != "references unknown merge tags firstnam, coupon" {
+		t.Fatalf("Unexpected error (%#v)", err)
+	}
+}
@@ -0,0 +1,56 @@
+package getresponse
+
+import "net/http"
+
+// Middleware wraps the RoundTripper requests are sent with, e.g. to record metrics or add tracing:
+//
+//	func metrics(next http.RoundTripper) http.RoundTripper {
+//		return getresponse.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			start := time.Now()
+//			resp, err := next.RoundTrip(req)
+//			observe(req, resp, time.Since(start))
+//			return resp, err
+//		})
+//	}
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware wraps the transport with m, so library and caller features compose without a custom http.Client.
+// Every attempt goes through, in order:
+//
+//  1. the client: throttling, retries, authentication, correlation id, signing (see WithRequestSigner) and debug
+//     dumps, once per attempt
+//  2. the middlewares, the first one given outermost; options given several times add up in order
+//  3. the transport of the http.Client passed to NewClient, or the one built from the transport options, or
+//     http.DefaultTransport
+//
+// The http.Client passed to NewClient is not modified: its settings such as Timeout and Jar are kept on a copy
+// using the wrapped transport.
+func WithMiddleware(m ...Middleware) Option {
+	return func(g *getResponseClient) {
+		g.middlewares = append(g.middlewares, m...)
+	}
+}
+
+// wrapTransport returns c with its transport wrapped in the middlewares, c itself without middlewares
+func wrapTransport(c *http.Client, middlewares []Middleware) *http.Client {
+	if len(middlewares) == 0 {
+		return c
+	}
+	rt := c.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	wrapped := *c
+	wrapped.Transport = rt
+	return &wrapped
+}
@@ -0,0 +1,64 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_Middleware(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	}))
+	defer ts.Close()
+
+	calls := []string{}
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+	base := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		calls = append(calls, "caller")
+		if r.Header.Get("X-Auth-Token") == "" {
+			t.Errorf("Expected the client to authenticate before the middlewares")
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	own := &http.Client{Transport: base, Timeout: time.Minute}
+
+	c := NewClient(ts.URL, "key", "", own,
+		WithoutThrottling(),
+		WithDefaultPolicy(Policy{MaxRetries: 1}),
+		WithMiddleware(trace("outer")),
+		WithMiddleware(trace("inner")),
+	)
+	_, err := c.Campaigns().List(context.Background(), &GetCampaignsRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := "outer inner caller outer inner caller"
+	if actual := strings.Join(calls, " "); actual != expected {
+		t.Fatalf("Actual calls (%v) did not match expected (%v)", actual, expected)
+	}
+	if own.Transport == nil || c.(*getResponseClient).c == own || c.(*getResponseClient).c.Timeout != time.Minute {
+		t.Fatalf("Expected a copy of the caller's client")
+	}
+	if _, ok := own.Transport.(roundTripperFunc); !ok {
+		t.Fatalf("Expected the caller's client to be left untouched")
+	}
+}
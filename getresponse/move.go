@@ -0,0 +1,78 @@
+package getresponse
+
+import (
+	"context"
+)
+
+// MoveOptions controls what happens to the autoresponder cycle when a contact moves to another campaign. By default
+// the contact starts the target campaign's cycle from day 0. At most one option may be set.
+type MoveOptions struct {
+	DayOfCycle       *int32 // start the target campaign's cycle at this day
+	KeepDayOfCycle   bool   // keep the day the contact is at
+	NoAutoresponders bool   // take the contact out of the cycle
+}
+
+func (o *MoveOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+	set := 0
+	for _, ok := range []bool{o.DayOfCycle != nil, o.KeepDayOfCycle, o.NoAutoresponders} {
+		if ok {
+			set++
+		}
+	}
+	if set > 1 {
+		return &ValidationError{Field: "options", Message: "DayOfCycle, KeepDayOfCycle and NoAutoresponders are exclusive"}
+	}
+	if o.DayOfCycle != nil && *o.DayOfCycle < 0 {
+		return &ValidationError{Field: "dayOfCycle", Message: "must not be negative"}
+	}
+	return nil
+}
+
+func (g *getResponseClient) moveContactToCampaign(ctx context.Context, contactID, targetCampaignID string, opts *MoveOptions) (*Contact, error) {
+	if contactID == "" {
+		return nil, &ValidationError{Field: "id", Message: "is required"}
+	}
+	if targetCampaignID == "" {
+		return nil, &ValidationError{Field: "campaign.campaignId", Message: "is required"}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &MoveOptions{}
+	}
+
+	req := &UpdateContactRequest{
+		ID:      contactID,
+		NewData: Contact{Campaign: &Campaign{CampaignID: targetCampaignID}},
+		Fields:  []string{"campaign", "dayOfCycle"},
+	}
+	switch {
+	case opts.NoAutoresponders:
+		req.Clear = []string{"dayOfCycle"}
+	case opts.KeepDayOfCycle:
+		// send the current day along, so the move does not reset it
+		res, err := g.getContact(withoutCache(ctx), &GetContactRequest{ID: contactID, Fields: []string{"dayOfCycle"}})
+		if err != nil {
+			return nil, err
+		}
+		if res.Contact.DayOfCycle == nil {
+			req.Clear = []string{"dayOfCycle"}
+		}
+		req.NewData.DayOfCycle = res.Contact.DayOfCycle
+	case opts.DayOfCycle != nil:
+		req.NewData.DayOfCycle = opts.DayOfCycle
+	default:
+		var start int32
+		req.NewData.DayOfCycle = &start
+	}
+
+	res, err := g.updateContact(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Contact, nil
+}
@@ -0,0 +1,89 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_MoveContactToCampaign(t *testing.T) {
+
+	type testcase struct {
+		name         string
+		opts         *MoveOptions
+		current      string
+		expectedBody string
+		expectErr    bool
+	}
+
+	testcases := []testcase{
+		{
+			name:         "restarts the cycle by default",
+			expectedBody: `{"campaign":{"campaignId":"c2"},"dayOfCycle":0}`,
+		},
+		{
+			name:         "given day",
+			opts:         &MoveOptions{DayOfCycle: makeInt32Ptr(5)},
+			expectedBody: `{"campaign":{"campaignId":"c2"},"dayOfCycle":5}`,
+		},
+		{
+			name:         "keeps the current day",
+			opts:         &MoveOptions{KeepDayOfCycle: true},
+			current:      `{"contactId":"foo","dayOfCycle":12}`,
+			expectedBody: `{"campaign":{"campaignId":"c2"},"dayOfCycle":12}`,
+		},
+		{
+			name:         "keeps being out of the cycle",
+			opts:         &MoveOptions{KeepDayOfCycle: true},
+			current:      `{"contactId":"foo"}`,
+			expectedBody: `{"campaign":{"campaignId":"c2"},"dayOfCycle":null}`,
+		},
+		{
+			name:         "without autoresponders",
+			opts:         &MoveOptions{NoAutoresponders: true},
+			expectedBody: `{"campaign":{"campaignId":"c2"},"dayOfCycle":null}`,
+		},
+		{
+			name:      "exclusive options",
+			opts:      &MoveOptions{NoAutoresponders: true, DayOfCycle: makeInt32Ptr(1)},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := ""
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fmt.Fprint(w, tc.current)
+					return
+				}
+				raw, _ := ioutil.ReadAll(r.Body)
+				body = string(raw)
+				fmt.Fprint(w, `{"contactId":"foo","campaign":{"campaignId":"c2"}}`)
+			}))
+			defer ts.Close()
+
+			contact, err := c.MoveContactToCampaign(context.Background(), "foo", "c2", tc.opts)
+			if tc.expectErr {
+				vErr := &ValidationError{}
+				if !errors.As(err, &vErr) || body != "" {
+					t.Fatalf("Expected a validation error and no update, got (%#v, %s)", err, body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if body != tc.expectedBody {
+				t.Fatalf("Actual body (%s) did not match expected (%s)", body, tc.expectedBody)
+			}
+			if contact.Campaign == nil || contact.Campaign.CampaignID != "c2" {
+				t.Fatalf("Unexpected contact (%#v)", contact)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultSendPollInterval = 10 * time.Second
+
+// Send statuses of SendMetrics
+const (
+	SendStatusScheduled  = "scheduled"
+	SendStatusInProgress = "in_progress"
+	SendStatusFinished   = "finished"
+)
+
+// NewsletterSendError is returned by WaitForSend when the send ended without finishing, e.g. it was cancelled
+type NewsletterSendError struct {
+	NewsletterID string
+	Status       string
+}
+
+func (e *NewsletterSendError) Error() string {
+	return fmt.Sprintf("newsletter %s send ended with status %q", e.NewsletterID, e.Status)
+}
+
+// failedSendStatuses end a send without finishing it
+var failedSendStatuses = map[string]bool{
+	"cancelled": true,
+	"canceled":  true,
+	"stopped":   true,
+	"failed":    true,
+	"error":     true,
+}
+
+// SendWaitOptions controls how WaitForSend polls the newsletter
+type SendWaitOptions struct {
+	Interval time.Duration // between polls (default 10s)
+	// Progress is called with the newsletter's metrics after every poll that changed them
+	Progress func(SendMetrics)
+}
+
+func (g *getResponseClient) sendNewsletterToSegment(ctx context.Context, request *CreateNewsletterRequest, segmentIDs ...string) (*Newsletter, error) {
+	if len(segmentIDs) == 0 {
+		return nil, &ValidationError{Field: "sendSettings.selectedSegments", Message: "at least one segment is required"}
+	}
+
+	req := *request
+	req.SendSettings.SelectedSegments = append([]string{}, request.SendSettings.SelectedSegments...)
+	for _, id := range segmentIDs {
+		_, err := g.GetSearchContact(ctx, &GetSearchContactRequest{ID: id})
+		apiErr := &APIError{}
+		if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusNotFound {
+			return nil, &ValidationError{Field: "sendSettings.selectedSegments", Message: fmt.Sprintf("segment %s does not exist", id)}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !containsString(req.SendSettings.SelectedSegments, id) {
+			req.SendSettings.SelectedSegments = append(req.SendSettings.SelectedSegments, id)
+		}
+	}
+
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return g.createNewsletter(ctx, &req)
+}
+
+func (g *getResponseClient) getNewsletter(ctx context.Context, id string) (*Newsletter, error) {
+	ctx = withOperation(ctx, OpGetNewsletter)
+
+	path, err := resourcePath("newsletters", id)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := doGet[Newsletter](ctx, g, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.localizeNewsletters(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (g *getResponseClient) waitForNewsletterSend(ctx context.Context, newsletterID string, opts *SendWaitOptions) (*Newsletter, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	o := SendWaitOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultSendPollInterval
+	}
+
+	var last *SendMetrics
+	for {
+		n, err := g.getNewsletter(withoutCache(ctx), newsletterID)
+		if err != nil {
+			return nil, err
+		}
+		if n.SendMetrics != nil {
+			m := *n.SendMetrics
+			if o.Progress != nil && (last == nil || !sameSendMetrics(*last, m)) {
+				err := g.callHook(ctx, "progress", func() {
+					o.Progress(m)
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+			last = &m
+
+			status := strings.ToLower(m.Status)
+			if status == SendStatusFinished {
+				return n, nil
+			}
+			if failedSendStatuses[status] {
+				return n, &NewsletterSendError{NewsletterID: newsletterID, Status: m.Status}
+			}
+		}
+
+		err = sleepContext(ctx, o.Interval)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func sameSendMetrics(a, b SendMetrics) bool {
+	return a.Status == b.Status && a.Sent == b.Sent && a.Total == b.Total
+}
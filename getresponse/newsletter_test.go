@@ -0,0 +1,231 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUnit_SendNewsletterToSegment(t *testing.T) {
+	var body string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/search-contacts/s1", "/v3/search-contacts/s2":
+			fmt.Fprint(w, `{"searchContactId":"s1","name":"active"}`)
+		case "/v3/search-contacts/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"httpStatus":404,"code":1013,"message":"Resource not found"}`)
+		case "/v3/newsletters":
+			raw, _ := ioutil.ReadAll(r.Body)
+			body = string(raw)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"newsletterId":"n1","status":"scheduled"}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	request := &CreateNewsletterRequest{
+		Subject:      "Hello",
+		Campaign:     Campaign{CampaignID: "c1"},
+		FromField:    FromFieldRef{FromFieldID: "f1"},
+		Content:      NewsletterContent{Plain: "Hi"},
+		SendSettings: SendSettings{SelectedSegments: []string{"s1"}},
+	}
+	n, err := c.SendNewsletterToSegment(context.Background(), request, "s1", "s2")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if n.NewsletterID != "n1" {
+		t.Fatalf("Unexpected newsletter (%#v)", n)
+	}
+	expected := `{"subject":"Hello","campaign":{"campaignId":"c1"},"fromField":{"fromFieldId":"f1"},"content":{"plain":"Hi"},"sendSettings":{"selectedSegments":["s1","s2"],"timeTravel":"false","perfectTiming":"false"}}`
+	if body != expected {
+		t.Fatalf("Actual body (%s) did not match expected (%s)", body, expected)
+	}
+	if len(request.SendSettings.SelectedSegments) != 1 {
+		t.Fatalf("The request must not be modified (%#v)", request.SendSettings)
+	}
+
+	body = ""
+	_, err = c.SendNewsletterToSegment(context.Background(), request, "missing")
+	vErr := &ValidationError{}
+	if !errors.As(err, &vErr) || body != "" {
+		t.Fatalf("Expected a validation error and no newsletter, got (%#v, %s)", err, body)
+	}
+}
+
+func TestUnit_CreateNewsletterScheduling(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour).Truncate(time.Second).In(time.FixedZone("CET", 3600))
+	past := time.Now().Add(-time.Hour)
+
+	type testcase struct {
+		name           string
+		sendOn         *time.Time
+		timeTravel     bool
+		perfectTiming  bool
+		expectedSendOn string
+		expectErr      bool
+	}
+
+	testcases := []testcase{
+		{
+			name:           "scheduled with time travel",
+			sendOn:         &future,
+			timeTravel:     true,
+			expectedSendOn: future.Format("2006-01-02T15:04:05") + "+0100",
+		},
+		{
+			name:          "perfect timing",
+			sendOn:        &future,
+			perfectTiming: true,
+		},
+		{
+			name:       "time travel needs a schedule",
+			timeTravel: true,
+			expectErr:  true,
+		},
+		{
+			name:          "exclusive options",
+			sendOn:        &future,
+			timeTravel:    true,
+			perfectTiming: true,
+			expectErr:     true,
+		},
+		{
+			name:      "in the past",
+			sendOn:    &past,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sent := map[string]interface{}{}
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewDecoder(r.Body).Decode(&sent)
+				fmt.Fprint(w, `{"newsletterId":"n1"}`)
+			}))
+			defer ts.Close()
+
+			req := &CreateNewsletterRequest{
+				Subject:   "Hello",
+				Campaign:  Campaign{CampaignID: "c1"},
+				FromField: FromFieldRef{FromFieldID: "f1"},
+				Content:   NewsletterContent{Plain: "Hi"},
+				SendOn:    tc.sendOn,
+				SendSettings: SendSettings{
+					SelectedCampaigns: []string{"c1"},
+					TimeTravel:        tc.timeTravel,
+					PerfectTiming:     tc.perfectTiming,
+				},
+			}
+			_, err := c.Newsletters().Create(context.Background(), req)
+			if tc.expectErr {
+				vErr := &ValidationError{}
+				if !errors.As(err, &vErr) || len(sent) != 0 {
+					t.Fatalf("Expected a validation error without a request, got (%#v, %v)", err, sent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if tc.expectedSendOn != "" && sent["sendOn"] != tc.expectedSendOn {
+				t.Fatalf("Actual sendOn (%v) did not match expected (%s)", sent["sendOn"], tc.expectedSendOn)
+			}
+			settings := sent["sendSettings"].(map[string]interface{})
+			if settings["timeTravel"] != fmt.Sprint(tc.timeTravel) || settings["perfectTiming"] != fmt.Sprint(tc.perfectTiming) {
+				t.Fatalf("Unexpected send settings (%v)", settings)
+			}
+		})
+	}
+}
+
+func TestUnit_WaitForNewsletterSend(t *testing.T) {
+	type testcase struct {
+		name          string
+		responses     []string
+		expectedCalls []string
+		expectedErr   string
+	}
+
+	testcases := []testcase{
+		{
+			name: "finished",
+			responses: []string{
+				`{"newsletterId":"n1"}`,
+				`{"newsletterId":"n1","sendMetrics":{"status":"in_progress","sent":"10","total":"100"}}`,
+				`{"newsletterId":"n1","sendMetrics":{"status":"in_progress","sent":"10","total":"100"}}`,
+				`{"newsletterId":"n1","sendMetrics":{"status":"finished","sent":100,"total":100}}`,
+			},
+			expectedCalls: []string{"in_progress 10/100", "finished 100/100"},
+		},
+		{
+			name: "cancelled",
+			responses: []string{
+				`{"newsletterId":"n1","sendMetrics":{"status":"scheduled","sent":"0","total":"100"}}`,
+				`{"newsletterId":"n1","sendMetrics":{"status":"cancelled","sent":"0","total":"100"}}`,
+			},
+			expectedCalls: []string{"scheduled 0/100", "cancelled 0/100"},
+			expectedErr:   `newsletter n1 send ended with status "cancelled"`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/newsletters/n1" {
+					t.Errorf("Unexpected request %s", r.URL.Path)
+				}
+				fmt.Fprint(w, tc.responses[polls])
+				polls++
+			}))
+			defer ts.Close()
+
+			var calls []string
+			n, err := c.Newsletters().WaitForSend(context.Background(), "n1", &SendWaitOptions{
+				Interval: time.Millisecond,
+				Progress: func(m SendMetrics) {
+					calls = append(calls, fmt.Sprintf("%s %d/%d", m.Status, m.Sent, m.Total))
+				},
+			})
+			if tc.expectedErr == "" && err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if tc.expectedErr != "" {
+				sendErr := &NewsletterSendError{}
+				if !errors.As(err, &sendErr) || err.Error() != tc.expectedErr {
+					t.Fatalf("Actual error (%v) did not match expected (%s)", err, tc.expectedErr)
+				}
+			}
+			if n == nil || n.NewsletterID != "n1" || polls != len(tc.responses) {
+				t.Fatalf("Unexpected result (%#v) after %d polls", n, polls)
+			}
+			if fmt.Sprint(calls) != fmt.Sprint(tc.expectedCalls) {
+				t.Fatalf("Actual progress (%v) did not match expected (%v)", calls, tc.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestUnit_WaitForNewsletterSendDeadline(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"newsletterId":"n1","sendMetrics":{"status":"in_progress","sent":"1","total":"2"}}`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.Newsletters().WaitForSend(ctx, "n1", &SendWaitOptions{Interval: time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got (%#v)", err)
+	}
+}
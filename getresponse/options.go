@@ -0,0 +1,43 @@
+package getresponse
+
+import (
+	"fmt"
+)
+
+// Option configures optional client behaviour, see NewClient
+type Option func(*getResponseClient)
+
+// Logger is satisfied by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger sets the logger used for diagnostic output
+func WithLogger(l Logger) Option {
+	return func(g *getResponseClient) {
+		g.logger = l
+	}
+}
+
+// WithDryRun makes the client validate and log mutating calls (create/update/delete) without sending them to the API.
+// Reads are still sent so the client can run safely against a production account.
+func WithDryRun(enabled bool) Option {
+	return func(g *getResponseClient) {
+		g.dryRun = enabled
+	}
+}
+
+// WithErrorBodyLimit caps how many bytes of the response body are kept on an APIError. A limit <= 0 keeps the whole body.
+// The default is 1024 bytes.
+func WithErrorBodyLimit(limit int) Option {
+	return func(g *getResponseClient) {
+		g.errorBodyLimit = limit
+	}
+}
+
+// logf writes to the configured logger with personal data redacted
+func (g *getResponseClient) logf(format string, v ...interface{}) {
+	if g.logger != nil {
+		g.logger.Printf("%s", g.redactString(fmt.Sprintf(format, v...)))
+	}
+}
@@ -0,0 +1,155 @@
+// Package outbox drains contact writes from a durable queue the application provides, so writes made while
+// GetResponse is unreachable are sent once it is back instead of being lost.
+//
+//	// in the request path, in the same transaction as the app's own data when the queue allows it
+//	err := queue.Enqueue(ctx, scheduler.Op{ID: eventID, Kind: scheduler.KindCreate, Create: req})
+//
+//	// in a background process
+//	w := &outbox.Worker{Client: client, Queue: queue, OnDeadLetter: report}
+//	err = w.Run(ctx)
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/scheduler"
+)
+
+const (
+	defaultBatchSize  = 10
+	defaultBackoff    = time.Second
+	defaultMaxBackoff = 10 * time.Minute
+	defaultIdleWait   = time.Second
+)
+
+// Message is an operation delivered by a Queue
+type Message struct {
+	Op scheduler.Op
+	// Attempts counts the earlier deliveries of the operation
+	Attempts int
+	// Handle is whatever the queue needs to acknowledge the delivery
+	Handle interface{}
+}
+
+// Queue is a durable queue of contact writes, e.g. backed by a database table or a message broker. Implementations
+// must keep an operation until it is acknowledged, and must be safe for concurrent use.
+type Queue interface {
+	// Enqueue durably stores an operation
+	Enqueue(ctx context.Context, op scheduler.Op) error
+	// Receive returns up to max operations due for delivery, or none when the queue is empty
+	Receive(ctx context.Context, max int) ([]Message, error)
+	// Ack removes a delivered operation from the queue
+	Ack(ctx context.Context, m Message) error
+	// Nack makes a delivered operation due again after delay, with its attempt count increased
+	Nack(ctx context.Context, m Message, delay time.Duration) error
+}
+
+// Worker sends the operations of a queue through a client. Operations failing with a retriable error (see
+// scheduler.Retriable) go back to the queue with an exponential delay, however often they fail, so nothing is lost
+// while the API is down. Operations the API rejects are handed to OnDeadLetter and removed.
+type Worker struct {
+	Client getresponse.Client
+	Queue  Queue
+	// BatchSize is how many operations are received at once, 10 when not set
+	BatchSize int
+	// Backoff is the delay before the first retry, doubled for every further one up to MaxBackoff. 1s and 10m when
+	// not set.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// IdleWait is how long to wait before polling an empty queue again, 1s when not set
+	IdleWait time.Duration
+	// OnDeadLetter, when set, receives operations that failed for good before they are removed. An error returned
+	// keeps the operation in the queue.
+	OnDeadLetter func(ctx context.Context, m Message, err error) error
+	// OnError, when set, is told about queue failures, which otherwise only delay the worker
+	OnError func(err error)
+}
+
+// Run drains the queue until ctx is done, then returns ctx's error
+func (w *Worker) Run(ctx context.Context) error {
+	for {
+		n, err := w.Drain(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && w.OnError != nil {
+			w.OnError(err)
+		}
+		if n == 0 || err != nil {
+			idle := w.IdleWait
+			if idle <= 0 {
+				idle = defaultIdleWait
+			}
+			timer := time.NewTimer(idle)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// Drain processes one batch of the queue and returns how many operations it received, and the first queue error
+func (w *Worker) Drain(ctx context.Context) (int, error) {
+	size := w.BatchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	msgs, err := w.Queue.Receive(ctx, size)
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	for _, m := range msgs {
+		err := w.process(ctx, m)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return len(msgs), firstErr
+}
+
+func (w *Worker) process(ctx context.Context, m Message) error {
+	err := m.Op.Apply(ctx, w.Client)
+	if err == nil {
+		return w.Queue.Ack(ctx, m)
+	}
+	if ctx.Err() != nil {
+		// shutting down, the queue redelivers unacknowledged operations
+		return nil
+	}
+	if scheduler.Retriable(err) {
+		return w.Queue.Nack(ctx, m, w.backoff(m.Attempts))
+	}
+
+	if w.OnDeadLetter != nil {
+		dErr := w.OnDeadLetter(ctx, m, err)
+		if dErr != nil {
+			return w.Queue.Nack(ctx, m, w.backoff(m.Attempts))
+		}
+	}
+	return w.Queue.Ack(ctx, m)
+}
+
+func (w *Worker) backoff(attempts int) time.Duration {
+	d := w.Backoff
+	if d <= 0 {
+		d = defaultBackoff
+	}
+	max := w.MaxBackoff
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+	for i := 0; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
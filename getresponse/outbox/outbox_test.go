@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/scheduler"
+)
+
+// memoryQueue is a Queue keeping messages in memory, delivering each at most once until it is acked or nacked
+type memoryQueue struct {
+	mu       sync.Mutex
+	messages []*Message
+	due      map[*Message]time.Time
+	acked    []string
+	delays   []time.Duration
+}
+
+func (q *memoryQueue) Enqueue(ctx context.Context, op scheduler.Op) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.due == nil {
+		q.due = map[*Message]time.Time{}
+	}
+	m := &Message{Op: op}
+	m.Handle = m
+	q.messages = append(q.messages, m)
+	q.due[m] = time.Time{}
+	return nil
+}
+
+func (q *memoryQueue) Receive(ctx context.Context, max int) ([]Message, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var ret []Message
+	for _, m := range q.messages {
+		due, ok := q.due[m]
+		if ok && len(ret) < max && !due.After(time.Now()) {
+			ret = append(ret, *m)
+			q.due[m] = time.Now().Add(time.Hour)
+		}
+	}
+	return ret, nil
+}
+
+func (q *memoryQueue) Ack(ctx context.Context, m Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.due, m.Handle.(*Message))
+	q.acked = append(q.acked, m.Op.ID)
+	return nil
+}
+
+func (q *memoryQueue) Nack(ctx context.Context, m Message, delay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	h := m.Handle.(*Message)
+	h.Attempts++
+	q.due[h] = time.Now().Add(delay)
+	q.delays = append(q.delays, delay)
+	return nil
+}
+
+func TestUnit_Worker(t *testing.T) {
+	down := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case down:
+			w.WriteHeader(http.StatusBadGateway)
+			fmt.Fprint(w, `<html>`)
+		case r.URL.Path == "/v3/contacts/bad":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":1013,"message":"Contact not found"}`)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer ts.Close()
+
+	q := &memoryQueue{}
+	q.Enqueue(context.Background(), scheduler.Op{ID: "1", Kind: scheduler.KindCreate, Create: &getresponse.CreateContactRequest{Email: "a@example.com", Campaign: getresponse.Campaign{CampaignID: "c"}}})
+	q.Enqueue(context.Background(), scheduler.Op{ID: "2", Kind: scheduler.KindRemove, ContactID: "bad"})
+
+	var deadLetters []string
+	w := &Worker{
+		Client:  getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Queue:   q,
+		Backoff: time.Nanosecond,
+		OnDeadLetter: func(ctx context.Context, m Message, err error) error {
+			apiErr := &getresponse.APIError{}
+			if !errors.As(err, &apiErr) || apiErr.ErrorCode != getresponse.ErrResourceNotFound {
+				t.Errorf("Unexpected dead letter error (%#v)", err)
+			}
+			deadLetters = append(deadLetters, m.Op.ID)
+			return nil
+		},
+	}
+
+	// the API is down: everything goes back to the queue
+	for i := 0; i < 2; i++ {
+		n, err := w.Drain(context.Background())
+		if err != nil || n != 2 {
+			t.Fatalf("Unexpected drain result (%d, %#v)", n, err)
+		}
+	}
+	if len(q.acked) != 0 || fmt.Sprint(q.delays) != "[1ns 1ns 2ns 2ns]" {
+		t.Fatalf("Unexpected queue state (acked %v, delays %v)", q.acked, q.delays)
+	}
+
+	down = false
+	n, err := w.Drain(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("Unexpected drain result (%d, %#v)", n, err)
+	}
+	if fmt.Sprint(q.acked) != "[1 2]" || fmt.Sprint(deadLetters) != "[2]" {
+		t.Fatalf("Unexpected queue state (acked %v, dead letters %v)", q.acked, deadLetters)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	w.IdleWait = time.Millisecond
+	if err := w.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected Run to stop with the context, got (%#v)", err)
+	}
+}
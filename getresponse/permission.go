@@ -0,0 +1,124 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Permission actions
+const (
+	ActionRead   = "read"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// ErrPermissionDenied is matched by the errors of requests the client's permissions do not allow
+var ErrPermissionDenied = errors.New("permission denied")
+
+// PermissionError is returned, before anything is sent, for a request the client's permissions do not allow
+type PermissionError struct {
+	Permission string // e.g. "contacts.delete"
+	Method     string
+	Path       string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s %s: %s is not allowed for this client", e.Method, e.Path, e.Permission)
+}
+
+func (e *PermissionError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+// WithAllowedPermissions restricts the client to the listed permissions, so code sharing a full-access API key can
+// follow least privilege. A permission is "<resource>.<action>": the resource is the first path segment of the
+// endpoint, e.g. "contacts", "campaigns" or "transactional-emails", and the action is "read" for GET, "create" for a
+// POST to the collection, "update" for a POST below it and "delete" for DELETE. Either part may be "*":
+//
+//	getresponse.WithAllowedPermissions("contacts.read", "contacts.create", "*.read")
+//
+// Requests sent with Do, Get and Post are checked as well. Ping is always allowed.
+func WithAllowedPermissions(permissions ...string) Option {
+	return func(g *getResponseClient) {
+		g.allowedPermissions = append(g.allowedPermissions, permissions...)
+		g.restrictPermissions = true
+	}
+}
+
+// WithDeniedPermissions forbids the listed permissions, see WithAllowedPermissions for their format. Denials take
+// precedence over allowances.
+func WithDeniedPermissions(permissions ...string) Option {
+	return func(g *getResponseClient) {
+		g.deniedPermissions = append(g.deniedPermissions, permissions...)
+	}
+}
+
+// RequestPermission returns the permission a request needs, e.g. "contacts.update" for POST /v3/contacts/{id}
+func RequestPermission(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && isVersionSegment(segments[0]) {
+		segments = segments[1:]
+	}
+	resource := ""
+	if len(segments) > 0 {
+		resource = segments[0]
+	}
+
+	action := ActionUpdate
+	switch {
+	case method == http.MethodGet || method == http.MethodHead:
+		action = ActionRead
+	case method == http.MethodDelete:
+		action = ActionDelete
+	case method == http.MethodPost && len(segments) <= 1:
+		action = ActionCreate
+	case method == http.MethodPost && resource == "search-contacts" && len(segments) == 2 && segments[1] == "contacts":
+		action = ActionRead // searches without saving the search
+	}
+	return resource + "." + action
+}
+
+// isRead reports whether a request only reads, like searches sent as POST
+func isRead(method, path string) bool {
+	return strings.HasSuffix(RequestPermission(method, path), "."+ActionRead)
+}
+
+func isVersionSegment(s string) bool {
+	return len(s) > 1 && s[0] == 'v' && strings.Trim(s[1:], "0123456789") == ""
+}
+
+func (g *getResponseClient) checkPermission(ctx context.Context, method, path string) error {
+	if !g.restrictPermissions && len(g.deniedPermissions) == 0 {
+		return nil
+	}
+	// pings and capability probes only read what the account may do, not its data
+	if op := operationFromContext(ctx); op == OpPing || op == OpProbeCapabilities {
+		return nil
+	}
+	permission := RequestPermission(method, path)
+	if matchesPermission(g.deniedPermissions, permission) ||
+		(g.restrictPermissions && !matchesPermission(g.allowedPermissions, permission)) {
+		return &PermissionError{Permission: permission, Method: method, Path: path}
+	}
+	return nil
+}
+
+func matchesPermission(patterns []string, permission string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, permission); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WithReadOnly forbids every create, update and delete, e.g. for a reporting client derived with Client.With. Unlike
+// WithAllowedPermissions it can only narrow what a client may do.
+func WithReadOnly() Option {
+	return WithDeniedPermissions("*."+ActionCreate, "*."+ActionUpdate, "*."+ActionDelete)
+}
@@ -0,0 +1,65 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnit_RequestPermission(t *testing.T) {
+	type testcase struct {
+		method, path, expected string
+	}
+	for _, tc := range []testcase{
+		{http.MethodGet, "/v3/contacts", "contacts.read"},
+		{http.MethodGet, "/v3/contacts/abc", "contacts.read"},
+		{http.MethodPost, "/v3/contacts", "contacts.create"},
+		{http.MethodPost, "/v3/contacts/abc", "contacts.update"},
+		{http.MethodPost, "/v3/contacts/abc/custom-fields", "contacts.update"},
+		{http.MethodDelete, "/v3/contacts/abc", "contacts.delete"},
+		{http.MethodPost, "/v3/transactional-emails", "transactional-emails.create"},
+	} {
+		actual := RequestPermission(tc.method, tc.path)
+		if actual != tc.expected {
+			t.Fatalf("Actual permission (%v) did not match expected (%v) for %s %s", actual, tc.expected, tc.method, tc.path)
+		}
+	}
+}
+
+func TestUnit_PermissionGuard(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+	c := NewClient(ts.URL, "", "", nil,
+		WithAllowedPermissions("contacts.*", "*.read"),
+		WithDeniedPermissions("contacts.delete"))
+	ctx := context.Background()
+
+	_, err := c.Contacts().Get(ctx, &GetContactRequest{ID: "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = c.Contacts().Remove(ctx, &RemoveContactRequest{ID: "a"})
+	pErr := &PermissionError{}
+	if !errors.As(err, &pErr) || pErr.Permission != "contacts.delete" || !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("Expected a permission error, got (%#v)", err)
+	}
+	_, err = c.Tags().Create(ctx, &CreateTagRequest{Name: "vip"})
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("Expected a permission error, got (%#v)", err)
+	}
+	if !c.Ping(ctx).OK() {
+		t.Fatalf("Expected Ping to be allowed")
+	}
+
+	expected := "[GET /v3/contacts/a GET /v3/accounts]"
+	if fmt.Sprint(calls) != expected {
+		t.Fatalf("Actual calls (%v) did not match expected (%v)", calls, expected)
+	}
+}
@@ -0,0 +1,75 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// PingStatus classifies the result of Ping
+type PingStatus string
+
+const (
+	PingOK PingStatus = "ok"
+	// PingNetworkError means no response was received: DNS, connection or timeout failures
+	PingNetworkError PingStatus = "network_error"
+	// PingAuthFailed means the credentials are missing, invalid or blocked
+	PingAuthFailed PingStatus = "auth_failed"
+	// PingQuotaExhausted means the account ran out of requests until the limit resets
+	PingQuotaExhausted PingStatus = "quota_exhausted"
+	// PingMisconfigured means the client's API URL or domain is invalid, see WithRegion
+	PingMisconfigured PingStatus = "misconfigured"
+	// PingAPIError is any other failure reported by the API, e.g. a 5xx response
+	PingAPIError PingStatus = "api_error"
+)
+
+// PingResult is the outcome of Ping
+type PingResult struct {
+	Status  PingStatus
+	Latency time.Duration
+	Err     error // nil when Status is PingOK
+}
+
+// OK reports whether the API can be used
+func (r PingResult) OK() bool {
+	return r.Status == PingOK
+}
+
+func (g *getResponseClient) Ping(ctx context.Context) PingResult {
+	ctx = withoutCache(withOperation(ctx, OpPing))
+
+	start := time.Now()
+	_, _, err := g.do(ctx, http.MethodGet, "/v3/accounts", nil, nil)
+	return PingResult{Status: pingStatus(err), Latency: time.Since(start), Err: err}
+}
+
+func pingStatus(err error) PingStatus {
+	if err == nil {
+		return PingOK
+	}
+	if errors.Is(err, ErrInvalidAPIURL) || errors.Is(err, ErrMissingDomain) {
+		return PingMisconfigured
+	}
+	if errors.Is(err, ErrThrottled) {
+		return PingQuotaExhausted
+	}
+	if cErr := (*credentialsError)(nil); errors.As(err, &cErr) {
+		return PingAuthFailed
+	}
+
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus == 0 {
+		return PingNetworkError
+	}
+	switch {
+	case apiErr.HTTPStatus == http.StatusUnauthorized || apiErr.HTTPStatus == http.StatusForbidden:
+		return PingAuthFailed
+	case apiErr.ErrorCode == ErrAuthenticationFailure || apiErr.ErrorCode == ErrIPBlocked ||
+		apiErr.ErrorCode == ErrTemporarilyBlocked || apiErr.ErrorCode == ErrPermanentlyBlocked:
+		return PingAuthFailed
+	case apiErr.HTTPStatus == http.StatusTooManyRequests || apiErr.ErrorCode == ErrequestQuotaReached:
+		return PingQuotaExhausted
+	}
+	return PingAPIError
+}
@@ -0,0 +1,89 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnit_Ping(t *testing.T) {
+
+	type testcase struct {
+		name           string
+		status         int
+		body           string
+		opts           []Option
+		closed         bool
+		expectedStatus PingStatus
+	}
+
+	testcases := []testcase{
+		{
+			name:           "ok",
+			status:         http.StatusOK,
+			body:           `{"accountId":"a"}`,
+			expectedStatus: PingOK,
+		},
+		{
+			name:           "invalid key",
+			status:         http.StatusUnauthorized,
+			body:           `{"code":1014,"message":"Problem during authentication process"}`,
+			expectedStatus: PingAuthFailed,
+		},
+		{
+			name:           "quota",
+			status:         http.StatusTooManyRequests,
+			body:           `{"code":1015,"message":"Request quota reached"}`,
+			expectedStatus: PingQuotaExhausted,
+		},
+		{
+			name:           "server error",
+			status:         http.StatusInternalServerError,
+			body:           `{"code":1,"message":"Internal error"}`,
+			expectedStatus: PingAPIError,
+		},
+		{
+			name:           "no credentials",
+			opts:           []Option{WithCredentialsProvider(EnvCredentials("GETRESPONSE_TEST_MISSING"))},
+			expectedStatus: PingAuthFailed,
+		},
+		{
+			name:           "network",
+			closed:         true,
+			expectedStatus: PingNetworkError,
+		},
+		{
+			name:           "misconfigured",
+			opts:           []Option{WithRegion(RegionMAXUS)},
+			expectedStatus: PingMisconfigured,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v3/accounts" {
+					t.Errorf("Unexpected request %s", r.URL.Path)
+				}
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			if tc.closed {
+				ts.Close()
+			} else {
+				defer ts.Close()
+			}
+
+			c := NewClient(ts.URL, "", "", nil, append(tc.opts, WithoutThrottling())...)
+			res := c.Ping(context.Background())
+			if res.Status != tc.expectedStatus {
+				t.Fatalf("Actual status (%s) did not match expected (%s): %v", res.Status, tc.expectedStatus, res.Err)
+			}
+			if res.OK() != (res.Err == nil) {
+				t.Fatalf("Unexpected result (%#v)", res)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Operation names a client call, e.g. for per-operation policies
+type Operation string
+
+// Operations performed by the client
+const (
+	OpCreateContact             Operation = "CreateContact"
+	OpGetContacts               Operation = "GetContacts"
+	OpGetContact                Operation = "GetContact"
+	OpUpdateContact             Operation = "UpdateContact"
+	OpUpdateContactCustomFields Operation = "UpdateContactCustomFields"
+	OpDeleteContact             Operation = "DeleteContact"
+	OpUnsubscribeContact        Operation = "UnsubscribeContact"
+	OpRemoveContact             Operation = "RemoveContact"
+	OpGetCampaign               Operation = "GetCampaign"
+	OpGetCampaigns              Operation = "GetCampaigns"
+	OpGetCustomFields           Operation = "GetCustomFields"
+	OpGetTags                   Operation = "GetTags"
+	OpGetNewsletters            Operation = "GetNewsletters"
+	OpGetNewsletter             Operation = "GetNewsletter"
+	OpGetNewsletterActivities   Operation = "GetNewsletterActivities"
+	OpCreateCampaign            Operation = "CreateCampaign"
+	OpUpdateCampaignSettings    Operation = "UpdateCampaignSettings"
+	OpCreateCustomField         Operation = "CreateCustomField"
+	OpCreateTag                 Operation = "CreateTag"
+	OpGetFromFields             Operation = "GetFromFields"
+	OpCreateFromField           Operation = "CreateFromField"
+	OpGetSearchContact          Operation = "GetSearchContact"
+	OpGetSegmentContacts        Operation = "GetSegmentContacts"
+	OpUpsertContactTags         Operation = "UpsertContactTags"
+	OpSearchContacts            Operation = "SearchContacts"
+	OpCreateNewsletter          Operation = "CreateNewsletter"
+	OpSendTransactionalEmail    Operation = "SendTransactionalEmail"
+	OpGetListSize               Operation = "GetListSize"
+	OpGetMessageStatistics      Operation = "GetMessageStatistics"
+	OpGetAutoresponders         Operation = "GetAutoresponders"
+	OpGetConfirmationContent    Operation = "GetConfirmationContent"
+	OpGetAccount                Operation = "GetAccount"
+	OpProbeCapabilities         Operation = "ProbeCapabilities"
+	OpPing                      Operation = "Ping"
+)
+
+const defaultBackoffMultiplier = 2.0
+
+// Policy controls the timeout and retries of an operation. The zero Policy sends every request once with no timeout
+// other than the context's.
+type Policy struct {
+	Timeout           time.Duration // per attempt, 0 for none
+	MaxRetries        int           // attempts after the first one
+	Backoff           time.Duration // delay before the first retry
+	BackoffMultiplier float64       // growth of the delay between retries (default 2)
+}
+
+func (p Policy) backoff(retry int) time.Duration {
+	m := p.BackoffMultiplier
+	if m < 1 {
+		m = defaultBackoffMultiplier
+	}
+	d := float64(p.Backoff)
+	for i := 0; i < retry; i++ {
+		d *= m
+	}
+	return time.Duration(d)
+}
+
+// WithPolicy sets the policy of a single operation, e.g. a short timeout for interactive GetContact calls
+func WithPolicy(op Operation, p Policy) Option {
+	return func(g *getResponseClient) {
+		if g.policies == nil {
+			g.policies = map[Operation]Policy{}
+		}
+		g.policies[op] = p
+	}
+}
+
+// WithDefaultPolicy sets the policy of every operation without one of its own
+func WithDefaultPolicy(p Policy) Option {
+	return func(g *getResponseClient) {
+		g.defaultPolicy = p
+	}
+}
+
+func (g *getResponseClient) policyFor(op Operation) Policy {
+	if p, ok := g.policies[op]; ok {
+		return p
+	}
+	return g.defaultPolicy
+}
+
+type operationKey struct{}
+
+func withOperation(ctx context.Context, op Operation) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+func operationFromContext(ctx context.Context) Operation {
+	if ctx == nil {
+		return ""
+	}
+	op, _ := ctx.Value(operationKey{}).(Operation)
+	return op
+}
+
+// shouldRetry reports whether an attempt failed in a way another attempt might fix
+func shouldRetry(ctx context.Context, status int, body []byte, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		_, throttled := err.(*ThrottledError)
+		return !throttled && !errors.Is(err, ErrHookPanicked)
+	}
+	if status < http.StatusBadRequest {
+		return false
+	}
+	return responseRetryable(status, body)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_Policy(t *testing.T) {
+
+	type testcase struct {
+		name          string
+		failures      int
+		delay         time.Duration
+		policy        Policy
+		expectErr     bool
+		expectedCalls int
+	}
+
+	testcases := []testcase{
+		{
+			name:          "retries until success",
+			failures:      2,
+			policy:        Policy{MaxRetries: 2, Backoff: time.Millisecond},
+			expectedCalls: 3,
+		},
+		{
+			name:          "gives up after max retries",
+			failures:      5,
+			policy:        Policy{MaxRetries: 1, Backoff: time.Millisecond},
+			expectErr:     true,
+			expectedCalls: 2,
+		},
+		{
+			name:          "no retries by default",
+			failures:      1,
+			expectErr:     true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "per attempt timeout",
+			delay:         200 * time.Millisecond,
+			policy:        Policy{Timeout: 20 * time.Millisecond, MaxRetries: 1},
+			expectErr:     true,
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// a timed out attempt may still be running when the next one arrives
+				if int(atomic.AddInt32(&calls, 1)) <= tc.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					fmt.Fprint(w, `{"code":1,"message":"internal error"}`)
+					return
+				}
+				select {
+				case <-time.After(tc.delay):
+				case <-r.Context().Done():
+				}
+				fmt.Fprint(w, `{"name":"foobar"}`)
+			}))
+			defer ts.Close()
+
+			c := NewClient(ts.URL, "", "", nil, WithPolicy(OpGetContact, tc.policy), WithDefaultPolicy(Policy{MaxRetries: 10}))
+			_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("Unexpected error result (%#v)", err)
+			}
+			if int(atomic.LoadInt32(&calls)) != tc.expectedCalls {
+				t.Fatalf("Actual calls (%d) did not match expected (%d)", atomic.LoadInt32(&calls), tc.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestUnit_PolicyDoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":1013,"message":"not found"}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil, WithDefaultPolicy(Policy{MaxRetries: 3}))
+	_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	grErr := &GetResponseError{}
+	if !errors.As(err, &grErr) || calls != 1 {
+		t.Fatalf("Expected a single failed call, got (%#v) after %d calls", err, calls)
+	}
+}
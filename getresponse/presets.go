@@ -0,0 +1,137 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ContactOrigin is how a contact was added, the Origin of a Contact
+type ContactOrigin string
+
+// Contact origins
+const (
+	OriginAPI         ContactOrigin = "api"
+	OriginImport      ContactOrigin = "import"
+	OriginWebform     ContactOrigin = "webform"
+	OriginPanel       ContactOrigin = "panel"
+	OriginLandingPage ContactOrigin = "landing_page"
+	OriginLeads       ContactOrigin = "leads"
+	OriginSale        ContactOrigin = "sale"
+	OriginForward     ContactOrigin = "forward"
+	OriginSurvey      ContactOrigin = "survey"
+	OriginCopy        ContactOrigin = "copy"
+)
+
+// Subscriber types of a contact search
+const (
+	subscribersRemoved     = "removed"
+	subscribersUndelivered = "undelivered"
+)
+
+const (
+	presetPerPage     = 100
+	contactTimeLayout = "2006-01-02T15:04:05-0700"
+)
+
+// ContactsCreatedVia returns the query of the contacts added through origin in the last days, for
+// ContactsClient.List, Count or ExportCSV:
+//
+//	loc, err := getresponse.AccountLocation(ctx, client)
+//	...
+//	res, err := client.Contacts().List(ctx, getresponse.ContactsCreatedVia(getresponse.OriginAPI, 7, loc))
+//
+// Days are counted in loc, UTC when nil, from the start of the day, the precision of the API's date filters. The API
+// filters on the days of the account's time zone.
+func ContactsCreatedVia(origin ContactOrigin, days int, loc *time.Location) *GetContactsRequest {
+	return &GetContactsRequest{
+		QueryHash: TimeRange{From: presetSince(days, loc)}.In(loc).SetHash(map[string]string{"origin": string(origin)}, "createdOn"),
+		Sort:      []SortTerm{Descending(string(FieldCreatedOn))},
+	}
+}
+
+// presetSince returns the start of the day days ago in loc, UTC when nil, today for 0
+func presetSince(days int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := time.Now().In(loc).AddDate(0, 0, -days).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// searchContactsRequest is the body of an unsaved contact search
+type searchContactsRequest struct {
+	SubscribersType      []string                `json:"subscribersType"`
+	SectionLogicOperator string                  `json:"sectionLogicOperator"`
+	Section              []searchContactsSection `json:"section"`
+}
+
+type searchContactsSection struct {
+	CampaignIDsList  []string          `json:"campaignIdsList"`
+	LogicOperator    string            `json:"logicOperator"`
+	SubscriberCycle  []string          `json:"subscriberCycle"`
+	SubscriptionDate string            `json:"subscriptionDate"`
+	Conditions       []json.RawMessage `json:"conditions"`
+}
+
+// searchRecent returns the contacts of the subscriber type changed in the last days. Searches have no date filter
+// on unsubscriptions or bounces, so contacts are filtered on changedOn, which these set; contacts without one are
+// kept.
+func (g *getResponseClient) searchRecent(ctx context.Context, subscribersType string, days int, campaignIDs []string) ([]Contact, error) {
+	ctx = withOperation(ctx, OpSearchContacts)
+
+	if len(campaignIDs) == 0 {
+		byName, err := g.listCampaignNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, campaigns := range byName {
+			for _, c := range campaigns {
+				campaignIDs = append(campaignIDs, c.CampaignID)
+			}
+		}
+		sort.Strings(campaignIDs)
+	}
+	body, err := json.Marshal(searchContactsRequest{
+		SubscribersType:      []string{subscribersType},
+		SectionLogicOperator: "or",
+		Section: []searchContactsSection{{
+			CampaignIDsList:  campaignIDs,
+			LogicOperator:    "and",
+			SubscriberCycle:  []string{"receiving_autoresponder", "not_receiving_autoresponder"},
+			SubscriptionDate: "all_time",
+			Conditions:       []json.RawMessage{},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	since := presetSince(days, time.UTC)
+	var contacts []Contact
+	query := url.Values{}
+	query.Set("perPage", strconv.Itoa(presetPerPage))
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		res, _, err := doJSON[[]Contact](ctx, g, http.MethodPost, "/v3/search-contacts/contacts", query, body)
+		if err != nil {
+			return nil, &PageError{Page: int32(page), Err: err}
+		}
+		for _, c := range res {
+			if c.ChangedOn != nil {
+				t, err := time.Parse(contactTimeLayout, *c.ChangedOn)
+				if err == nil && t.Before(since) {
+					continue
+				}
+			}
+			contacts = append(contacts, c)
+		}
+		if len(res) < presetPerPage {
+			return contacts, nil
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUnit_ContactsCreatedVia(t *testing.T) {
+	req := ContactsCreatedVia(OriginAPI, 7, nil)
+	from := time.Now().UTC().AddDate(0, 0, -7).Format("2006-01-02")
+	if req.QueryHash["origin"] != "api" || req.QueryHash["createdOn][from"] != from {
+		t.Fatalf("Unexpected query (%v)", req.QueryHash)
+	}
+
+	// at any time at least one of these is on another day than UTC
+	for _, loc := range []*time.Location{time.FixedZone("UTC+14", 14*3600), time.FixedZone("UTC-12", -12*3600)} {
+		req = ContactsCreatedVia(OriginAPI, 7, loc)
+		from = time.Now().In(loc).AddDate(0, 0, -7).Format("2006-01-02")
+		if req.QueryHash["createdOn][from"] != from {
+			t.Fatalf("Actual from (%s) did not match expected (%s) in %s", req.QueryHash["createdOn][from"], from, loc)
+		}
+	}
+}
+
+func TestUnit_RecentlyUnsubscribed(t *testing.T) {
+	recent := time.Now().Add(-time.Hour).Format(contactTimeLayout)
+	old := time.Now().AddDate(0, 0, -30).Format(contactTimeLayout)
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/campaigns":
+			fmt.Fprint(w, `[{"campaignId":"b","name":"B"},{"campaignId":"a","name":"A"}]`)
+		case "/v3/search-contacts/contacts":
+			body := searchContactsRequest{}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil || fmt.Sprint(body.SubscribersType) != "[removed]" || fmt.Sprint(body.Section[0].CampaignIDsList) != "[a b]" {
+				t.Errorf("Unexpected search (%#v, %#v)", body, err)
+			}
+			fmt.Fprintf(w, `[{"contactId":"1","changedOn":"%s"},{"contactId":"2","changedOn":"%s"},{"contactId":"3"}]`, recent, old)
+		default:
+			t.Errorf("Unexpected request (%s)", r.URL.Path)
+		}
+	})
+	defer ts.Close()
+	c = c.With(WithoutThrottling(), WithReadOnly(), WithDryRun(true))
+
+	contacts, err := c.Contacts().RecentlyUnsubscribed(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(contacts) != 2 || *contacts[0].ContactID != "1" || *contacts[1].ContactID != "3" {
+		t.Fatalf("Unexpected contacts (%#v)", contacts)
+	}
+}
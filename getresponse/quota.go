@@ -0,0 +1,158 @@
+package getresponse
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// QuotaStatus is the request budget reported by the rate limit headers of the most recent response
+type QuotaStatus struct {
+	Known      bool // false until a response with rate limit headers was received
+	Limit      int
+	Remaining  int
+	ResetAt    time.Time // when Remaining goes back to Limit
+	ObservedAt time.Time
+}
+
+// Available is what is left of the budget at now, the full limit once the window was reset
+func (q QuotaStatus) Available(now time.Time) int {
+	if !q.Known {
+		return 0
+	}
+	if !q.ResetAt.IsZero() && !now.Before(q.ResetAt) {
+		return q.Limit
+	}
+	return q.Remaining
+}
+
+// quotaTracker keeps the latest QuotaStatus of a client
+type quotaTracker struct {
+	mu     sync.Mutex
+	status QuotaStatus
+}
+
+func (q *quotaTracker) observe(h http.Header) {
+	remaining, ok := rateLimitInt(h, rateLimitRemainingKey)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	status := QuotaStatus{Known: true, Remaining: remaining, ObservedAt: now}
+	status.Limit, _ = rateLimitInt(h, rateLimitLimitKey)
+	if reset, ok := parseRateLimitReset(headerValue(h, rateLimitResetKey)); ok {
+		status.ResetAt = now.Add(reset)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.status = status
+}
+
+func (q *quotaTracker) get() QuotaStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.status
+}
+
+func (g *getResponseClient) QuotaStatus() QuotaStatus {
+	return g.quota.get()
+}
+
+// BudgetTracker keeps a client's QuotaStatus fresh in the background and lets batch jobs reserve part of the budget
+// before they start. It is safe for concurrent use.
+type BudgetTracker struct {
+	c        Client
+	interval time.Duration
+
+	mu       sync.Mutex
+	reserved int
+	window   time.Time // ResetAt of the window the reservations were made in
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// StartBudgetTracker pings the API whenever no response was seen for interval, so the quota status never gets older
+// than that. Each ping costs one request. Stop, or closing the client, ends the background goroutine.
+func StartBudgetTracker(c Client, interval time.Duration) *BudgetTracker {
+	b := &BudgetTracker{c: c, interval: interval, stop: make(chan struct{}), done: make(chan struct{})}
+	go b.run()
+	if g, ok := c.(*getResponseClient); ok {
+		g.lifecycle.whenClosed(b.Stop)
+	}
+	return b
+}
+
+func (b *BudgetTracker) run() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	b.refresh()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			b.refresh()
+		}
+	}
+}
+
+func (b *BudgetTracker) refresh() {
+	q := b.c.QuotaStatus()
+	if q.Known && time.Since(q.ObservedAt) < b.interval {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), b.interval)
+	defer cancel()
+	b.c.Ping(ctx)
+}
+
+// Stop ends the background refreshes. Closing the client stops it as well.
+func (b *BudgetTracker) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stop)
+	})
+	<-b.done
+}
+
+// Status returns the latest quota status
+func (b *BudgetTracker) Status() QuotaStatus {
+	return b.c.QuotaStatus()
+}
+
+// Available is the budget left after the reservations made in the current window
+func (b *BudgetTracker) Available() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.available(b.c.QuotaStatus())
+}
+
+// Reserve sets n requests of the current window aside for a batch job and reports whether the budget allows it.
+// Reservations are dropped when the window resets.
+func (b *BudgetTracker) Reserve(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q := b.c.QuotaStatus()
+	if b.available(q) < n {
+		return false
+	}
+	b.reserved += n
+	return true
+}
+
+func (b *BudgetTracker) available(q QuotaStatus) int {
+	if !q.ResetAt.Equal(b.window) && !time.Now().Before(b.window) {
+		b.window = q.ResetAt
+		b.reserved = 0
+	}
+	available := q.Available(time.Now()) - b.reserved
+	if available < 0 {
+		return 0
+	}
+	return available
+}
@@ -0,0 +1,57 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_QuotaStatus(t *testing.T) {
+	var pings int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/accounts" {
+			atomic.AddInt32(&pings, 1)
+		}
+		w.Header().Set(XRateLimitLimitHeader, "30000")
+		w.Header().Set(XRateLimitRemainingHeader, "100")
+		w.Header().Set(XRateLimitResetHeader, "600 seconds")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil, WithoutThrottling())
+	if c.QuotaStatus().Known {
+		t.Fatalf("Expected an unknown status before any request, got (%#v)", c.QuotaStatus())
+	}
+
+	b := StartBudgetTracker(c, time.Hour)
+	defer b.Stop()
+	deadline := time.Now().Add(time.Second)
+	for !b.Status().Known && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	q := b.Status()
+	if !q.Known || q.Limit != 30000 || q.Remaining != 100 || q.ResetAt.Sub(q.ObservedAt) != 10*time.Minute {
+		t.Fatalf("Unexpected quota status (%#v)", q)
+	}
+	if q.Available(q.ResetAt) != 30000 {
+		t.Fatalf("Expected the full limit once the window resets, got %d", q.Available(q.ResetAt))
+	}
+
+	if !b.Reserve(60) || b.Reserve(60) || b.Available() != 40 {
+		t.Fatalf("Unexpected reservations, %d available", b.Available())
+	}
+
+	_, err := c.Campaigns().Get(context.Background(), &GetCampaignRequest{ID: "c"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if atomic.LoadInt32(&pings) != 1 {
+		t.Fatalf("Expected a single ping, got %d", pings)
+	}
+}
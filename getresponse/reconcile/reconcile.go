@@ -0,0 +1,313 @@
+// Package reconcile provisions campaigns, custom fields, tags and from fields from a declarative spec, so account
+// configuration can be kept as code. Apply is idempotent: resources are matched by name, missing ones are created and
+// differences in existing ones are reported as drift.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+const listPerPage = 100
+
+// Kinds of resources
+const (
+	KindCampaign    = "campaign"
+	KindCustomField = "custom field"
+	KindTag         = "tag"
+	KindFromField   = "from field"
+)
+
+// Spec is the desired configuration of an account. Resources not listed are left alone.
+type Spec struct {
+	Campaigns    []CampaignSpec    `json:"campaigns,omitempty"`
+	CustomFields []CustomFieldSpec `json:"customFields,omitempty"`
+	Tags         []TagSpec         `json:"tags,omitempty"`
+	FromFields   []FromFieldSpec   `json:"fromFields,omitempty"`
+}
+
+// CampaignSpec describes a campaign (list)
+type CampaignSpec struct {
+	Name         string `json:"name"`
+	LanguageCode string `json:"languageCode,omitempty"`
+	// LocalizedConfirmation makes created campaigns confirm subscriptions with the default confirmation subject and
+	// body of LanguageCode, see getresponse.CampaignsClient.LocalizeConfirmation
+	LocalizedConfirmation bool `json:"localizedConfirmation,omitempty"`
+}
+
+// CustomFieldSpec describes a custom field
+type CustomFieldSpec struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Hidden bool     `json:"hidden,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// TagSpec describes a tag
+type TagSpec struct {
+	Name string `json:"name"`
+}
+
+// FromFieldSpec describes a from field
+type FromFieldSpec struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Drift is a difference between the spec and the account
+type Drift struct {
+	Kind    string
+	Name    string
+	Missing bool   // the resource does not exist
+	Field   string // the attribute that differs, if the resource exists
+	Want    string
+	Have    string
+}
+
+func (d Drift) String() string {
+	if d.Missing {
+		return fmt.Sprintf("%s %q is missing", d.Kind, d.Name)
+	}
+	return fmt.Sprintf("%s %q: %s is %q, want %q", d.Kind, d.Name, d.Field, d.Have, d.Want)
+}
+
+// Report lists the drift found and the resources created to fix it
+type Report struct {
+	Drift   []Drift
+	Created []Drift
+}
+
+// InSync reports whether the account matched the spec
+func (r *Report) InSync() bool {
+	return len(r.Drift) == 0
+}
+
+// Options controls Apply
+type Options struct {
+	// DryRun only reports drift without creating anything
+	DryRun bool
+}
+
+// Apply reconciles the account with the spec. Missing resources are created unless DryRun is set. Attribute drift of
+// existing resources is only reported, since GetResponse does not allow changing e.g. a custom field's type.
+func Apply(ctx context.Context, c getresponse.Client, spec Spec, opts Options) (*Report, error) {
+	r := &Report{}
+	steps := []func(context.Context, getresponse.Client, Spec, Options, *Report) error{
+		applyCampaigns,
+		applyCustomFields,
+		applyTags,
+		applyFromFields,
+	}
+	for _, step := range steps {
+		err := step(ctx, c, spec, opts, r)
+		if err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (r *Report) missing(kind, name string, create func() error, opts Options) error {
+	d := Drift{Kind: kind, Name: name, Missing: true}
+	r.Drift = append(r.Drift, d)
+	if opts.DryRun {
+		return nil
+	}
+	err := create()
+	if err != nil {
+		return fmt.Errorf("reconcile: could not create %s %q: %w", kind, name, err)
+	}
+	r.Created = append(r.Created, d)
+	return nil
+}
+
+func (r *Report) compare(kind, name, field, want, have string) {
+	if want != have {
+		r.Drift = append(r.Drift, Drift{Kind: kind, Name: name, Field: field, Want: want, Have: have})
+	}
+}
+
+func applyCampaigns(ctx context.Context, c getresponse.Client, spec Spec, opts Options, r *Report) error {
+	if len(spec.Campaigns) == 0 {
+		return nil
+	}
+	existing := map[string]getresponse.Campaign{}
+	err := forEachPage(func(page int32) (int, error) {
+		res, err := c.Campaigns().List(ctx, &getresponse.GetCampaignsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		for _, campaign := range res.Campaigns {
+			existing[campaign.Name] = campaign
+		}
+		return len(res.Campaigns), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range spec.Campaigns {
+		s := s
+		have, ok := existing[s.Name]
+		if !ok {
+			err = r.missing(KindCampaign, s.Name, func() error {
+				req := &getresponse.CreateCampaignRequest{Name: s.Name}
+				if s.LanguageCode != "" {
+					req.LanguageCode = &s.LanguageCode
+				}
+				created, err := c.Campaigns().Create(ctx, req)
+				if err != nil || !s.LocalizedConfirmation || s.LanguageCode == "" {
+					return err
+				}
+				_, err = c.Campaigns().LocalizeConfirmation(ctx, created.CampaignID, s.LanguageCode)
+				return err
+			}, opts)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if s.LanguageCode != "" && have.LanguageCode != nil {
+			r.compare(KindCampaign, s.Name, "languageCode", s.LanguageCode, *have.LanguageCode)
+		}
+	}
+	return nil
+}
+
+func applyCustomFields(ctx context.Context, c getresponse.Client, spec Spec, opts Options, r *Report) error {
+	if len(spec.CustomFields) == 0 {
+		return nil
+	}
+	existing := map[string]getresponse.CustomFieldDefinition{}
+	err := forEachPage(func(page int32) (int, error) {
+		res, err := c.CustomFields().List(ctx, &getresponse.GetCustomFieldsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		for _, f := range res.CustomFields {
+			existing[f.Name] = f
+		}
+		return len(res.CustomFields), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range spec.CustomFields {
+		s := s
+		have, ok := existing[s.Name]
+		if !ok {
+			err = r.missing(KindCustomField, s.Name, func() error {
+				values := s.Values
+				if values == nil {
+					values = []string{}
+				}
+				_, err := c.CustomFields().Create(ctx, &getresponse.CreateCustomFieldRequest{Name: s.Name, Type: s.Type, Hidden: s.Hidden, Values: values})
+				return err
+			}, opts)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		r.compare(KindCustomField, s.Name, "type", s.Type, have.Type)
+		r.compare(KindCustomField, s.Name, "hidden", fmt.Sprint(s.Hidden), have.Hidden)
+		r.compare(KindCustomField, s.Name, "values", joinSorted(s.Values), joinSorted(have.Values))
+	}
+	return nil
+}
+
+func applyTags(ctx context.Context, c getresponse.Client, spec Spec, opts Options, r *Report) error {
+	if len(spec.Tags) == 0 {
+		return nil
+	}
+	existing := map[string]bool{}
+	err := forEachPage(func(page int32) (int, error) {
+		res, err := c.Tags().List(ctx, &getresponse.GetTagsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		for _, t := range res.Tags {
+			existing[t.Name] = true
+		}
+		return len(res.Tags), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range spec.Tags {
+		s := s
+		if existing[s.Name] {
+			continue
+		}
+		err = r.missing(KindTag, s.Name, func() error {
+			_, err := c.Tags().Create(ctx, &getresponse.CreateTagRequest{Name: s.Name})
+			return err
+		}, opts)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyFromFields(ctx context.Context, c getresponse.Client, spec Spec, opts Options, r *Report) error {
+	if len(spec.FromFields) == 0 {
+		return nil
+	}
+	existing := map[string]getresponse.FromField{}
+	err := forEachPage(func(page int32) (int, error) {
+		res, err := c.FromFields().List(ctx, &getresponse.GetFromFieldsRequest{Page: page, PerPage: listPerPage})
+		if err != nil {
+			return 0, err
+		}
+		for _, f := range res.FromFields {
+			existing[f.Name] = f
+		}
+		return len(res.FromFields), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range spec.FromFields {
+		s := s
+		have, ok := existing[s.Name]
+		if !ok {
+			err = r.missing(KindFromField, s.Name, func() error {
+				_, err := c.FromFields().Create(ctx, &getresponse.CreateFromFieldRequest{Name: s.Name, Email: s.Email})
+				return err
+			}, opts)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		r.compare(KindFromField, s.Name, "email", s.Email, have.Email)
+	}
+	return nil
+}
+
+// forEachPage calls fetch with increasing page numbers until a page comes back short
+func forEachPage(fetch func(page int32) (int, error)) error {
+	for page := int32(1); ; page++ {
+		n, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if n < listPerPage {
+			return nil
+		}
+	}
+}
+
+func joinSorted(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
@@ -0,0 +1,97 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_Apply(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			body, _ := ioutil.ReadAll(r.Body)
+			calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/v3/campaigns":
+			fmt.Fprint(w, `[{"campaignId":"c1","name":"newsletter","languageCode":"EN"}]`)
+		case "/v3/custom-fields":
+			fmt.Fprint(w, `[{"customFieldId":"f1","name":"plan","type":"single_select","hidden":"false","values":["pro","free"]}]`)
+		case "/v3/tags":
+			fmt.Fprint(w, `[{"tagId":"t1","name":"vip"}]`)
+		case "/v3/from-fields":
+			fmt.Fprint(w, `[{"fromFieldId":"ff1","name":"Support","email":"help@example.com"}]`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := getresponse.NewClient(ts.URL, "", "", nil)
+	spec := Spec{
+		Campaigns: []CampaignSpec{{Name: "newsletter", LanguageCode: "PL"}, {Name: "onboarding"}},
+		CustomFields: []CustomFieldSpec{
+			{Name: "plan", Type: "single_select", Values: []string{"free", "pro"}},
+			{Name: "company", Type: "text"},
+		},
+		Tags:       []TagSpec{{Name: "vip"}, {Name: "churned"}},
+		FromFields: []FromFieldSpec{{Name: "Support", Email: "support@example.com"}},
+	}
+
+	report, err := Apply(context.Background(), client, spec, Options{DryRun: true})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("Dry run must not write, got (%v)", calls)
+	}
+	expectedDrift := []string{
+		`campaign "newsletter": languageCode is "EN", want "PL"`,
+		`campaign "onboarding" is missing`,
+		`custom field "company" is missing`,
+		`tag "churned" is missing`,
+		`from field "Support": email is "help@example.com", want "support@example.com"`,
+	}
+	if len(report.Drift) != len(expectedDrift) {
+		t.Fatalf("Actual drift (%v) did not match expected (%v)", report.Drift, expectedDrift)
+	}
+	for i := range expectedDrift {
+		if report.Drift[i].String() != expectedDrift[i] {
+			t.Errorf("Actual drift (%s) did not match expected (%s)", report.Drift[i], expectedDrift[i])
+		}
+	}
+	if report.InSync() || len(report.Created) != 0 {
+		t.Fatalf("Unexpected report (%#v)", report)
+	}
+
+	report, err = Apply(context.Background(), client, spec, Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(report.Created) != 3 {
+		t.Fatalf("Unexpected created resources (%v)", report.Created)
+	}
+	expectedCalls := []string{
+		`POST /v3/campaigns {"name":"onboarding"}`,
+		`POST /v3/custom-fields {"name":"company","type":"text","hidden":false,"values":[]}`,
+		`POST /v3/tags {"name":"churned"}`,
+	}
+	sort.Strings(calls)
+	if len(calls) != len(expectedCalls) {
+		t.Fatalf("Actual calls (%v) did not match expected (%v)", calls, expectedCalls)
+	}
+	for i := range expectedCalls {
+		if calls[i] != expectedCalls[i] {
+			t.Errorf("Actual call (%s) did not match expected (%s)", calls[i], expectedCalls[i])
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+)
+
+// ErrHookPanicked is matched by the errors of calls where a user provided hook panicked
+var ErrHookPanicked = errors.New("hook panicked")
+
+// HookPanicError is returned instead of a panic raised by a hook, see WithPanicRecovery
+type HookPanicError struct {
+	Hook  string // e.g. "audit", "debug" or "middleware"
+	Value interface{}
+	Stack []byte
+}
+
+func (e *HookPanicError) Error() string {
+	return fmt.Sprintf("%s hook panicked: %v", e.Hook, e.Value)
+}
+
+func (e *HookPanicError) Is(target error) bool {
+	return target == ErrHookPanicked
+}
+
+// WithPanicRecovery turns panics raised by the hooks given to the client (audit, debug, cancel, unknown fields and
+// link decoration hooks, request signers, middlewares and progress callbacks) into *HookPanicError errors returned
+// by the call, so a faulty logging hook fails one call rather than the process. A hook run after a write, such as the
+// audit hook, fails a call whose write was made. See ContextWithPanicRecovery to recover single calls.
+func WithPanicRecovery() Option {
+	return func(g *getResponseClient) {
+		g.panicRecovery = true
+	}
+}
+
+type panicRecoveryKey struct{}
+
+// ContextWithPanicRecovery recovers the panics of hooks during calls made with the returned context, as
+// WithPanicRecovery does for every call
+func ContextWithPanicRecovery(ctx context.Context) context.Context {
+	return context.WithValue(ctx, panicRecoveryKey{}, true)
+}
+
+func (g *getResponseClient) recoversPanics(ctx context.Context) bool {
+	return g.panicRecovery || (ctx != nil && ctx.Value(panicRecoveryKey{}) != nil)
+}
+
+// callHook runs a user provided hook, returning its panic as an error when panics are recovered
+func (g *getResponseClient) callHook(ctx context.Context, hook string, f func()) (err error) {
+	if !g.recoversPanics(ctx) {
+		f()
+		return nil
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = &HookPanicError{Hook: hook, Value: v, Stack: debug.Stack()}
+		}
+	}()
+	f()
+	return nil
+}
+
+// recoverMiddlewares is the outermost middleware, turning panics raised further down the chain into errors
+func (g *getResponseClient) recoverMiddlewares(next http.RoundTripper) http.RoundTripper {
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var resp *http.Response
+		var err error
+		pErr := g.callHook(req.Context(), "middleware", func() {
+			resp, err = next.RoundTrip(req)
+		})
+		if pErr != nil {
+			return nil, pErr
+		}
+		return resp, err
+	})
+}
@@ -0,0 +1,80 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestUnit_PanicRecovery(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"tagId":"t1"}`))
+	}))
+	defer ts.Close()
+
+	panicking := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			panic("middleware bug")
+		})
+	}
+
+	type testcase struct {
+		name         string
+		opts         []Option
+		ctx          context.Context
+		expectedHook string
+	}
+	tests := []testcase{
+		{
+			name:         "audit",
+			opts:         []Option{WithPanicRecovery(), WithAuditHook(func(context.Context, AuditEntry) { panic("audit bug") })},
+			ctx:          context.Background(),
+			expectedHook: "audit",
+		},
+		{
+			name:         "middleware",
+			opts:         []Option{WithPanicRecovery(), WithMiddleware(panicking)},
+			ctx:          context.Background(),
+			expectedHook: "middleware",
+		},
+		{
+			name:         "per call",
+			opts:         []Option{WithDebugHook(func(context.Context, DebugRequest) { panic("debug bug") })},
+			ctx:          ContextWithPanicRecovery(context.Background()),
+			expectedHook: "debug",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]Option{WithoutThrottling(), WithDefaultPolicy(Policy{MaxRetries: 2})}, tc.opts...)
+			c := NewClient(ts.URL, "", "", nil, opts...)
+			atomic.StoreInt32(&requests, 0)
+
+			_, err := c.Tags().Create(tc.ctx, &CreateTagRequest{Name: "vip"})
+			pErr := &HookPanicError{}
+			if !errors.As(err, &pErr) || !errors.Is(err, ErrHookPanicked) {
+				t.Fatalf("Actual error (%#v) did not match expected (%v)", err, ErrHookPanicked)
+			}
+			if pErr.Hook != tc.expectedHook || !strings.Contains(string(pErr.Stack), "recover_test.go") {
+				t.Errorf("Actual hook (%v) did not match expected (%v), stack:\n%s", pErr.Hook, tc.expectedHook, pErr.Stack)
+			}
+			if n := atomic.LoadInt32(&requests); n > 1 {
+				t.Errorf("Expected a panicking hook not to be retried (%d requests)", n)
+			}
+		})
+	}
+
+	c := NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithAuditHook(func(context.Context, AuditEntry) { panic("audit bug") }))
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected the panic to be left alone without recovery")
+		}
+	}()
+	c.Tags().Create(context.Background(), &CreateTagRequest{Name: "vip"})
+}
@@ -0,0 +1,53 @@
+package getresponse
+
+import (
+	"net"
+	"regexp"
+)
+
+const redactedValue = "[REDACTED]"
+
+var (
+	piiJSONFieldRegexp  = regexp.MustCompile(`"(name|email|ipAddress)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	emailRegexp         = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	ipv4Regexp          = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6CandidateRegexp = regexp.MustCompile(`[0-9A-Fa-f]*:[0-9A-Fa-f:.]*:[0-9A-Fa-f.]*`)
+)
+
+// Redactor masks personal data in text before it ends up in logs, debug dumps or error messages
+type Redactor func(s string) string
+
+// RedactPII is the default Redactor. It masks email addresses and IP addresses anywhere in the text, and the values
+// of JSON name, email and ipAddress fields.
+func RedactPII(s string) string {
+	s = piiJSONFieldRegexp.ReplaceAllString(s, `"$1"$2"`+redactedValue+`"`)
+	s = emailRegexp.ReplaceAllString(s, redactedValue)
+	s = ipv4Regexp.ReplaceAllString(s, redactedValue)
+	return ipv6CandidateRegexp.ReplaceAllStringFunc(s, func(m string) string {
+		if ip := net.ParseIP(m); ip != nil {
+			return redactedValue
+		}
+		return m
+	})
+}
+
+// WithRedactor replaces the default RedactPII redactor
+func WithRedactor(r Redactor) Option {
+	return func(g *getResponseClient) {
+		g.redact = r
+	}
+}
+
+// WithoutRedaction lets personal data through to logs and error messages. Only use it where that is allowed.
+func WithoutRedaction() Option {
+	return func(g *getResponseClient) {
+		g.redact = nil
+	}
+}
+
+func (g *getResponseClient) redactString(s string) string {
+	if g.redact == nil {
+		return s
+	}
+	return g.redact(s)
+}
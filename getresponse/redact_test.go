@@ -0,0 +1,77 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUnit_RedactPII(t *testing.T) {
+
+	type testcase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testcases := []testcase{
+		{
+			name:     "json body",
+			input:    `{"name":"John \"JJ\" Smith","email":"jsmith@example.com","ipAddress":"127.0.0.1","dayOfCycle":5}`,
+			expected: `{"name":"[REDACTED]","email":"[REDACTED]","ipAddress":"[REDACTED]","dayOfCycle":5}`,
+		},
+		{
+			name:     "free text",
+			input:    "Contact jsmith@example.com from 10.0.0.12 or 2001:db8::ff00:42:8329 already exists",
+			expected: "Contact [REDACTED] from [REDACTED] or [REDACTED] already exists",
+		},
+		{
+			name:     "nothing to redact",
+			input:    "GET /v3/contacts/foo: 404",
+			expected: "GET /v3/contacts/foo: 404",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := RedactPII(tc.input)
+			if actual != tc.expected {
+				t.Fatalf("Actual (%s) did not match expected (%s)", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUnit_RedactErrorsAndLogs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"code":1008,"message":"Contact jsmith@example.com already added"}`)
+	}))
+	defer ts.Close()
+	request := &CreateContactRequest{Email: "jsmith@example.com", Name: makeStringPtr("John Smith"), Campaign: Campaign{CampaignID: "123"}}
+
+	err := NewClient(ts.URL, "", "", nil).CreateContact(context.Background(), request)
+	if err == nil || strings.Contains(err.Error(), "jsmith@example.com") {
+		t.Fatalf("Expected the email to be redacted from the error, got (%v)", err)
+	}
+
+	err = NewClient(ts.URL, "", "", nil, WithoutRedaction()).CreateContact(context.Background(), request)
+	if err == nil || !strings.Contains(err.Error(), "jsmith@example.com") {
+		t.Fatalf("Expected the email to be kept in the error, got (%v)", err)
+	}
+
+	logs := &bytes.Buffer{}
+	c := NewClient(ts.URL, "", "", nil, WithDryRun(true), WithLogger(log.New(logs, "", 0)))
+	err = c.CreateContact(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if strings.Contains(logs.String(), "jsmith@example.com") || strings.Contains(logs.String(), "John Smith") {
+		t.Fatalf("Expected personal data to be redacted from logs, got (%s)", logs.String())
+	}
+}
@@ -0,0 +1,50 @@
+package getresponse
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Region is the base URL of a GetResponse API endpoint, to be used with WithRegion. A custom URL can be converted
+// to a Region as well.
+type Region string
+
+// Standard endpoints, described @ https://apidocs.getresponse.com/v3/case-study/getresponse-max
+const (
+	RegionGetResponse Region = "https://api.getresponse.com"
+	// RegionMAXPoland and RegionMAXUS serve GetResponse MAX (360) accounts, which also need their domain passed to
+	// NewClient, including custom MAX domains
+	RegionMAXPoland Region = "https://api3.getresponse360.pl"
+	RegionMAXUS     Region = "https://api3.getresponse360.com"
+)
+
+var (
+	ErrMissingDomain = errors.New("GetResponse MAX endpoints require the account domain (X-Domain header)")
+	ErrInvalidAPIURL = errors.New("API URL must be an absolute http(s) URL")
+)
+
+// WithRegion sends requests to the region's endpoint instead of the URL passed to NewClient
+func WithRegion(r Region) Option {
+	return func(g *getResponseClient) {
+		g.apiUrl = string(r)
+	}
+}
+
+// validateEndpoint checks the configured API URL and domain. Requests fail with the error, since NewClient cannot
+// return one.
+func validateEndpoint(apiUrl, domain string) error {
+	u, err := url.Parse(apiUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidAPIURL
+	}
+	if domain == "" && isMAXHost(u.Hostname()) {
+		return ErrMissingDomain
+	}
+	return nil
+}
+
+func isMAXHost(host string) bool {
+	host = strings.ToLower(host)
+	return strings.HasSuffix(host, ".getresponse360.pl") || strings.HasSuffix(host, ".getresponse360.com")
+}
@@ -0,0 +1,68 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUnit_Region(t *testing.T) {
+
+	type testcase struct {
+		name        string
+		apiURL      string
+		domain      string
+		opts        []Option
+		expectedURL string
+		expectedErr error
+	}
+
+	testcases := []testcase{
+		{
+			name:        "default endpoint",
+			opts:        []Option{WithRegion(RegionGetResponse)},
+			expectedURL: "https://api.getresponse.com",
+		},
+		{
+			name:        "MAX endpoint with domain",
+			domain:      "example.com",
+			opts:        []Option{WithRegion(RegionMAXPoland)},
+			expectedURL: "https://api3.getresponse360.pl",
+		},
+		{
+			name:        "MAX endpoint without domain",
+			opts:        []Option{WithRegion(RegionMAXUS)},
+			expectedErr: ErrMissingDomain,
+		},
+		{
+			name:        "MAX URL passed to NewClient",
+			apiURL:      "https://API3.getresponse360.com",
+			expectedErr: ErrMissingDomain,
+		},
+		{
+			name:        "invalid URL",
+			apiURL:      "api.getresponse.com",
+			expectedErr: ErrInvalidAPIURL,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClient(tc.apiURL, "", tc.domain, nil, tc.opts...)
+			g := c.(*getResponseClient)
+			if !errors.Is(g.configErr, tc.expectedErr) {
+				t.Fatalf("Actual error (%#v) did not match expected (%#v)", g.configErr, tc.expectedErr)
+			}
+			if tc.expectedErr != nil {
+				_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("Expected requests to fail with (%#v), got (%#v)", tc.expectedErr, err)
+				}
+				return
+			}
+			if g.apiUrl != tc.expectedURL {
+				t.Fatalf("Actual URL (%s) did not match expected (%s)", g.apiUrl, tc.expectedURL)
+			}
+		})
+	}
+}
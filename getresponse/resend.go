@@ -0,0 +1,104 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const activitiesPerPage = 100
+
+// ErrNoNonOpeners is returned by ResendToNonOpeners when every contact the newsletter was sent to opened it
+var ErrNoNonOpeners = errors.New("every contact opened the newsletter")
+
+// messageActivity is an entry of /v3/newsletters/{id}/activities
+type messageActivity struct {
+	Activity  string `json:"activity"`
+	ContactID string `json:"contactId"`
+	Contact   struct {
+		ContactID string `json:"contactId"`
+	} `json:"contact"`
+}
+
+// nonOpeners lists the contacts the newsletter was sent to that neither opened it nor clicked a link of it, in the
+// order they were sent to
+func (g *getResponseClient) nonOpeners(ctx context.Context, newsletterID string) ([]string, error) {
+	sent, err := g.newsletterActivityContacts(ctx, newsletterID, "send")
+	if err != nil {
+		return nil, err
+	}
+	opened := map[string]bool{}
+	// a click is an open where images were blocked
+	for _, activity := range []string{"open", "click"} {
+		ids, err := g.newsletterActivityContacts(ctx, newsletterID, activity)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			opened[id] = true
+		}
+	}
+
+	var ret []string
+	for _, id := range sent {
+		if !opened[id] {
+			opened[id] = true
+			ret = append(ret, id)
+		}
+	}
+	return ret, nil
+}
+
+func (g *getResponseClient) newsletterActivityContacts(ctx context.Context, newsletterID, activity string) ([]string, error) {
+	ctx = withOperation(ctx, OpGetNewsletterActivities)
+
+	path, err := resourcePath("newsletters", newsletterID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for page := int32(1); ; page++ {
+		query := listQuery(map[string]string{"activity": activity}, nil, nil, nil, page, activitiesPerPage)
+		activities, _, err := doGet[[]messageActivity](ctx, g, path+"/activities", query)
+		if err != nil {
+			return nil, &PageError{Page: page, Err: err}
+		}
+		for _, a := range activities {
+			if a.Activity != "" && a.Activity != activity {
+				continue
+			}
+			id := a.ContactID
+			if id == "" {
+				id = a.Contact.ContactID
+			}
+			if id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if len(activities) < activitiesPerPage {
+			return ids, nil
+		}
+	}
+}
+
+func (g *getResponseClient) resendToNonOpeners(ctx context.Context, newsletterID string, request *CreateNewsletterRequest) (*Newsletter, error) {
+	s := request.SendSettings
+	if len(s.SelectedCampaigns) > 0 || len(s.SelectedSegments) > 0 || len(s.SelectedContacts) > 0 {
+		return nil, &ValidationError{Field: "sendSettings", Message: "selects the non-openers, campaigns, segments and contacts must be left empty"}
+	}
+	ids, err := g.nonOpeners(ctx, newsletterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("newsletter %s: %w", newsletterID, ErrNoNonOpeners)
+	}
+
+	req := *request
+	req.SendSettings.SelectedContacts = ids
+	err = req.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return g.createNewsletter(ctx, &req)
+}
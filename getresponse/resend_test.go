@@ -0,0 +1,69 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUnit_ResendToNonOpeners(t *testing.T) {
+	activities := map[string]string{
+		"send":  `[{"activity":"send","contactId":"a"},{"activity":"send","contactId":"b"},{"activity":"send","contact":{"contactId":"c"}},{"activity":"send","contactId":"d"}]`,
+		"open":  `[{"activity":"open","contactId":"b"},{"activity":"open","contactId":"b"}]`,
+		"click": `[{"activity":"click","contact":{"contactId":"d"}}]`,
+	}
+	var created CreateNewsletterRequest
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v3/newsletters/n1/activities":
+			fmt.Fprint(w, activities[r.URL.Query().Get("query[activity]")])
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/newsletters":
+			err := json.NewDecoder(r.Body).Decode(&created)
+			if err != nil {
+				t.Errorf("Unexpected error occurred (%#v)", err)
+			}
+			fmt.Fprint(w, `{"newsletterId":"n2"}`)
+		default:
+			t.Errorf("Unexpected request (%s %s)", r.Method, r.URL)
+		}
+	})
+	defer ts.Close()
+
+	request := &CreateNewsletterRequest{
+		Subject:      "Did you miss this?",
+		Campaign:     Campaign{CampaignID: "c1"},
+		FromField:    FromFieldRef{FromFieldID: "f1"},
+		Content:      NewsletterContent{Plain: "..."},
+		SendSettings: SendSettings{ExcludedCampaigns: []string{"c2"}},
+	}
+	n, err := c.Newsletters().ResendToNonOpeners(context.Background(), "n1", request)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if n.NewsletterID != "n2" {
+		t.Errorf("Actual newsletter (%v) did not match expected (%v)", n.NewsletterID, "n2")
+	}
+	expected := SendSettings{SelectedContacts: []string{"a", "c"}, ExcludedCampaigns: []string{"c2"}}
+	if !reflect.DeepEqual(created.SendSettings, expected) {
+		t.Errorf("Actual send settings (%#v) did not match expected (%#v)", created.SendSettings, expected)
+	}
+	if request.SendSettings.SelectedContacts != nil {
+		t.Errorf("Expected the request not to be modified (%v)", request.SendSettings.SelectedContacts)
+	}
+
+	activities["open"] = `[{"contactId":"a"},{"contactId":"b"},{"contactId":"c"}]`
+	_, err = c.Newsletters().ResendToNonOpeners(context.Background(), "n1", request)
+	if !errors.Is(err, ErrNoNonOpeners) {
+		t.Errorf("Actual error (%v) did not match expected (%v)", err, ErrNoNonOpeners)
+	}
+
+	request.SendSettings.SelectedCampaigns = []string{"c1"}
+	_, err = c.Newsletters().ResendToNonOpeners(context.Background(), "n1", request)
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Expected a validation error, got (%#v)", err)
+	}
+}
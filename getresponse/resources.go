@@ -0,0 +1,515 @@
+package getresponse
+
+import (
+	"context"
+	"io"
+)
+
+// ContactsClient groups the calls on contacts, see Client.Contacts
+type ContactsClient interface {
+	// Create - https://apidocs.getresponse.com/v3/resources/contacts#contacts.create
+	Create(ctx context.Context, request *CreateContactRequest) error
+
+	// CreateAndWait creates the contact and polls List until it has been added, since contact creation
+	// is asynchronous. Returns ErrContactPendingConfirmation if the campaign requires double opt-in.
+	CreateAndWait(ctx context.Context, request *CreateContactRequest, opts *WaitOptions) (*Contact, error)
+
+	// GetConfirmation reports whether the contact confirmed its subscription to a double opt-in campaign
+	GetConfirmation(ctx context.Context, email, campaignID string) (ConfirmationStatus, error)
+
+	// List - https://apidocs.getresponse.com/v3/resources/contacts#contacts.get.all
+	List(ctx context.Context, request *GetContactsRequest) (*GetContactsResponse, error)
+
+	// Count returns how many contacts match the query, read from the TotalCount header of a one contact page.
+	// Paging, sorting and fields of the query are ignored.
+	Count(ctx context.Context, query *GetContactsRequest) (int, error)
+
+	// Get - https://apidocs.getresponse.com/v3/resources/contacts#contacts.get
+	Get(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error)
+
+	// GetByIDs looks the contacts up in parallel, see GetByIDsOptions. It returns the contacts found by id and the
+	// error of every id that could not be read, e.g. an *APIError with ErrResourceNotFound; the error map is nil when
+	// every lookup succeeded.
+	GetByIDs(ctx context.Context, ids []string, opts *GetByIDsOptions) (map[string]Contact, map[string]error)
+
+	// Update - https://apidocs.getresponse.com/v3/resources/contacts#contacts.update
+	Update(ctx context.Context, request *UpdateContactRequest) (*UpdateContactResponse, error)
+
+	// UpdateIf reads the contact, applies mutate to a copy and sends only what mutate changed. It starts over
+	// when the contact changes in between, and returns ErrUpdateConflict if it keeps changing. Custom fields are
+	// upserted one by one, so concurrent updates of other custom fields are kept.
+	UpdateIf(ctx context.Context, id string, mutate func(*Contact) error) (*Contact, error)
+
+	// MoveToCampaign moves the contact to another campaign, restarting, keeping or leaving its autoresponder
+	// cycle as opts says
+	MoveToCampaign(ctx context.Context, contactID, targetCampaignID string, opts *MoveOptions) (*Contact, error)
+
+	// CopyToCampaign adds the contact to another campaign as well, with its name, custom fields and tags. If
+	// it is already there, the tags and custom field values are merged into that contact.
+	CopyToCampaign(ctx context.Context, contactID, targetCampaignID string) error
+
+	// UpdateCustomFields - https://apidocs.getresponse.com/v3/resources/contacts#contacts.upsert.custom-fields
+	UpdateCustomFields(ctx context.Context, request *UpdateContactCustomFieldsRequest) (*UpdateContactCustomFieldsResponse, error)
+
+	// Unsubscribe unsubscribes the contact in response to a message, keeping its history in the account
+	Unsubscribe(ctx context.Context, request *UnsubscribeContactRequest) error
+
+	// Remove removes the contact from the campaign without recording an unsubscription
+	Remove(ctx context.Context, request *RemoveContactRequest) error
+
+	// RecentlyUnsubscribed returns the contacts of the campaigns, every campaign when none is given, that were
+	// unsubscribed or removed in the last days. See ContactsCreatedVia for other audit lists.
+	RecentlyUnsubscribed(ctx context.Context, days int, campaignIDs ...string) ([]Contact, error)
+
+	// RecentlyBounced returns the contacts of the campaigns, every campaign when none is given, whose address
+	// bounced in the last days
+	RecentlyBounced(ctx context.Context, days int, campaignIDs ...string) ([]Contact, error)
+
+	// ExportCSV writes every contact matching the query to w as CSV, fetching one page at a time. A failed page
+	// is returned as a *PageError telling where to resume: a run started at its Page leaves out the header row, so
+	// its output continues the failed one. Custom fields get a column each, named after the field, unless Fields leaves
+	// out customFieldValues.
+	ExportCSV(ctx context.Context, w io.Writer, query *GetContactsRequest) error
+
+	// ImportCSV creates the contacts read from CSV in the campaign, mapping extra columns to custom fields
+	ImportCSV(ctx context.Context, r io.Reader, campaignID string, fieldMapping map[string]string) (*ImportResult, error)
+}
+
+// CampaignsClient groups the calls on campaigns (lists), see Client.Campaigns
+type CampaignsClient interface {
+	// List - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.get.all
+	List(ctx context.Context, request *GetCampaignsRequest) (*GetCampaignsResponse, error)
+
+	// Get - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.get
+	Get(ctx context.Context, request *GetCampaignRequest) (*Campaign, error)
+
+	// Create - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.create
+	Create(ctx context.Context, request *CreateCampaignRequest) (*Campaign, error)
+
+	// UpdateSettings - https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.update
+	UpdateSettings(ctx context.Context, request *UpdateCampaignSettingsRequest) (*Campaign, error)
+
+	// ListSize returns the number of subscribers of the campaigns over time, for growth charts
+	ListSize(ctx context.Context, request *GetListSizeRequest) (*TimeSeries, error)
+
+	// Cycle returns the autoresponders of the campaign, to tell which messages a contact got or will get by its
+	// day of cycle
+	Cycle(ctx context.Context, campaignID string) (*AutoresponderCycle, error)
+
+	// ConfirmationContent returns the confirmation subjects and bodies of each language, e.g. "EN" or "PL", by
+	// language code
+	ConfirmationContent(ctx context.Context, languageCodes ...string) (map[string]*ConfirmationContent, error)
+
+	// LocalizeConfirmation makes the campaign confirm subscriptions with the default subject and first body of the
+	// language, see ConfirmationContent.Confirmation
+	LocalizeConfirmation(ctx context.Context, campaignID, languageCode string) (*Campaign, error)
+
+	// ResolveByName returns the id of the named campaign, so configuration can use names. The name is matched
+	// exactly, or regardless of case when no campaign has exactly that name; several such matches are an
+	// *AmbiguousCampaignError and none an *UnknownCampaignError. Campaigns are cached for 10 minutes.
+	ResolveByName(ctx context.Context, name string) (string, error)
+}
+
+// CustomFieldsClient groups the calls on custom field definitions, see Client.CustomFields
+type CustomFieldsClient interface {
+	// List - https://apidocs.getresponse.com/v3/resources/customfields#customfields.get.all
+	List(ctx context.Context, request *GetCustomFieldsRequest) (*GetCustomFieldsResponse, error)
+
+	// Create - https://apidocs.getresponse.com/v3/resources/customfields#customfields.create
+	Create(ctx context.Context, request *CreateCustomFieldRequest) (*CustomFieldDefinition, error)
+
+	// Catalog returns the client's cached catalog of custom fields, used to resolve names in requests
+	Catalog() *CustomFieldCatalog
+
+	// Ensure returns the id of the custom field named in the request, creating it if missing. An existing field
+	// is checked against the request, see EnsureCustomFieldOptions, and a *CustomFieldMismatchError is returned
+	// with its id if it differs.
+	Ensure(ctx context.Context, request *CreateCustomFieldRequest, opts *EnsureCustomFieldOptions) (string, error)
+}
+
+// TagsClient groups the calls on tags, see Client.Tags
+type TagsClient interface {
+	// List - https://apidocs.getresponse.com/v3/resources/tags#tags.get.all
+	List(ctx context.Context, request *GetTagsRequest) (*GetTagsResponse, error)
+
+	// Create - https://apidocs.getresponse.com/v3/resources/tags#tags.create
+	Create(ctx context.Context, request *CreateTagRequest) (*Tag, error)
+
+	// Catalog returns the client's cached catalog of tags, used to resolve names in requests
+	Catalog() *TagCatalog
+
+	// TagSegment adds the tags to every contact of a segment or query, one contact at a time within the client's
+	// throttling. Failures of single contacts are collected in the result rather than stopping the run.
+	TagSegment(ctx context.Context, request *TagSegmentRequest) (*TagSegmentResult, error)
+}
+
+// FromFieldsClient groups the calls on from fields, see Client.FromFields
+type FromFieldsClient interface {
+	// List - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.get.all
+	List(ctx context.Context, request *GetFromFieldsRequest) (*GetFromFieldsResponse, error)
+
+	// Create - https://apidocs.getresponse.com/v3/resources/fromfields#fromfields.create
+	Create(ctx context.Context, request *CreateFromFieldRequest) (*FromField, error)
+}
+
+// NewslettersClient groups the calls on newsletters, see Client.Newsletters
+type NewslettersClient interface {
+	// List - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.get.all
+	List(ctx context.Context, request *GetNewslettersRequest) (*GetNewslettersResponse, error)
+
+	// Create - https://apidocs.getresponse.com/v3/resources/newsletters#newsletters.create
+	Create(ctx context.Context, request *CreateNewsletterRequest) (*Newsletter, error)
+
+	// SendToSegment creates the newsletter sent to the given segments (saved contact searches) on top of
+	// the request's send settings, after checking the segments exist
+	SendToSegment(ctx context.Context, request *CreateNewsletterRequest, segmentIDs ...string) (*Newsletter, error)
+
+	// NonOpeners lists the contacts the newsletter was sent to that neither opened it nor clicked its links, from its
+	// activities. Opens are only tracked for contacts showing images, so some readers are counted as non-openers.
+	NonOpeners(ctx context.Context, newsletterID string) ([]string, error)
+
+	// ResendToNonOpeners creates the request's newsletter as a follow-up sent to the NonOpeners of newsletterID, as
+	// the web app's "resend to non-openers" does. The request must not select campaigns, segments or contacts, its
+	// exclusions still apply. ErrNoNonOpeners is returned when there is nobody to resend to.
+	ResendToNonOpeners(ctx context.Context, newsletterID string, request *CreateNewsletterRequest) (*Newsletter, error)
+
+	// WaitForSend polls the newsletter until its send finishes, reporting progress to opts.Progress. A send that ends
+	// otherwise, e.g. cancelled, returns a *NewsletterSendError. Bound the wait with ctx.
+	WaitForSend(ctx context.Context, newsletterID string, opts *SendWaitOptions) (*Newsletter, error)
+
+	// Statistics returns the totals of a newsletter, autoresponder, RSS newsletter or split test in one shape, so
+	// reports need a single path for every kind of message
+	Statistics(ctx context.Context, message MessageRef) (*MessageStatistics, error)
+}
+
+type contactsClient struct {
+	g *getResponseClient
+}
+
+func (g *getResponseClient) Contacts() ContactsClient {
+	return contactsClient{g: g}
+}
+
+func (c contactsClient) Create(ctx context.Context, request *CreateContactRequest) error {
+	return c.g.createContact(ctx, request)
+}
+
+func (c contactsClient) CreateAndWait(ctx context.Context, request *CreateContactRequest, opts *WaitOptions) (*Contact, error) {
+	return c.g.createContactAndWait(ctx, request, opts)
+}
+
+func (c contactsClient) GetConfirmation(ctx context.Context, email, campaignID string) (ConfirmationStatus, error) {
+	return c.g.getContactConfirmation(ctx, email, campaignID)
+}
+
+func (c contactsClient) List(ctx context.Context, request *GetContactsRequest) (*GetContactsResponse, error) {
+	return c.g.getContacts(ctx, request)
+}
+
+func (c contactsClient) Count(ctx context.Context, query *GetContactsRequest) (int, error) {
+	return c.g.countContacts(ctx, query)
+}
+
+func (c contactsClient) Get(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error) {
+	return c.g.getContact(ctx, request)
+}
+
+func (c contactsClient) GetByIDs(ctx context.Context, ids []string, opts *GetByIDsOptions) (map[string]Contact, map[string]error) {
+	return c.g.getContactsByIDs(ctx, ids, opts)
+}
+
+func (c contactsClient) Update(ctx context.Context, request *UpdateContactRequest) (*UpdateContactResponse, error) {
+	return c.g.updateContact(ctx, request)
+}
+
+func (c contactsClient) UpdateIf(ctx context.Context, id string, mutate func(*Contact) error) (*Contact, error) {
+	return c.g.updateContactIf(ctx, id, mutate)
+}
+
+func (c contactsClient) MoveToCampaign(ctx context.Context, contactID, targetCampaignID string, opts *MoveOptions) (*Contact, error) {
+	return c.g.moveContactToCampaign(ctx, contactID, targetCampaignID, opts)
+}
+
+func (c contactsClient) CopyToCampaign(ctx context.Context, contactID, targetCampaignID string) error {
+	return c.g.copyContactToCampaign(ctx, contactID, targetCampaignID)
+}
+
+func (c contactsClient) UpdateCustomFields(ctx context.Context, request *UpdateContactCustomFieldsRequest) (*UpdateContactCustomFieldsResponse, error) {
+	return c.g.updateContactCustomFields(ctx, request)
+}
+
+func (c contactsClient) Unsubscribe(ctx context.Context, request *UnsubscribeContactRequest) error {
+	return c.g.unsubscribeContact(ctx, request)
+}
+
+func (c contactsClient) RecentlyUnsubscribed(ctx context.Context, days int, campaignIDs ...string) ([]Contact, error) {
+	return c.g.searchRecent(ctx, subscribersRemoved, days, campaignIDs)
+}
+
+func (c contactsClient) RecentlyBounced(ctx context.Context, days int, campaignIDs ...string) ([]Contact, error) {
+	return c.g.searchRecent(ctx, subscribersUndelivered, days, campaignIDs)
+}
+
+func (c contactsClient) Remove(ctx context.Context, request *RemoveContactRequest) error {
+	return c.g.removeContact(ctx, request)
+}
+
+func (c contactsClient) ExportCSV(ctx context.Context, w io.Writer, query *GetContactsRequest) error {
+	return c.g.exportContactsCSV(ctx, w, query)
+}
+
+func (c contactsClient) ImportCSV(ctx context.Context, r io.Reader, campaignID string, fieldMapping map[string]string) (*ImportResult, error) {
+	return c.g.importContactsCSV(ctx, r, campaignID, fieldMapping)
+}
+
+type campaignsClient struct {
+	g *getResponseClient
+}
+
+func (g *getResponseClient) Campaigns() CampaignsClient {
+	return campaignsClient{g: g}
+}
+
+func (c campaignsClient) List(ctx context.Context, request *GetCampaignsRequest) (*GetCampaignsResponse, error) {
+	return c.g.getCampaigns(ctx, request)
+}
+
+func (c campaignsClient) Get(ctx context.Context, request *GetCampaignRequest) (*Campaign, error) {
+	return c.g.getCampaign(ctx, request)
+}
+
+func (c campaignsClient) Create(ctx context.Context, request *CreateCampaignRequest) (*Campaign, error) {
+	return c.g.createCampaign(ctx, request)
+}
+
+func (c campaignsClient) UpdateSettings(ctx context.Context, request *UpdateCampaignSettingsRequest) (*Campaign, error) {
+	return c.g.updateCampaignSettings(ctx, request)
+}
+
+func (c campaignsClient) ListSize(ctx context.Context, request *GetListSizeRequest) (*TimeSeries, error) {
+	return c.g.getListSize(ctx, request)
+}
+
+func (c campaignsClient) Cycle(ctx context.Context, campaignID string) (*AutoresponderCycle, error) {
+	return c.g.getCycle(ctx, campaignID)
+}
+
+func (c campaignsClient) ConfirmationContent(ctx context.Context, languageCodes ...string) (map[string]*ConfirmationContent, error) {
+	return c.g.getConfirmationContent(ctx, languageCodes)
+}
+
+func (c campaignsClient) LocalizeConfirmation(ctx context.Context, campaignID, languageCode string) (*Campaign, error) {
+	return c.g.localizeConfirmation(ctx, campaignID, languageCode)
+}
+
+func (c campaignsClient) ResolveByName(ctx context.Context, name string) (string, error) {
+	return c.g.resolveCampaignByName(ctx, name)
+}
+
+type customFieldsClient struct {
+	g *getResponseClient
+}
+
+func (g *getResponseClient) CustomFields() CustomFieldsClient {
+	return customFieldsClient{g: g}
+}
+
+func (c customFieldsClient) List(ctx context.Context, request *GetCustomFieldsRequest) (*GetCustomFieldsResponse, error) {
+	return c.g.getCustomFields(ctx, request)
+}
+
+func (c customFieldsClient) Create(ctx context.Context, request *CreateCustomFieldRequest) (*CustomFieldDefinition, error) {
+	return c.g.createCustomField(ctx, request)
+}
+
+func (c customFieldsClient) Catalog() *CustomFieldCatalog {
+	return c.g.customFieldCatalog
+}
+
+func (c customFieldsClient) Ensure(ctx context.Context, request *CreateCustomFieldRequest, opts *EnsureCustomFieldOptions) (string, error) {
+	return c.g.ensureCustomField(ctx, request, opts)
+}
+
+type tagsClient struct {
+	g *getResponseClient
+}
+
+func (g *getResponseClient) Tags() TagsClient {
+	return tagsClient{g: g}
+}
+
+func (c tagsClient) List(ctx context.Context, request *GetTagsRequest) (*GetTagsResponse, error) {
+	return c.g.getTags(ctx, request)
+}
+
+func (c tagsClient) Create(ctx context.Context, request *CreateTagRequest) (*Tag, error) {
+	return c.g.createTag(ctx, request)
+}
+
+func (c tagsClient) TagSegment(ctx context.Context, request *TagSegmentRequest) (*TagSegmentResult, error) {
+	return c.g.tagSegment(ctx, request)
+}
+
+func (c tagsClient) Catalog() *TagCatalog {
+	return c.g.tagCatalog
+}
+
+type fromFieldsClient struct {
+	g *getResponseClient
+}
+
+func (g *getResponseClient) FromFields() FromFieldsClient {
+	return fromFieldsClient{g: g}
+}
+
+func (c fromFieldsClient) List(ctx context.Context, request *GetFromFieldsRequest) (*GetFromFieldsResponse, error) {
+	return c.g.getFromFields(ctx, request)
+}
+
+func (c fromFieldsClient) Create(ctx context.Context, request *CreateFromFieldRequest) (*FromField, error) {
+	return c.g.createFromField(ctx, request)
+}
+
+type newslettersClient struct {
+	g *getResponseClient
+}
+
+func (g *getResponseClient) Newsletters() NewslettersClient {
+	return newslettersClient{g: g}
+}
+
+func (c newslettersClient) List(ctx context.Context, request *GetNewslettersRequest) (*GetNewslettersResponse, error) {
+	return c.g.getNewsletters(ctx, request)
+}
+
+func (c newslettersClient) Create(ctx context.Context, request *CreateNewsletterRequest) (*Newsletter, error) {
+	return c.g.createNewsletter(ctx, request)
+}
+
+func (c newslettersClient) SendToSegment(ctx context.Context, request *CreateNewsletterRequest, segmentIDs ...string) (*Newsletter, error) {
+	return c.g.sendNewsletterToSegment(ctx, request, segmentIDs...)
+}
+
+func (c newslettersClient) NonOpeners(ctx context.Context, newsletterID string) ([]string, error) {
+	return c.g.nonOpeners(ctx, newsletterID)
+}
+
+func (c newslettersClient) ResendToNonOpeners(ctx context.Context, newsletterID string, request *CreateNewsletterRequest) (*Newsletter, error) {
+	return c.g.resendToNonOpeners(ctx, newsletterID, request)
+}
+
+func (c newslettersClient) WaitForSend(ctx context.Context, newsletterID string, opts *SendWaitOptions) (*Newsletter, error) {
+	return c.g.waitForNewsletterSend(ctx, newsletterID, opts)
+}
+
+func (c newslettersClient) Statistics(ctx context.Context, message MessageRef) (*MessageStatistics, error) {
+	return c.g.getMessageStatistics(ctx, message)
+}
+
+func (g *getResponseClient) CreateContact(ctx context.Context, request *CreateContactRequest) error {
+	return g.createContact(ctx, request)
+}
+
+func (g *getResponseClient) CreateContactAndWait(ctx context.Context, request *CreateContactRequest, opts *WaitOptions) (*Contact, error) {
+	return g.createContactAndWait(ctx, request, opts)
+}
+
+func (g *getResponseClient) GetContactConfirmation(ctx context.Context, email, campaignID string) (ConfirmationStatus, error) {
+	return g.getContactConfirmation(ctx, email, campaignID)
+}
+
+func (g *getResponseClient) GetContacts(ctx context.Context, request *GetContactsRequest) (*GetContactsResponse, error) {
+	return g.getContacts(ctx, request)
+}
+
+func (g *getResponseClient) GetContact(ctx context.Context, request *GetContactRequest) (*GetContactResponse, error) {
+	return g.getContact(ctx, request)
+}
+
+func (g *getResponseClient) UpdateContact(ctx context.Context, request *UpdateContactRequest) (*UpdateContactResponse, error) {
+	return g.updateContact(ctx, request)
+}
+
+func (g *getResponseClient) UpdateContactIf(ctx context.Context, id string, mutate func(*Contact) error) (*Contact, error) {
+	return g.updateContactIf(ctx, id, mutate)
+}
+
+func (g *getResponseClient) MoveContactToCampaign(ctx context.Context, contactID, targetCampaignID string, opts *MoveOptions) (*Contact, error) {
+	return g.moveContactToCampaign(ctx, contactID, targetCampaignID, opts)
+}
+
+func (g *getResponseClient) CopyContactToCampaign(ctx context.Context, contactID, targetCampaignID string) error {
+	return g.copyContactToCampaign(ctx, contactID, targetCampaignID)
+}
+
+func (g *getResponseClient) UpdateContactCustomFields(ctx context.Context, request *UpdateContactCustomFieldsRequest) (*UpdateContactCustomFieldsResponse, error) {
+	return g.updateContactCustomFields(ctx, request)
+}
+
+func (g *getResponseClient) UnsubscribeContact(ctx context.Context, request *UnsubscribeContactRequest) error {
+	return g.unsubscribeContact(ctx, request)
+}
+
+func (g *getResponseClient) RemoveContact(ctx context.Context, request *RemoveContactRequest) error {
+	return g.removeContact(ctx, request)
+}
+
+func (g *getResponseClient) ExportContactsCSV(ctx context.Context, w io.Writer, query *GetContactsRequest) error {
+	return g.exportContactsCSV(ctx, w, query)
+}
+
+func (g *getResponseClient) ImportContactsCSV(ctx context.Context, r io.Reader, campaignID string, fieldMapping map[string]string) (*ImportResult, error) {
+	return g.importContactsCSV(ctx, r, campaignID, fieldMapping)
+}
+
+func (g *getResponseClient) GetCampaigns(ctx context.Context, request *GetCampaignsRequest) (*GetCampaignsResponse, error) {
+	return g.getCampaigns(ctx, request)
+}
+
+func (g *getResponseClient) GetCampaign(ctx context.Context, request *GetCampaignRequest) (*Campaign, error) {
+	return g.getCampaign(ctx, request)
+}
+
+func (g *getResponseClient) CreateCampaign(ctx context.Context, request *CreateCampaignRequest) (*Campaign, error) {
+	return g.createCampaign(ctx, request)
+}
+
+func (g *getResponseClient) UpdateCampaignSettings(ctx context.Context, request *UpdateCampaignSettingsRequest) (*Campaign, error) {
+	return g.updateCampaignSettings(ctx, request)
+}
+
+func (g *getResponseClient) GetCustomFields(ctx context.Context, request *GetCustomFieldsRequest) (*GetCustomFieldsResponse, error) {
+	return g.getCustomFields(ctx, request)
+}
+
+func (g *getResponseClient) CreateCustomField(ctx context.Context, request *CreateCustomFieldRequest) (*CustomFieldDefinition, error) {
+	return g.createCustomField(ctx, request)
+}
+
+func (g *getResponseClient) GetTags(ctx context.Context, request *GetTagsRequest) (*GetTagsResponse, error) {
+	return g.getTags(ctx, request)
+}
+
+func (g *getResponseClient) CreateTag(ctx context.Context, request *CreateTagRequest) (*Tag, error) {
+	return g.createTag(ctx, request)
+}
+
+func (g *getResponseClient) GetFromFields(ctx context.Context, request *GetFromFieldsRequest) (*GetFromFieldsResponse, error) {
+	return g.getFromFields(ctx, request)
+}
+
+func (g *getResponseClient) CreateFromField(ctx context.Context, request *CreateFromFieldRequest) (*FromField, error) {
+	return g.createFromField(ctx, request)
+}
+
+func (g *getResponseClient) GetNewsletters(ctx context.Context, request *GetNewslettersRequest) (*GetNewslettersResponse, error) {
+	return g.getNewsletters(ctx, request)
+}
+
+func (g *getResponseClient) CreateNewsletter(ctx context.Context, request *CreateNewsletterRequest) (*Newsletter, error) {
+	return g.createNewsletter(ctx, request)
+}
+
+func (g *getResponseClient) SendNewsletterToSegment(ctx context.Context, request *CreateNewsletterRequest, segmentIDs ...string) (*Newsletter, error) {
+	return g.sendNewsletterToSegment(ctx, request, segmentIDs...)
+}
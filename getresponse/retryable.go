@@ -0,0 +1,96 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Temporary reports whether err was caused by a condition expected to clear by itself: an exhausted quota, a
+// temporary block of the account, a server error or a network failure. The same request may succeed later.
+func Temporary(err error) bool {
+	c, ok := classify(err)
+	return ok && c != failurePermanent
+}
+
+// Retryable reports whether a retry loop with backoff should send the same request again. It is Temporary minus the
+// failures retrying makes worse: requests from a temporarily blocked account (code 1016) only extend the block.
+// Errors of cancelled or expired contexts, local validation and permanent API errors are not retryable.
+func Retryable(err error) bool {
+	c, ok := classify(err)
+	return ok && c == failureRetryable
+}
+
+type failureClass int
+
+const (
+	failurePermanent failureClass = iota
+	failureRetryable
+	failureBlocked // temporary, but retrying extends it
+)
+
+// codeClasses classifies the GetResponse error codes that decide retryability on their own, whatever the HTTP status
+var codeClasses = map[int]failureClass{
+	ErrInternalError:         failureRetryable,
+	ErrExternalError:         failureRetryable,
+	ErrequestQuotaReached:    failureRetryable,
+	ErrTemporarilyBlocked:    failureBlocked,
+	ErrPermanentlyBlocked:    failurePermanent,
+	ErrIPBlocked:             failurePermanent,
+	ErrAuthenticationFailure: failurePermanent,
+}
+
+// classify returns the failure class of err, false for a nil error
+func classify(err error) (failureClass, bool) {
+	if err == nil {
+		return 0, false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return failurePermanent, true
+	}
+
+	grErr := &GetResponseError{}
+	if errors.As(err, &grErr) {
+		if c, ok := codeClasses[grErr.ErrorCode]; ok {
+			return c, true
+		}
+	}
+	if errors.Is(err, ErrThrottled) || errors.Is(err, ErrBodyStalled) {
+		return failureRetryable, true
+	}
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) && apiErr.HTTPStatus != 0 {
+		return statusClass(apiErr.HTTPStatus), true
+	}
+	raw := &GetResponseErrorRaw{}
+	if errors.As(err, &raw) && raw.HTTPStatus != 0 {
+		return statusClass(raw.HTTPStatus), true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return failureRetryable, true
+	}
+	return failurePermanent, true
+}
+
+func statusClass(status int) failureClass {
+	if status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+		return failureRetryable
+	}
+	return failurePermanent
+}
+
+// responseRetryable reports whether an error response is worth another attempt, judging by its error code if it has
+// one of codeClasses and by its status otherwise
+func responseRetryable(status int, body []byte) bool {
+	grErr := &GetResponseError{}
+	if json.Unmarshal(body, grErr) == nil {
+		if c, ok := codeClasses[grErr.ErrorCode]; ok {
+			return c == failureRetryable
+		}
+	}
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
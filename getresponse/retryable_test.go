@@ -0,0 +1,73 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestUnit_Retryable(t *testing.T) {
+	apiErr := func(status, code int) error {
+		return &APIError{HTTPStatus: status, ErrorCode: code, Err: &GetResponseError{HTTPStatus: status, ErrorCode: code}}
+	}
+
+	type testcase struct {
+		name      string
+		err       error
+		retryable bool
+		temporary bool
+	}
+	for _, tc := range []testcase{
+		{name: "nil", err: nil},
+		{name: "quota reached", err: &APIError{HTTPStatus: 429, Err: &ThrottledError{Err: &GetResponseError{ErrorCode: ErrequestQuotaReached}}}, retryable: true, temporary: true},
+		{name: "temporarily blocked", err: apiErr(http.StatusForbidden, ErrTemporarilyBlocked), temporary: true},
+		{name: "temporarily blocked with 429", err: &APIError{HTTPStatus: 429, Err: &ThrottledError{Err: &GetResponseError{ErrorCode: ErrTemporarilyBlocked}}}, temporary: true},
+		{name: "permanently blocked", err: apiErr(http.StatusForbidden, ErrPermanentlyBlocked)},
+		{name: "ip blocked", err: apiErr(http.StatusForbidden, ErrIPBlocked)},
+		{name: "authentication failure", err: apiErr(http.StatusUnauthorized, ErrAuthenticationFailure)},
+		{name: "not found", err: apiErr(http.StatusNotFound, ErrResourceNotFound)},
+		{name: "validation", err: apiErr(http.StatusBadRequest, ErrValidationError)},
+		{name: "internal error", err: apiErr(http.StatusInternalServerError, ErrInternalError), retryable: true, temporary: true},
+		{name: "bad gateway without body", err: &APIError{HTTPStatus: 502, Err: &GetResponseErrorRaw{HTTPStatus: 502, Err: errors.New("EOF")}}, retryable: true, temporary: true},
+		{name: "held back by throttler", err: &ThrottledError{}, retryable: true, temporary: true},
+		{name: "stalled body", err: &APIError{Err: &stalledError{}}, retryable: true, temporary: true},
+		{name: "network", err: &APIError{Err: &url.Error{Op: "Get", URL: "/v3/contacts", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}}, retryable: true, temporary: true},
+		{name: "cancelled", err: &APIError{Err: &url.Error{Op: "Get", URL: "/v3/contacts", Err: context.Canceled}}},
+		{name: "deadline", err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded)},
+		{name: "local validation", err: &ValidationError{Field: "id", Message: "is invalid"}},
+		{name: "permission", err: &PermissionError{Permission: "contacts.delete"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := Retryable(tc.err); actual != tc.retryable {
+				t.Fatalf("Actual Retryable (%t) did not match expected (%t)", actual, tc.retryable)
+			}
+			if actual := Temporary(tc.err); actual != tc.temporary {
+				t.Fatalf("Actual Temporary (%t) did not match expected (%t)", actual, tc.temporary)
+			}
+		})
+	}
+}
+
+func TestUnit_NoRetryWhenBlocked(t *testing.T) {
+	var requests int32
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"httpStatus":429,"code":1016,"message":"temporarily blocked"}`)
+	}))
+	defer ts.Close()
+	c = NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithDefaultPolicy(Policy{MaxRetries: 3}))
+
+	_, err := c.GetContacts(context.Background(), &GetContactsRequest{Page: 1, PerPage: 10})
+	if err == nil || Retryable(err) || !Temporary(err) {
+		t.Fatalf("Expected a temporary, non-retryable error, got (%#v)", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("Actual requests (%d) did not match expected (%d)", n, 1)
+	}
+}
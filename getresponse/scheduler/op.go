@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+// Kind is the write an Op performs
+type Kind string
+
+const (
+	KindCreate             Kind = "create"
+	KindUpdate             Kind = "update"
+	KindUpdateCustomFields Kind = "updateCustomFields"
+	KindRemove             Kind = "remove"
+)
+
+// Op is a queued contact write. It only holds JSON friendly data, so stores can persist it as is.
+type Op struct {
+	// ID identifies the operation in the store, it must be unique within the queue
+	ID   string `json:"id"`
+	Kind Kind   `json:"kind"`
+	// ContactID is the contact updated or removed
+	ContactID string `json:"contactId,omitempty"`
+	// Create is the contact to create, for KindCreate
+	Create *getresponse.CreateContactRequest `json:"create,omitempty"`
+	// Data and Fields are the new data and optional field mask, for KindUpdate
+	Data   *getresponse.Contact `json:"data,omitempty"`
+	Fields []string             `json:"fields,omitempty"`
+	// CustomFields are the values to upsert, for KindUpdateCustomFields
+	CustomFields []getresponse.CustomField `json:"customFields,omitempty"`
+}
+
+// Validate checks the operation has what its kind needs
+func (o Op) Validate() error {
+	if o.ID == "" {
+		return &getresponse.ValidationError{Field: "ID", Message: "is required"}
+	}
+	switch o.Kind {
+	case KindCreate:
+		if o.Create == nil {
+			return &getresponse.ValidationError{Field: "Create", Message: "is required for creates"}
+		}
+		return o.Create.Validate()
+	case KindUpdate:
+		if o.Data == nil {
+			return &getresponse.ValidationError{Field: "Data", Message: "is required for updates"}
+		}
+	case KindUpdateCustomFields:
+		if len(o.CustomFields) == 0 {
+			return &getresponse.ValidationError{Field: "CustomFields", Message: "is required for custom field updates"}
+		}
+	case KindRemove:
+	default:
+		return &getresponse.ValidationError{Field: "Kind", Message: fmt.Sprintf("%q is not supported", o.Kind)}
+	}
+	if o.ContactID == "" {
+		return &getresponse.ValidationError{Field: "ContactID", Message: "is required"}
+	}
+	return nil
+}
+
+// Apply performs the operation through c
+func (o Op) Apply(ctx context.Context, c getresponse.Client) error {
+	err := o.Validate()
+	if err != nil {
+		return err
+	}
+
+	switch o.Kind {
+	case KindCreate:
+		return c.Contacts().Create(ctx, o.Create)
+	case KindUpdate:
+		_, err = c.Contacts().Update(ctx, &getresponse.UpdateContactRequest{ID: o.ContactID, NewData: *o.Data, Fields: o.Fields})
+	case KindUpdateCustomFields:
+		_, err = c.Contacts().UpdateCustomFields(ctx, &getresponse.UpdateContactCustomFieldsRequest{ID: o.ContactID, CustomFields: o.CustomFields})
+	case KindRemove:
+		err = c.Contacts().Remove(ctx, &getresponse.RemoveContactRequest{ID: o.ContactID})
+	}
+	return err
+}
+
+// Retriable reports whether a failed operation may succeed when tried again: network errors, 429 and 5xx responses.
+// Validation errors and other API errors are permanent.
+func Retriable(err error) bool {
+	if errors.Is(err, getresponse.ErrThrottled) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	apiErr := &getresponse.APIError{}
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.HTTPStatus == 0 || apiErr.HTTPStatus == 429 || apiErr.HTTPStatus >= 500
+}
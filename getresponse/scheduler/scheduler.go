@@ -0,0 +1,208 @@
+// Package scheduler runs queued contact writes through a client at the pace the API allows. Progress is persisted in
+// a Store, so a run interrupted by a crash or a deploy can be resumed where it stopped.
+//
+//	store, err := scheduler.OpenFileStore("nightly-sync.jsonl")
+//	...
+//	s := &scheduler.Scheduler{Client: client, Store: store, Concurrency: 4}
+//	err = s.Enqueue(ops...)
+//	...
+//	res, err := s.Run(ctx) // run again after a crash to resume
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = time.Second
+	minThrottleWait    = time.Second
+)
+
+// Scheduler executes the operations of its store. The client's throttler spaces requests out; on top of that the
+// scheduler waits out 429 responses and retries failures that may be temporary, see Retriable.
+type Scheduler struct {
+	Client getresponse.Client
+	Store  Store
+	// Concurrency is the number of operations run at once, 1 when not set
+	Concurrency int
+	// MaxAttempts is how often an operation failing with a retriable error is tried, 3 when not set. Waiting out a
+	// 429 does not count as an attempt.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled for every further one. 1s when not set.
+	Backoff time.Duration
+	// Reserve is the part of the request budget left to other traffic: when the client's QuotaStatus shows no more
+	// than Reserve requests left, operations wait for the window to reset
+	Reserve int
+	// OnResult, when set, is called after each operation with its outcome. It may be called concurrently.
+	OnResult func(op Op, err error)
+}
+
+// Result reports what a run did
+type Result struct {
+	Succeeded int
+	Failed    []OpError
+}
+
+// OpError is an operation that failed for good
+type OpError struct {
+	Op  Op
+	Err error
+}
+
+func (e OpError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Op.Kind, e.Op.ID, e.Err.Error())
+}
+
+func (e OpError) Unwrap() error {
+	return e.Err
+}
+
+// Enqueue validates the operations and adds them to the store
+func (s *Scheduler) Enqueue(ops ...Op) error {
+	for _, o := range ops {
+		err := o.Validate()
+		if err != nil {
+			return fmt.Errorf("scheduler: operation %q: %w", o.ID, err)
+		}
+	}
+	return s.Store.Add(ops)
+}
+
+// Run executes the pending operations until all have an outcome or ctx is done. Operations interrupted by ctx stay
+// pending for the next run. A store failure stops the run and is returned.
+func (s *Scheduler) Run(ctx context.Context) (*Result, error) {
+	pending, err := s.Store.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	workers := s.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan Op)
+	res := &Result{}
+	var mu sync.Mutex
+	var storeErr error
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for o := range queue {
+				err := s.execute(ctx, o)
+				if ctx.Err() != nil {
+					// interrupted, leave it pending
+					continue
+				}
+				if s.OnResult != nil {
+					s.OnResult(o, err)
+				}
+				sErr := s.Store.Done(o.ID, err)
+
+				mu.Lock()
+				if sErr != nil && storeErr == nil {
+					storeErr = sErr
+					cancel()
+				}
+				if err != nil {
+					res.Failed = append(res.Failed, OpError{Op: o, Err: err})
+				} else {
+					res.Succeeded++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, o := range pending {
+		select {
+		case queue <- o:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if storeErr != nil {
+		return res, storeErr
+	}
+	return res, ctx.Err()
+}
+
+// execute runs an operation, retrying it as the policy allows
+func (s *Scheduler) execute(ctx context.Context, o Op) error {
+	maxAttempts := s.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = defaultMaxAttempts
+	}
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	for attempt := 1; ; {
+		err := s.waitForBudget(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = o.Apply(ctx, s.Client)
+		if err == nil || !Retriable(err) {
+			return err
+		}
+
+		var wait time.Duration
+		tErr := &getresponse.ThrottledError{}
+		if errors.As(err, &tErr) {
+			wait = tErr.RetryAfter
+			if wait < minThrottleWait {
+				wait = minThrottleWait
+			}
+		} else {
+			if attempt >= maxAttempts {
+				return err
+			}
+			wait = backoff << uint(attempt-1)
+			attempt++
+		}
+
+		err = sleep(ctx, wait)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// waitForBudget holds the operation back while the quota is down to the reserve
+func (s *Scheduler) waitForBudget(ctx context.Context) error {
+	q := s.Client.QuotaStatus()
+	now := time.Now()
+	if !q.Known || q.ResetAt.IsZero() || q.Available(now) > s.Reserve {
+		return nil
+	}
+	return sleep(ctx, q.ResetAt.Sub(now))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_SchedulerResume(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	unavailable := 1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+		switch r.URL.Path {
+		case "/v3/contacts/bad":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code":1000,"message":"Validation error"}`)
+		case "/v3/contacts/flaky":
+			if unavailable > 0 {
+				unavailable--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, `{"code":1,"message":"Internal error"}`)
+				return
+			}
+			fmt.Fprint(w, `{"contactId":"flaky"}`)
+		case "/v3/contacts":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+	client := getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling())
+
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	name := "New"
+	ops := []Op{
+		{ID: "1", Kind: KindCreate, Create: &getresponse.CreateContactRequest{Email: "new@example.com", Campaign: getresponse.Campaign{CampaignID: "c"}}},
+		{ID: "2", Kind: KindUpdate, ContactID: "flaky", Data: &getresponse.Contact{Name: &name}},
+		{ID: "3", Kind: KindUpdate, ContactID: "bad", Data: &getresponse.Contact{Name: &name}},
+		{ID: "4", Kind: KindRemove, ContactID: "gone"},
+	}
+	s := &Scheduler{Client: client, Store: store, Backoff: time.Millisecond}
+	err = s.Enqueue(ops...)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = s.Enqueue(Op{ID: "5", Kind: KindRemove})
+	vErr := &getresponse.ValidationError{}
+	if !errors.As(err, &vErr) || vErr.Field != "ContactID" {
+		t.Fatalf("Expected a validation error, got (%#v)", err)
+	}
+
+	// stop after the first operation, as a crash would
+	ctx, cancel := context.WithCancel(context.Background())
+	s.OnResult = func(Op, error) { cancel() }
+	res, err := s.Run(ctx)
+	if !errors.Is(err, context.Canceled) || res.Succeeded != 1 {
+		t.Fatalf("Unexpected first run (%#v, %#v)", res, err)
+	}
+	store.Close()
+
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	defer store.Close()
+	pending, _ := store.Pending()
+	if len(pending) != 3 || pending[0].ID != "2" || *pending[0].Data.Name != "New" {
+		t.Fatalf("Unexpected pending operations after restart (%#v)", pending)
+	}
+
+	s = &Scheduler{Client: client, Store: store, Backoff: time.Millisecond, Concurrency: 2}
+	res, err = s.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if res.Succeeded != 2 || len(res.Failed) != 1 || res.Failed[0].Op.ID != "3" {
+		t.Fatalf("Unexpected second run (%#v)", res)
+	}
+	pending, _ = store.Pending()
+	if len(pending) != 0 {
+		t.Fatalf("Expected an empty queue, got (%#v)", pending)
+	}
+
+	expected := []string{
+		`DELETE /v3/contacts/gone `,
+		`POST /v3/contacts {"email":"new@example.com","campaign":{"campaignId":"c"}}`,
+		`POST /v3/contacts/bad {"name":"New"}`,
+		`POST /v3/contacts/flaky {"name":"New"}`,
+		`POST /v3/contacts/flaky {"name":"New"}`,
+	}
+	sort.Strings(calls)
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Fatalf("Actual calls (%q) did not match expected (%q)", calls, expected)
+	}
+}
+
+func TestUnit_FileStorePartialWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.jsonl")
+	store, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	store.Add([]Op{{ID: "a", Kind: KindRemove, ContactID: "a"}})
+	store.Close()
+
+	// a crash while writing b leaves half a line
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	f.WriteString(`{"op":{"id":"b","kind":"rem`)
+	f.Close()
+
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = store.Add([]Op{{ID: "c", Kind: KindRemove, ContactID: "c"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	store.Close()
+
+	store, err = OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	defer store.Close()
+	pending, _ := store.Pending()
+	if len(pending) != 2 || pending[0].ID != "a" || pending[1].ID != "c" {
+		t.Fatalf("Actual pending operations (%#v) did not match expected (a, c)", pending)
+	}
+}
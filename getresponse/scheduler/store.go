@@ -0,0 +1,175 @@
+package scheduler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Store persists the queue and the progress of a Scheduler. Implementations must be safe for concurrent use.
+type Store interface {
+	// Add persists queued operations
+	Add(ops []Op) error
+	// Pending returns the operations without an outcome, in the order they were added
+	Pending() ([]Op, error)
+	// Done records the outcome of an operation, err is nil on success. Done operations are not returned by Pending
+	// again.
+	Done(id string, err error) error
+}
+
+// MemoryStore keeps the queue in memory, for jobs that do not need to survive a crash
+type MemoryStore struct {
+	mu   sync.Mutex
+	ops  []Op
+	done map[string]bool
+}
+
+func (m *MemoryStore) Add(ops []Op) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ops = append(m.ops, ops...)
+	return nil
+}
+
+func (m *MemoryStore) Pending() ([]Op, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pending []Op
+	for _, o := range m.ops {
+		if !m.done[o.ID] {
+			pending = append(pending, o)
+		}
+	}
+	return pending, nil
+}
+
+func (m *MemoryStore) Done(id string, err error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.done == nil {
+		m.done = map[string]bool{}
+	}
+	m.done[id] = true
+	return nil
+}
+
+// FileStore keeps the queue in an append-only file of JSON lines, synced after every write, so a run interrupted by a
+// crash resumes with the operations that had no outcome yet
+type FileStore struct {
+	mu   sync.Mutex
+	f    *os.File
+	ops  []Op
+	done map[string]bool
+}
+
+// fileRecord is a line of a FileStore: either a queued operation or the outcome of one
+type fileRecord struct {
+	Op    *Op    `json:"op,omitempty"`
+	Done  string `json:"done,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// OpenFileStore opens or creates the store at path, loading the queue it holds
+func OpenFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{f: f, done: map[string]bool{}}
+	reader := bufio.NewReader(f)
+	var complete int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("scheduler: reading %s: %w", path, err)
+		}
+		complete += int64(len(line))
+		r := fileRecord{}
+		if json.Unmarshal(line, &r) != nil {
+			continue
+		}
+		if r.Op != nil {
+			s.ops = append(s.ops, *r.Op)
+		}
+		if r.Done != "" {
+			s.done[r.Done] = true
+		}
+	}
+	// a crash can leave a partial last line, which the next record would be appended to
+	if err := f.Truncate(complete); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("scheduler: truncating %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *FileStore) Add(ops []Op) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]fileRecord, len(ops))
+	for i := range ops {
+		records[i].Op = &ops[i]
+	}
+	err := s.write(records...)
+	if err != nil {
+		return err
+	}
+	s.ops = append(s.ops, ops...)
+	return nil
+}
+
+func (s *FileStore) Pending() ([]Op, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []Op
+	for _, o := range s.ops {
+		if !s.done[o.ID] {
+			pending = append(pending, o)
+		}
+	}
+	return pending, nil
+}
+
+func (s *FileStore) Done(id string, opErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := fileRecord{Done: id}
+	if opErr != nil {
+		r.Error = opErr.Error()
+	}
+	err := s.write(r)
+	if err != nil {
+		return err
+	}
+	s.done[id] = true
+	return nil
+}
+
+// Close closes the file
+func (s *FileStore) Close() error {
+	return s.f.Close()
+}
+
+func (s *FileStore) write(records ...fileRecord) error {
+	var buf []byte
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	_, err := s.f.Write(buf)
+	if err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
@@ -0,0 +1,213 @@
+// Package scoring adjusts contact scores from activity: each activity kind is given a delta, and the deltas of the
+// activities of a contact are added to its score in one update. Activities are applied at most once, keyed by their
+// ID, so events received twice from callbacks or seen again by a poller do not count twice:
+//
+//	e := &scoring.Engine{Client: client, Rules: scoring.Rules{"open": 1, "click": 3, "unsubscribe": -10}}
+//	for ev := range events {
+//		res, err := e.Apply(ctx, scoring.FromWebhook(ev))
+//		...
+//	}
+package scoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/webhook"
+)
+
+// Activity is something a contact did that may change its score
+type Activity struct {
+	// ID identifies the activity, an activity whose ID was already applied is skipped
+	ID        string
+	Kind      string
+	ContactID string
+}
+
+// FromWebhook turns a callback into an activity of the callback's action. Callbacks carry no ID of their own, so
+// the ID is made of the action, contact, message and link: a contact is scored once per message it opened and once
+// per link it clicked, however many times it did.
+func FromWebhook(e *webhook.Event) Activity {
+	id := strings.Join([]string{string(e.Action), e.ContactID, e.MessageID, e.LinkURL, e.GoalURL}, "|")
+	return Activity{ID: id, Kind: string(e.Action), ContactID: e.ContactID}
+}
+
+// Rules gives the score delta of each activity kind, activities of other kinds are ignored
+type Rules map[string]int64
+
+// Store remembers the applied activity IDs. Implementations must be safe for concurrent use: Claim is what keeps
+// two deliveries of the same activity applied at the same time from both counting.
+type Store interface {
+	// Claim atomically reserves the activities that are neither applied nor claimed and returns their IDs. Claimed
+	// activities are then either marked applied or released.
+	Claim(ids []string) ([]string, error)
+	// Release gives back claimed activities whose update failed, so they can be applied again
+	Release(ids []string) error
+	// MarkApplied records claimed activities as applied
+	MarkApplied(ids []string) error
+}
+
+// MemoryStore remembers the applied activities in memory, for consumers that do not need to survive a restart
+type MemoryStore struct {
+	mu  sync.Mutex
+	ids map[string]bool // true once applied, false while claimed
+}
+
+func (m *MemoryStore) Claim(ids []string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ids == nil {
+		m.ids = map[string]bool{}
+	}
+	var claimed []string
+	for _, id := range ids {
+		if _, ok := m.ids[id]; !ok {
+			m.ids[id] = false
+			claimed = append(claimed, id)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *MemoryStore) Release(ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		if !m.ids[id] {
+			delete(m.ids, id)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStore) MarkApplied(ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ids == nil {
+		m.ids = map[string]bool{}
+	}
+	for _, id := range ids {
+		m.ids[id] = true
+	}
+	return nil
+}
+
+// Result tells what Apply did
+type Result struct {
+	// Scores are the new scores of the updated contacts, by contact ID
+	Scores     map[string]int64
+	Applied    int
+	Duplicates int // activities applied before
+	Ignored    int // activities without a rule, a contact or an ID
+}
+
+// Engine applies Rules to activities. Apply may be called from several goroutines, the Store claims each activity
+// for one of them.
+type Engine struct {
+	Client getresponse.Client
+	Rules  Rules
+	// Store remembers the applied activities, a MemoryStore when not set
+	Store Store
+
+	once sync.Once
+}
+
+func (e *Engine) store() Store {
+	e.once.Do(func() {
+		if e.Store == nil {
+			e.Store = &MemoryStore{}
+		}
+	})
+	return e.Store
+}
+
+// Apply adds the deltas of the activities to the scores of their contacts, one update per contact. Activities are
+// claimed in the Store before any update, so an activity being applied by another call counts as a duplicate, and
+// released when the update of their contact fails, so they can be retried by applying them again.
+// The first error stops Apply, the result then tells what was done before.
+func (e *Engine) Apply(ctx context.Context, activities ...Activity) (*Result, error) {
+	store := e.store()
+	r := &Result{Scores: map[string]int64{}}
+
+	var candidates []Activity
+	batch := map[string]bool{}
+	for _, a := range activities {
+		_, ok := e.Rules[a.Kind]
+		if !ok || a.ID == "" || a.ContactID == "" {
+			r.Ignored++
+			continue
+		}
+		if batch[a.ID] {
+			r.Duplicates++
+			continue
+		}
+		batch[a.ID] = true
+		candidates = append(candidates, a)
+	}
+	if len(candidates) == 0 {
+		return r, nil
+	}
+
+	candidateIDs := make([]string, len(candidates))
+	for i, a := range candidates {
+		candidateIDs[i] = a.ID
+	}
+	claimedIDs, err := store.Claim(candidateIDs)
+	if err != nil {
+		return r, err
+	}
+	claimed := make(map[string]bool, len(claimedIDs))
+	for _, id := range claimedIDs {
+		claimed[id] = true
+	}
+
+	var contacts []string
+	deltas := map[string]int64{}
+	ids := map[string][]string{}
+	for _, a := range candidates {
+		if !claimed[a.ID] {
+			r.Duplicates++
+			continue
+		}
+		if _, ok := ids[a.ContactID]; !ok {
+			contacts = append(contacts, a.ContactID)
+		}
+		deltas[a.ContactID] += e.Rules[a.Kind]
+		ids[a.ContactID] = append(ids[a.ContactID], a.ID)
+	}
+
+	for i, id := range contacts {
+		if deltas[id] != 0 {
+			c, err := e.Client.Contacts().UpdateIf(ctx, id, func(c *getresponse.Contact) error {
+				score := deltas[id]
+				if c.Scoring != nil {
+					score += *c.Scoring
+				}
+				c.Scoring = &score
+				return nil
+			})
+			if err != nil {
+				var unapplied []string
+				for _, id := range contacts[i:] {
+					unapplied = append(unapplied, ids[id]...)
+				}
+				if rErr := store.Release(unapplied); rErr != nil {
+					return r, fmt.Errorf("%w (releasing activities: %v)", err, rErr)
+				}
+				return r, err
+			}
+			if c.Scoring != nil {
+				r.Scores[id] = *c.Scoring
+			}
+		}
+		err := store.MarkApplied(ids[id])
+		if err != nil {
+			return r, err
+		}
+		r.Applied += len(ids[id])
+	}
+	return r, nil
+}
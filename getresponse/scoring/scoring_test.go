@@ -0,0 +1,124 @@
+package scoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+	"github.com/devimteam/go-getresponse/getresponse/webhook"
+)
+
+func TestUnit_EngineApply(t *testing.T) {
+	var mu sync.Mutex
+	scores := map[string]int64{"a": 5, "b": 0}
+	updates := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		id := r.URL.Path[len("/v3/contacts/"):]
+		if r.Method == http.MethodPost {
+			var body map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			if err != nil {
+				t.Errorf("Unexpected error occurred (%#v)", err)
+			}
+			if len(body) != 1 {
+				t.Errorf("Expected only the score to be sent (%v)", body)
+			}
+			scores[id] = int64(body["scoring"].(float64))
+			updates++
+		}
+		fmt.Fprintf(w, `{"contactId":"%s","scoring":%d}`, id, scores[id])
+	}))
+	defer ts.Close()
+
+	e := &Engine{
+		Client: getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Rules:  Rules{"open": 1, "click": 3},
+	}
+	open := FromWebhook(&webhook.Event{Action: webhook.ActionOpen, ContactID: "a", MessageID: "m1"})
+	res, err := e.Apply(context.Background(),
+		open,
+		open,
+		Activity{ID: "c1", Kind: "click", ContactID: "a"},
+		Activity{ID: "c2", Kind: "click", ContactID: "b"},
+		Activity{ID: "s1", Kind: "subscribe", ContactID: "b"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if res.Applied != 3 || res.Duplicates != 1 || res.Ignored != 1 {
+		t.Errorf("Actual counts (%+v) did not match expected (3 applied, 1 duplicate, 1 ignored)", res)
+	}
+	if res.Scores["a"] != 9 || res.Scores["b"] != 3 {
+		t.Errorf("Actual scores (%v) did not match expected (map[a:9 b:3])", res.Scores)
+	}
+	if updates != 2 {
+		t.Errorf("Actual updates (%v) did not match expected (%v)", updates, 2)
+	}
+
+	res, err = e.Apply(context.Background(), open, Activity{ID: "c1", Kind: "click", ContactID: "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if res.Applied != 0 || res.Duplicates != 2 || updates != 2 {
+		t.Errorf("Expected activities applied before to be skipped (%+v, %d updates)", res, updates)
+	}
+}
+
+func TestUnit_EngineApplyConcurrentDuplicates(t *testing.T) {
+	var updates, failures int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// keep every delivery in flight at the same time
+		time.Sleep(10 * time.Millisecond)
+		if r.Method == http.MethodPost {
+			if atomic.AddInt32(&failures, 1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code":1000,"message":"Validation error"}`)
+				return
+			}
+			atomic.AddInt32(&updates, 1)
+		}
+		fmt.Fprint(w, `{"contactId":"a","scoring":1}`)
+	}))
+	defer ts.Close()
+
+	e := &Engine{
+		Client: getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Rules:  Rules{"open": 1},
+	}
+	open := FromWebhook(&webhook.Event{Action: webhook.ActionOpen, ContactID: "a", MessageID: "m1"})
+
+	// the first update fails, its activity is released and applied by the retry
+	_, err := e.Apply(context.Background(), open)
+	if err == nil {
+		t.Fatalf("Expected the first update to fail")
+	}
+
+	var applied, duplicates int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := e.Apply(context.Background(), open)
+			if err != nil {
+				t.Errorf("Unexpected error occurred (%#v)", err)
+				return
+			}
+			atomic.AddInt32(&applied, int32(res.Applied))
+			atomic.AddInt32(&duplicates, int32(res.Duplicates))
+		}()
+	}
+	wg.Wait()
+	if applied != 1 || duplicates != 7 || updates != 1 {
+		t.Errorf("Actual counts (%d applied, %d duplicates, %d updates) did not match expected (1, 7, 1)", applied, duplicates, updates)
+	}
+}
@@ -0,0 +1,66 @@
+package getresponse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestUnit_SecretsAudit checks the API key only leaves the client in the auth header, and never shows up in dumps,
+// logs, errors or panics, even when the server echoes it back
+func TestUnit_SecretsAudit(t *testing.T) {
+	const key = "audit-api-key-4f1c"
+
+	var urls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		urls = append(urls, r.URL.String())
+		if r.Header.Get(XAuthTokenHeader) != "api-key "+key {
+			t.Errorf("Expected the key in the auth header, got (%s)", r.Header.Get(XAuthTokenHeader))
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"code":1014,"message":"Invalid token %s","context":["%s"]}`, r.Header.Get(XAuthTokenHeader), key)
+	}))
+	defer ts.Close()
+
+	dump := &bytes.Buffer{}
+	logs := &bytes.Buffer{}
+	c := NewClient(ts.URL, key, "", nil, WithDebug(dump), WithLogger(log.New(logs, "", 0)))
+
+	_, err := c.Contacts().Get(context.Background(), &GetContactRequest{ID: "foo"})
+	if err == nil {
+		t.Fatalf("Expected an error")
+	}
+
+	panicked := func() (out string) {
+		defer func() {
+			out = fmt.Sprint(recover())
+		}()
+		panic(Credentials{APIKey: key})
+	}()
+
+	outputs := map[string]string{
+		"urls":            strings.Join(urls, " "),
+		"debug dump":      dump.String(),
+		"logs":            logs.String(),
+		"error":           err.Error(),
+		"error %+v":       fmt.Sprintf("%+v", err),
+		"error %#v":       fmt.Sprintf("%#v", err),
+		"client %+v":      fmt.Sprintf("%+v", c),
+		"credentials %#v": fmt.Sprintf("%#v", Credentials{APIKey: key, AccessToken: key}),
+		"provider %#v":    fmt.Sprintf("%#v", RefreshingCredentials(nil)),
+		"panic":           panicked,
+	}
+	for name, out := range outputs {
+		if strings.Contains(out, key) {
+			t.Errorf("Expected %s not to contain the API key, got (%s)", name, out)
+		}
+	}
+	if !strings.Contains(dump.String(), "X-Auth-Token: api-key [REDACTED]") {
+		t.Fatalf("Expected the dump to show the redacted header, got (%s)", dump.String())
+	}
+}
@@ -0,0 +1,115 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned for requests started after Close
+var ErrClientClosed = errors.New("client is closed")
+
+// lifecycle tracks the requests in flight, so Close can wait for them
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	drained  chan struct{} // closed once the client is closed and no request is in flight
+	nextID   uint64
+	inFlight map[uint64]context.CancelFunc
+	onClose  []func()
+}
+
+// begin registers a request, done must be called once it returned
+func (l *lifecycle) begin(ctx context.Context) (context.Context, func(), error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrClientClosed
+	}
+	if l.inFlight == nil {
+		l.inFlight = map[uint64]context.CancelFunc{}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	l.nextID++
+	id := l.nextID
+	l.inFlight[id] = cancel
+	return ctx, func() {
+		cancel()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.inFlight, id)
+		if l.closed && len(l.inFlight) == 0 {
+			close(l.drained)
+		}
+	}, nil
+}
+
+// whenClosed calls f once the client is closed, right away when it already is
+func (l *lifecycle) whenClosed(f func()) {
+	l.mu.Lock()
+	if !l.closed {
+		l.onClose = append(l.onClose, f)
+		l.mu.Unlock()
+		return
+	}
+	l.mu.Unlock()
+	f()
+}
+
+// close stops new requests and returns the components to stop and the channel closed once the requests drained
+func (l *lifecycle) close() ([]func(), <-chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, l.drained
+	}
+	l.closed = true
+	l.drained = make(chan struct{})
+	if len(l.inFlight) == 0 {
+		close(l.drained)
+	}
+	onClose := l.onClose
+	l.onClose = nil
+	return onClose, l.drained
+}
+
+func (l *lifecycle) cancelAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, cancel := range l.inFlight {
+		cancel()
+	}
+}
+
+func (g *getResponseClient) Close(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	onClose, drained := g.lifecycle.close()
+	// components may be waiting on requests of their own, which are cancelled with the others at the deadline
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for _, f := range onClose {
+			f()
+		}
+	}()
+
+	var err error
+	for _, c := range []<-chan struct{}{stopped, drained} {
+		select {
+		case <-c:
+		case <-ctx.Done():
+			if err == nil {
+				err = ctx.Err()
+				g.lifecycle.cancelAll()
+			}
+			<-c
+		}
+	}
+	g.constructor.client.CloseIdleConnections()
+	return err
+}
@@ -0,0 +1,82 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUnit_Close(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/slow" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		received <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	type testcase struct {
+		name        string
+		timeout     time.Duration
+		release     bool
+		expectedErr error
+	}
+	tests := []testcase{
+		{name: "deadline", timeout: 50 * time.Millisecond, expectedErr: context.DeadlineExceeded},
+		{name: "drained", timeout: 5 * time.Second, release: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClient(ts.URL, "", "", nil, WithoutThrottling())
+			tracker := StartBudgetTracker(c, time.Hour)
+
+			requestErr := make(chan error, 1)
+			go func() {
+				requestErr <- c.Do(context.Background(), http.MethodGet, "/v3/slow", nil, nil, nil)
+			}()
+			<-received
+
+			closeErr := make(chan error, 1)
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+				defer cancel()
+				closeErr <- c.Close(ctx)
+			}()
+			// requests started while closing are refused
+			for {
+				_, err := c.Tags().List(context.Background(), &GetTagsRequest{})
+				if errors.Is(err, ErrClientClosed) {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			if tc.release {
+				close(release)
+			}
+
+			err := <-closeErr
+			if err != tc.expectedErr {
+				t.Fatalf("Actual error (%v) did not match expected (%v)", err, tc.expectedErr)
+			}
+			err = <-requestErr
+			if (err == nil) != (tc.expectedErr == nil) {
+				t.Fatalf("Unexpected request error (%#v)", err)
+			}
+			tracker.Stop()
+			if err := c.Close(context.Background()); err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package getresponse
+
+import (
+	"crypto/sha256"
+	"net/http"
+)
+
+// RequestSigner signs a request for proxies or gateways that require it, e.g. with an HMAC of the method, path and
+// body hash. It runs before every attempt, once all other headers are set, and may add headers or query parameters.
+// bodySHA256 is the SHA-256 of the body, of the empty body when there is none, and nil for a streamed body, which
+// cannot be hashed without reading it.
+type RequestSigner func(req *http.Request, bodySHA256 []byte) error
+
+// WithRequestSigner signs every request sent with s. A failing signer fails the attempt with a *SigningError.
+func WithRequestSigner(s RequestSigner) Option {
+	return func(g *getResponseClient) {
+		g.signer = s
+	}
+}
+
+// SigningError is returned when the RequestSigner fails
+type SigningError struct {
+	Err error
+}
+
+func (e *SigningError) Error() string {
+	return "getresponse: signing request: " + e.Err.Error()
+}
+
+func (e *SigningError) Unwrap() error {
+	return e.Err
+}
+
+// sign runs the signer on req
+func (g *getResponseClient) sign(req *http.Request, body *requestBody) error {
+	if g.signer == nil {
+		return nil
+	}
+	var err error
+	pErr := g.callHook(req.Context(), "signer", func() {
+		err = g.signer(req, body.sha256())
+	})
+	if pErr != nil {
+		return pErr
+	}
+	if err != nil {
+		return &SigningError{Err: err}
+	}
+	return nil
+}
+
+// sha256 hashes the body when it is in memory
+func (b *requestBody) sha256() []byte {
+	if b == nil {
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	}
+	if b.data == nil {
+		return nil
+	}
+	sum := sha256.Sum256(b.data)
+	return sum[:]
+}
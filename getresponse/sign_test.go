@@ -0,0 +1,80 @@
+package getresponse
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUnit_RequestSigner(t *testing.T) {
+	key := []byte("proxy-key")
+	mac := func(method, path string, bodyHash []byte) string {
+		h := hmac.New(sha256.New, key)
+		fmt.Fprintf(h, "%s\n%s\n%x", method, path, bodyHash)
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	var verified []string
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		hash := []byte(nil)
+		if r.Header.Get("X-Body-Hash") != "UNSIGNED" {
+			sum := sha256.Sum256(body)
+			hash = sum[:]
+		}
+		ok := r.Header.Get("X-Signature") == mac(r.Method, r.URL.RequestURI(), hash) && r.Header.Get(XAuthTokenHeader) != ""
+		verified = append(verified, fmt.Sprintf("%s %t", r.Method, ok))
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+	c = NewClient(ts.URL, "key", "", nil, WithoutThrottling(), WithRequestSigner(func(req *http.Request, bodySHA256 []byte) error {
+		if bodySHA256 == nil {
+			req.Header.Set("X-Body-Hash", "UNSIGNED")
+		}
+		req.Header.Set("X-Signature", mac(req.Method, req.URL.RequestURI(), bodySHA256))
+		return nil
+	}))
+
+	err := c.Do(context.Background(), http.MethodGet, "/v3/webforms", map[string][]string{"page": {"1"}}, nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = c.Do(context.Background(), http.MethodPost, "/v3/webforms", nil, map[string]string{"name": "signup"}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = c.Do(context.Background(), http.MethodPost, "/v3/imports", nil, io.MultiReader(strings.NewReader(`{}`)), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []string{"GET true", "POST true", "POST true"}
+	if fmt.Sprint(verified) != fmt.Sprint(expected) {
+		t.Fatalf("Actual verifications (%v) did not match expected (%v)", verified, expected)
+	}
+}
+
+func TestUnit_RequestSignerError(t *testing.T) {
+	requests := 0
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+	c = NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithRequestSigner(func(*http.Request, []byte) error {
+		return errors.New("key unavailable")
+	}))
+
+	_, err := c.Contacts().Get(context.Background(), &GetContactRequest{ID: "a"})
+	sErr := &SigningError{}
+	if !errors.As(err, &sErr) || requests != 0 {
+		t.Fatalf("Expected a SigningError without requests, got (%#v) after %d requests", err, requests)
+	}
+}
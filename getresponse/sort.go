@@ -0,0 +1,150 @@
+package getresponse
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SortDirection orders the results of a list call on a field
+type SortDirection string
+
+// Sort directions
+const (
+	SortAsc  SortDirection = "ASC"
+	SortDesc SortDirection = "DESC"
+)
+
+// SortTerm orders the results of a list call on a field. The terms of a request's Sort are sent in priority order,
+// the first one deciding first:
+//
+//	Sort: []getresponse.SortTerm{getresponse.Descending("createdOn"), getresponse.Ascending("email")}
+type SortTerm struct {
+	Field     string
+	Direction SortDirection // SortAsc when not set
+}
+
+// Ascending returns the term sorting on field in ascending order
+func Ascending(field string) SortTerm {
+	return SortTerm{Field: field, Direction: SortAsc}
+}
+
+// Descending returns the term sorting on field in descending order
+func Descending(field string) SortTerm {
+	return SortTerm{Field: field, Direction: SortDesc}
+}
+
+// sortOrderKey lists the sorted fields of a query in priority order. It is not a valid parameter name, so it can't
+// clash with the caller's, and encodeQuery drops it.
+const sortOrderKey = "\x00sort"
+
+// setSort adds the sort terms, followed by the entries of hash they leave out in alphabetical order since maps have
+// none
+func setSort(query url.Values, hash map[string]string, terms []SortTerm) {
+	for _, t := range terms {
+		if t.Field == "" {
+			continue
+		}
+		k := sortParam(t.Field)
+		if query.Has(k) {
+			continue
+		}
+		d := t.Direction
+		if d == "" {
+			d = SortAsc
+		}
+		query.Set(k, string(d))
+		query.Add(sortOrderKey, t.Field)
+	}
+
+	fields := make([]string, 0, len(hash))
+	for k, v := range hash {
+		if v != "" && !query.Has(sortParam(k)) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	for _, k := range fields {
+		query.Set(sortParam(k), hash[k])
+		query.Add(sortOrderKey, k)
+	}
+}
+
+func sortParam(field string) string {
+	return "sort[" + field + "]"
+}
+
+// encodeQuery encodes query like url.Values.Encode, sorted by parameter name so the same query always gives the
+// same URL, but with the sort parameters last in priority order. It is built in a single buffer, since every request
+// encodes its query.
+func encodeQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	order := query[sortOrderKey]
+	keys := make([]string, 0, len(query))
+	sorted := make([]string, len(order))
+	size := 0
+	for k, vs := range query {
+		if k == sortOrderKey {
+			continue
+		}
+		for _, v := range vs {
+			size += len(k) + len(v) + 2
+		}
+		if i := sortParamIndex(k, order); i >= 0 {
+			sorted[i] = k
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	// brackets are escaped to three bytes each
+	b.Grow(size + size/2)
+	for _, k := range append(keys, sorted...) {
+		for _, v := range query[k] {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			writeQueryEscaped(&b, k)
+			b.WriteByte('=')
+			writeQueryEscaped(&b, v)
+		}
+	}
+	return b.String()
+}
+
+// sortParamIndex returns the position in order of the field k is the sort parameter of, -1 when it is none of them
+func sortParamIndex(k string, order []string) int {
+	if len(order) == 0 || !strings.HasPrefix(k, "sort[") || !strings.HasSuffix(k, "]") {
+		return -1
+	}
+	field := k[len("sort[") : len(k)-1]
+	for i, f := range order {
+		if f == field {
+			return i
+		}
+	}
+	return -1
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// writeQueryEscaped writes s escaped as url.QueryEscape does, without allocating the escaped string
+func writeQueryEscaped(b *strings.Builder, s string) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == ' ':
+			b.WriteByte('+')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(upperHex[c>>4])
+			b.WriteByte(upperHex[c&15])
+		}
+	}
+}
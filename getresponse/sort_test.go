@@ -0,0 +1,62 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestUnit_SortOrder(t *testing.T) {
+	queries := []string{}
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `[]`)
+	})
+	defer ts.Close()
+
+	_, err := c.Contacts().List(context.Background(), &GetContactsRequest{
+		QueryHash: map[string]string{"name": "a"},
+		SortHash:  map[string]string{"name": "ASC", "email": "DESC", "createdOn": "ASC"},
+		Sort:      []SortTerm{Descending("createdOn"), {Field: "name"}, Ascending("createdOn")},
+		Page:      1,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	_, err = c.Campaigns().List(context.Background(), &GetCampaignsRequest{SortHash: map[string]string{"name": "ASC"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []string{
+		"page=1&query%5Bname%5D=a&sort%5BcreatedOn%5D=DESC&sort%5Bname%5D=ASC&sort%5Bemail%5D=DESC",
+		"sort%5Bname%5D=ASC",
+	}
+	if fmt.Sprint(queries) != fmt.Sprint(expected) {
+		t.Fatalf("Actual queries (%v) did not match expected (%v)", queries, expected)
+	}
+}
+
+func TestUnit_EncodeQuery(t *testing.T) {
+	query := url.Values{
+		"query[name]":  {"john doe"},
+		"query[email]": {"a+b@example.com"},
+		"fields":       {"contactId,email"},
+		"tags":         {"ü/ä", "~-_."},
+	}
+	actual := encodeQuery(query)
+	if actual != query.Encode() {
+		t.Errorf("Actual query (%v) did not match expected (%v)", actual, query.Encode())
+	}
+
+	sort := []SortTerm{Descending("changedOn"), Ascending("email")}
+	allocs := testing.AllocsPerRun(100, func() {
+		encodeQuery(listQuery(map[string]string{"campaignId": "V", "name": "john"}, nil, sort, []string{"email"}, 12, 1000))
+	})
+	// guards against building query parameters with fmt again, see BenchmarkListQuery
+	if allocs > 24 {
+		t.Errorf("Actual allocations (%v) exceeded expected (%v)", allocs, 24)
+	}
+}
@@ -0,0 +1,72 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBodyStalled is matched by the errors of responses whose body stopped arriving for longer than the body read
+// timeout, as happens on half-open connections
+var ErrBodyStalled = errors.New("response body stalled")
+
+// WithBodyReadTimeout aborts a response whose body receives no data for d, once the headers arrived. Unlike a
+// request timeout it does not limit large responses that keep streaming, and unlike the transport timeouts it applies
+// to any http.Client. Stalled responses fail with an error matching ErrBodyStalled and are retried as network errors
+// are, see Policy.
+func WithBodyReadTimeout(d time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.bodyReadTimeout = d
+	}
+}
+
+// stalledError is returned when the body read timeout fired
+type stalledError struct {
+	timeout time.Duration
+}
+
+func (e *stalledError) Error() string {
+	return "response body stalled for " + e.timeout.String()
+}
+
+func (e *stalledError) Is(target error) bool {
+	return target == ErrBodyStalled
+}
+
+// readBody reads the response body, aborting the request through cancel when no data arrives for timeout. A zero
+// timeout only stops at ctx's end.
+func readBody(ctx context.Context, cancel context.CancelFunc, body io.Reader, timeout time.Duration) ([]byte, error) {
+	r := io.Reader(&contextReader{ctx: ctx, r: body})
+	if timeout <= 0 || cancel == nil {
+		return readAll(r)
+	}
+
+	var stalled int32
+	timer := time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&stalled, 1)
+		cancel()
+	})
+	defer timer.Stop()
+
+	ret, err := readAll(&progressReader{r: r, progress: func() { timer.Reset(timeout) }})
+	if err != nil && atomic.LoadInt32(&stalled) == 1 {
+		return nil, &stalledError{timeout: timeout}
+	}
+	return ret, err
+}
+
+// progressReader calls progress whenever data is read
+type progressReader struct {
+	r        io.Reader
+	progress func()
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.progress()
+	}
+	return n, err
+}
@@ -0,0 +1,105 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stallingServer sends the headers and the start of a body, then waits until the client gives up
+func stallingServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"contactId":"a"`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+}
+
+func TestUnit_BodyReadTimeout(t *testing.T) {
+	type testcase struct {
+		name string
+		opts []Option
+	}
+	for _, tc := range []testcase{
+		{name: "without debug"},
+		// dumps must not read the body ahead of the stall timer
+		{name: "with debug", opts: []Option{WithDebug(ioutil.Discard)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+			ts := stallingServer(&requests)
+			defer ts.Close()
+			opts := []Option{WithoutThrottling(), WithBodyReadTimeout(50 * time.Millisecond), WithDefaultPolicy(Policy{MaxRetries: 1})}
+			c := NewClient(ts.URL, "", "", nil, append(opts, tc.opts...)...)
+
+			start := time.Now()
+			_, err := c.GetContacts(context.Background(), &GetContactsRequest{Page: 1, PerPage: 10})
+			if !errors.Is(err, ErrBodyStalled) {
+				t.Fatalf("Expected ErrBodyStalled, got (%#v)", err)
+			}
+			if errors.Is(err, context.Canceled) {
+				t.Fatalf("A stalled body must not be reported as a cancellation (%v)", err)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("Stalled request took %s", elapsed)
+			}
+			if n := atomic.LoadInt32(&requests); n != 2 {
+				t.Fatalf("Actual requests (%d) did not match expected (%d)", n, 2)
+			}
+		})
+	}
+}
+
+func TestUnit_BodyReadTimeoutAllowsSlowProgress(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, chunk := range []string{`[{"contactId":"a"},`, `{"contactId":"b"},`, `{"contactId":"c"}]`} {
+			fmt.Fprint(w, chunk)
+			w.(http.Flusher).Flush()
+			time.Sleep(30 * time.Millisecond)
+		}
+	}))
+	defer ts.Close()
+	c := NewClient(ts.URL, "", "", nil, WithoutThrottling(), WithBodyReadTimeout(time.Second))
+
+	res, err := c.GetContacts(context.Background(), &GetContactsRequest{Page: 1, PerPage: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if len(res.Contacts) != 3 {
+		t.Fatalf("Actual contacts (%d) did not match expected (%d)", len(res.Contacts), 3)
+	}
+}
+
+func TestUnit_BodyReadInheritsContextDeadline(t *testing.T) {
+	var requests int32
+	ts := stallingServer(&requests)
+	defer ts.Close()
+
+	type testcase struct {
+		name string
+		opts []Option
+	}
+	for _, tc := range []testcase{
+		{name: "without body read timeout"},
+		{name: "with a longer body read timeout", opts: []Option{WithBodyReadTimeout(time.Minute)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClient(ts.URL, "", "", nil, append([]Option{WithoutThrottling()}, tc.opts...)...)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err := c.GetContacts(ctx, &GetContactsRequest{Page: 1, PerPage: 10})
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Expected context.DeadlineExceeded, got (%#v)", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GroupBy sets the interval of statistics points
+type GroupBy string
+
+// Intervals of statistics points
+const (
+	GroupByHour  GroupBy = "hour"
+	GroupByDay   GroupBy = "day"
+	GroupByMonth GroupBy = "month"
+	GroupByTotal GroupBy = "total"
+)
+
+// GetListSizeRequest selects the list size statistics of campaigns.
+// https://apidocs.getresponse.com/v3/resources/campaigns#campaigns.statistics.list-size
+type GetListSizeRequest struct {
+	CampaignIDs []string // required
+	GroupBy     GroupBy  // GroupByDay when not set
+	From        time.Time
+	To          time.Time
+}
+
+// ListSizePoint is the size of a campaign at a point of a TimeSeries
+type ListSizePoint struct {
+	CampaignID         string    // empty if the API did not split the statistics by campaign
+	Date               time.Time // in the account's time zone with WithAccountTimeZone, UTC otherwise
+	TotalSubscribers   int
+	AddedSubscribers   int
+	RemovedSubscribers int
+}
+
+// TimeSeries holds list size statistics sorted by date
+type TimeSeries struct {
+	GroupBy GroupBy
+	Points  []ListSizePoint
+	Raw     json.RawMessage // response body, set with WithRawResponses
+}
+
+// Subscribers returns the subscribers at each date, summed over the requested campaigns
+func (ts *TimeSeries) Subscribers() map[time.Time]int {
+	ret := make(map[time.Time]int, len(ts.Points))
+	for _, p := range ts.Points {
+		ret[p.Date] += p.TotalSubscribers
+	}
+	return ret
+}
+
+// statisticsDateLayouts are the layouts of the dates of statistics points, which depend on GroupBy
+var statisticsDateLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02 15", "2006-01-02", "2006-01", time.RFC3339}
+
+// parseStatisticsDate reads a date of a statistics point, in loc when it has no zone
+func parseStatisticsDate(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range statisticsDateLayouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unknown date format " + s)
+}
+
+type listSizeValues struct {
+	CreatedOn          string          `json:"createdOn"`
+	TotalSubscribers   json.RawMessage `json:"totalSubscribers"`
+	AddedSubscribers   json.RawMessage `json:"addedSubscribers"`
+	RemovedSubscribers json.RawMessage `json:"removedSubscribers"`
+}
+
+func (v listSizeValues) point(campaignID, date string, loc *time.Location) (ListSizePoint, error) {
+	t, err := parseStatisticsDate(date, loc)
+	if err != nil {
+		return ListSizePoint{}, err
+	}
+	return ListSizePoint{
+		CampaignID: campaignID,
+		Date:       t,
+		// counts are sent as numbers or numeric strings
+		TotalSubscribers:   lenientInt(v.TotalSubscribers),
+		AddedSubscribers:   lenientInt(v.AddedSubscribers),
+		RemovedSubscribers: lenientInt(v.RemovedSubscribers),
+	}, nil
+}
+
+// parseListSize reads both shapes the statistics are sent in: a list of points with a createdOn date, or the points
+// by date by campaign id. Dates without a zone are read in loc.
+func parseListSize(data []byte, loc *time.Location) ([]ListSizePoint, error) {
+	var points []ListSizePoint
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		var list []listSizeValues
+		err := json.Unmarshal(data, &list)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range list {
+			p, err := v.point("", v.CreatedOn, loc)
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, p)
+		}
+	} else {
+		var byCampaign map[string]map[string]listSizeValues
+		err := json.Unmarshal(data, &byCampaign)
+		if err != nil {
+			return nil, err
+		}
+		for campaignID, byDate := range byCampaign {
+			for date, v := range byDate {
+				p, err := v.point(campaignID, date, loc)
+				if err != nil {
+					return nil, err
+				}
+				points = append(points, p)
+			}
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		if !points[i].Date.Equal(points[j].Date) {
+			return points[i].Date.Before(points[j].Date)
+		}
+		return points[i].CampaignID < points[j].CampaignID
+	})
+	return points, nil
+}
+
+func (g *getResponseClient) getListSize(ctx context.Context, req *GetListSizeRequest) (*TimeSeries, error) {
+	ctx = withOperation(ctx, OpGetListSize)
+
+	if len(req.CampaignIDs) == 0 {
+		return nil, &ValidationError{Field: "campaignId", Message: "is required"}
+	}
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = GroupByDay
+	}
+	queryHash := map[string]string{
+		"campaignId": strings.Join(req.CampaignIDs, ","),
+		"groupBy":    string(groupBy),
+	}
+	days := req.From.Location()
+	loc, err := g.location(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if g.accountTimeZone {
+		days = loc
+	}
+	TimeRange{From: req.From, To: req.To}.In(days).SetHash(queryHash, "createdOn")
+
+	path := "/v3/campaigns/statistics/list-size"
+	status, ret, err := g.do(ctx, http.MethodGet, path, listQuery(queryHash, nil, nil, nil, 0, 0), nil)
+	if err != nil {
+		return nil, err
+	}
+	points, err := parseListSize(ret, loc)
+	if err != nil {
+		return nil, g.decodeError(ctx, http.MethodGet, path, status, ret, err)
+	}
+
+	return &TimeSeries{GroupBy: groupBy, Points: points, Raw: g.raw(ret)}, nil
+}
+
+// MessageType is a kind of message with statistics
+type MessageType string
+
+// Kinds of messages
+const (
+	MessageNewsletter    MessageType = "newsletter"
+	MessageAutoresponder MessageType = "autoresponder"
+	MessageRSSNewsletter MessageType = "rss-newsletter"
+	MessageSplitTest     MessageType = "splittest"
+)
+
+// messageCollections are the API collections of the kinds of messages
+var messageCollections = map[MessageType]string{
+	MessageNewsletter:    "newsletters",
+	MessageAutoresponder: "autoresponders",
+	MessageRSSNewsletter: "rss-newsletters",
+	MessageSplitTest:     "splittests",
+}
+
+// MessageRef references a message of any kind
+type MessageRef struct {
+	Type MessageType
+	ID   string
+}
+
+// MessageStatistics are the totals of a message, whatever its kind
+type MessageStatistics struct {
+	Message       MessageRef
+	Sent          int
+	TotalOpened   int
+	UniqueOpened  int
+	TotalClicked  int
+	UniqueClicked int
+	Goals         int
+	UniqueGoals   int
+	Forwarded     int
+	Unsubscribed  int
+	Bounced       int
+	Complaints    int
+	Raw           json.RawMessage // response body, set with WithRawResponses
+}
+
+// messageStatisticsFields maps the statistics fields of the API to MessageStatistics
+var messageStatisticsFields = map[string]func(*MessageStatistics) *int{
+	"sent":          func(s *MessageStatistics) *int { return &s.Sent },
+	"totalOpened":   func(s *MessageStatistics) *int { return &s.TotalOpened },
+	"uniqueOpened":  func(s *MessageStatistics) *int { return &s.UniqueOpened },
+	"totalClicked":  func(s *MessageStatistics) *int { return &s.TotalClicked },
+	"uniqueClicked": func(s *MessageStatistics) *int { return &s.UniqueClicked },
+	"goals":         func(s *MessageStatistics) *int { return &s.Goals },
+	"uniqueGoals":   func(s *MessageStatistics) *int { return &s.UniqueGoals },
+	"forwarded":     func(s *MessageStatistics) *int { return &s.Forwarded },
+	"unsubscribed":  func(s *MessageStatistics) *int { return &s.Unsubscribed },
+	"bounced":       func(s *MessageStatistics) *int { return &s.Bounced },
+	"complaints":    func(s *MessageStatistics) *int { return &s.Complaints },
+}
+
+// parseMessageStatistics sums the statistics whether they are sent as a list of time intervals, a single object or
+// objects by message id
+func parseMessageStatistics(data []byte, stats *MessageStatistics) error {
+	var intervals []map[string]json.RawMessage
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err := json.Unmarshal(data, &intervals)
+		if err != nil {
+			return err
+		}
+	} else {
+		var obj map[string]json.RawMessage
+		err := json.Unmarshal(data, &obj)
+		if err != nil {
+			return err
+		}
+		if byID, ok := obj[stats.Message.ID]; ok && len(obj) == 1 {
+			return parseMessageStatistics(byID, stats)
+		}
+		intervals = append(intervals, obj)
+	}
+
+	for _, interval := range intervals {
+		for name, field := range messageStatisticsFields {
+			*field(stats) += lenientInt(interval[name])
+		}
+	}
+	return nil
+}
+
+func (g *getResponseClient) getMessageStatistics(ctx context.Context, message MessageRef) (*MessageStatistics, error) {
+	ctx = withOperation(ctx, OpGetMessageStatistics)
+
+	collection, ok := messageCollections[message.Type]
+	if !ok {
+		return nil, &ValidationError{Field: "type", Message: fmt.Sprintf("unknown message type %q", message.Type)}
+	}
+	path, err := resourcePath(collection, message.ID)
+	if err != nil {
+		return nil, err
+	}
+	path += "/statistics"
+
+	query := listQuery(map[string]string{"groupBy": string(GroupByTotal)}, nil, nil, nil, 0, 0)
+	status, ret, err := g.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	stats := &MessageStatistics{Message: message}
+	err = parseMessageStatistics(ret, stats)
+	if err != nil {
+		return nil, g.decodeError(ctx, http.MethodGet, path, status, ret, err)
+	}
+	stats.Raw = g.raw(ret)
+
+	return stats, nil
+}
@@ -0,0 +1,174 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUnit_CampaignListSize(t *testing.T) {
+	type testcase struct {
+		name     string
+		body     string
+		expected []ListSizePoint
+	}
+
+	day := func(d int) time.Time {
+		return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	testcases := []testcase{
+		{
+			name: "list of points",
+			body: `[{"createdOn":"2026-03-02","totalSubscribers":"12","addedSubscribers":"3","removedSubscribers":"1"},` +
+				`{"createdOn":"2026-03-01","totalSubscribers":10,"addedSubscribers":10,"removedSubscribers":0}]`,
+			expected: []ListSizePoint{
+				{Date: day(1), TotalSubscribers: 10, AddedSubscribers: 10},
+				{Date: day(2), TotalSubscribers: 12, AddedSubscribers: 3, RemovedSubscribers: 1},
+			},
+		},
+		{
+			name: "points by campaign",
+			body: `{"c2":{"2026-03-01":{"totalSubscribers":5}},"c1":{"2026-03-02":{"totalSubscribers":"8"},"2026-03-01":{"totalSubscribers":7}}}`,
+			expected: []ListSizePoint{
+				{CampaignID: "c1", Date: day(1), TotalSubscribers: 7},
+				{CampaignID: "c2", Date: day(1), TotalSubscribers: 5},
+				{CampaignID: "c1", Date: day(2), TotalSubscribers: 8},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := ""
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Path + "?" + r.URL.RawQuery
+				fmt.Fprint(w, tc.body)
+			}))
+			defer ts.Close()
+
+			res, err := c.Campaigns().ListSize(context.Background(), &GetListSizeRequest{
+				CampaignIDs: []string{"c1", "c2"},
+				From:        day(1),
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			expectedQuery := "/v3/campaigns/statistics/list-size?query%5BcampaignId%5D=c1%2Cc2&query%5BcreatedOn%5D%5Bfrom%5D=2026-03-01&query%5BgroupBy%5D=day"
+			if query != expectedQuery {
+				t.Fatalf("Actual query (%s) did not match expected (%s)", query, expectedQuery)
+			}
+			if res.GroupBy != GroupByDay || !reflect.DeepEqual(res.Points, tc.expected) {
+				t.Fatalf("Actual series (%+v) did not match expected (%+v)", res.Points, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUnit_TimeSeriesSubscribers(t *testing.T) {
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	ts := &TimeSeries{Points: []ListSizePoint{
+		{CampaignID: "c1", Date: march, TotalSubscribers: 7},
+		{CampaignID: "c2", Date: march, TotalSubscribers: 5},
+	}}
+	if actual := ts.Subscribers()[march]; actual != 12 {
+		t.Fatalf("Actual subscribers (%d) did not match expected (%d)", actual, 12)
+	}
+
+	for _, layout := range []string{"2026-03-01 13", "2026-03", "2026-03-01 13:00:00"} {
+		if _, err := parseStatisticsDate(layout, time.UTC); err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+}
+
+func TestUnit_CampaignListSizeErrors(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"createdOn":"yesterday"}]`)
+	}))
+	defer ts.Close()
+
+	_, err := c.Campaigns().ListSize(context.Background(), &GetListSizeRequest{})
+	vErr := &ValidationError{}
+	if !errors.As(err, &vErr) {
+		t.Fatalf("Expected a ValidationError, got (%#v)", err)
+	}
+	_, err = c.Campaigns().ListSize(context.Background(), &GetListSizeRequest{CampaignIDs: []string{"c1"}, GroupBy: GroupByMonth})
+	if !errors.Is(err, ErrCouldNotUnmarshal) {
+		t.Fatalf("Expected ErrCouldNotUnmarshal, got (%#v)", err)
+	}
+}
+
+func TestUnit_GetMessageStatistics(t *testing.T) {
+	type testcase struct {
+		name         string
+		message      MessageRef
+		body         string
+		expectedPath string
+		expected     MessageStatistics
+	}
+
+	testcases := []testcase{
+		{
+			name:         "newsletter intervals",
+			message:      MessageRef{Type: MessageNewsletter, ID: "n1"},
+			body:         `[{"timeInterval":"2026-03-01","sent":"100","uniqueOpened":"40","bounced":2},{"timeInterval":"2026-03-02","sent":50,"uniqueOpened":10}]`,
+			expectedPath: "/v3/newsletters/n1/statistics",
+			expected:     MessageStatistics{Sent: 150, UniqueOpened: 50, Bounced: 2},
+		},
+		{
+			name:         "autoresponder object",
+			message:      MessageRef{Type: MessageAutoresponder, ID: "a1"},
+			body:         `{"sent":10,"totalClicked":3,"uniqueClicked":2,"unsubscribed":1}`,
+			expectedPath: "/v3/autoresponders/a1/statistics",
+			expected:     MessageStatistics{Sent: 10, TotalClicked: 3, UniqueClicked: 2, Unsubscribed: 1},
+		},
+		{
+			name:         "rss newsletter by id",
+			message:      MessageRef{Type: MessageRSSNewsletter, ID: "r1"},
+			body:         `{"r1":[{"sent":"7","complaints":"1"}]}`,
+			expectedPath: "/v3/rss-newsletters/r1/statistics",
+			expected:     MessageStatistics{Sent: 7, Complaints: 1},
+		},
+		{
+			name:         "split test",
+			message:      MessageRef{Type: MessageSplitTest, ID: "s1"},
+			body:         `[{"sent":5,"goals":1,"uniqueGoals":1,"forwarded":2}]`,
+			expectedPath: "/v3/splittests/s1/statistics",
+			expected:     MessageStatistics{Sent: 5, Goals: 1, UniqueGoals: 1, Forwarded: 2},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := ""
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r.URL.Path + "?" + r.URL.RawQuery
+				fmt.Fprint(w, tc.body)
+			}))
+			defer ts.Close()
+
+			stats, err := c.Newsletters().Statistics(context.Background(), tc.message)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if expected := tc.expectedPath + "?query%5BgroupBy%5D=total"; request != expected {
+				t.Fatalf("Actual request (%s) did not match expected (%s)", request, expected)
+			}
+			tc.expected.Message = tc.message
+			if !reflect.DeepEqual(*stats, tc.expected) {
+				t.Fatalf("Actual statistics (%+v) did not match expected (%+v)", *stats, tc.expected)
+			}
+		})
+	}
+
+	c := NewClient("", "", "", nil)
+	_, err := c.Newsletters().Statistics(context.Background(), MessageRef{Type: "webinar", ID: "w1"})
+	vErr := &ValidationError{}
+	if !errors.As(err, &vErr) || vErr.Field != "type" {
+		t.Fatalf("Expected a ValidationError, got (%#v)", err)
+	}
+}
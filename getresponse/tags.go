@@ -0,0 +1,174 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultTagCatalogTTL = 10 * time.Minute
+
+// WithTagAutoCreate creates the tags named in contact requests that do not exist yet, instead of failing with an
+// UnknownTagError. It is off by default, since a typo then creates a tag.
+func WithTagAutoCreate(enabled bool) Option {
+	return func(g *getResponseClient) {
+		g.tagAutoCreate = enabled
+	}
+}
+
+// WithTagCatalogTTL sets how long the client's tag catalog keeps tag ids before listing them again, 10 minutes by
+// default. Unknown names always list them again.
+func WithTagCatalogTTL(ttl time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.tagCatalogTTL = ttl
+	}
+}
+
+// UnknownTagError is returned when a tag name is not in the account
+type UnknownTagError struct {
+	Name string
+}
+
+func (e *UnknownTagError) Error() string {
+	return fmt.Sprintf("tag %q does not exist", e.Name)
+}
+
+// TagCatalog maps tag names to tags, listing them once and again when they are older than the catalog's TTL or a
+// name is not found
+type TagCatalog struct {
+	client Client
+	ttl    time.Duration
+
+	flight   flight
+	mu       sync.Mutex
+	byName   map[string]Tag
+	loadedAt time.Time
+}
+
+// NewTagCatalog returns a catalog of the tags of the client's account, listed again after ttl. A zero ttl keeps the
+// tags until a name is not found.
+func NewTagCatalog(c Client, ttl time.Duration) *TagCatalog {
+	return &TagCatalog{client: c, ttl: ttl}
+}
+
+// Refresh lists the tags again. Concurrent calls share a single listing.
+func (c *TagCatalog) Refresh(ctx context.Context) error {
+	return c.flight.do(ctx, func() error {
+		return c.refresh(ctx)
+	})
+}
+
+func (c *TagCatalog) refresh(ctx context.Context) error {
+	byName := map[string]Tag{}
+	req := &GetTagsRequest{Page: 1, PerPage: 100}
+	for {
+		res, err := c.client.Tags().List(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, t := range res.Tags {
+			byName[t.Name] = t
+		}
+		if len(res.Tags) < int(req.PerPage) {
+			break
+		}
+		req.Page++
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.byName, c.loadedAt = byName, time.Now()
+	return nil
+}
+
+// Lookup returns the named tag
+func (c *TagCatalog) Lookup(ctx context.Context, name string) (Tag, error) {
+	c.mu.Lock()
+	stale := c.byName == nil || (c.ttl > 0 && time.Since(c.loadedAt) > c.ttl)
+	t, ok := c.byName[name]
+	c.mu.Unlock()
+	if ok && !stale {
+		return t, nil
+	}
+
+	// the tag may have been created since the tags were listed
+	err := c.Refresh(ctx)
+	if err != nil {
+		return Tag{}, err
+	}
+	c.mu.Lock()
+	t, ok = c.byName[name]
+	c.mu.Unlock()
+	if !ok {
+		return t, &UnknownTagError{Name: name}
+	}
+	return t, nil
+}
+
+// Resolve returns the named tags, with only their ids set, creating the missing ones when create is set
+func (c *TagCatalog) Resolve(ctx context.Context, names []string, create bool) ([]Tag, error) {
+	ret := make([]Tag, 0, len(names))
+	for _, name := range names {
+		t, err := c.Lookup(ctx, name)
+		if _, unknown := err.(*UnknownTagError); unknown && create {
+			t, err = c.create(ctx, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, Tag{TagID: t.TagID})
+	}
+	return ret, nil
+}
+
+func (c *TagCatalog) create(ctx context.Context, name string) (Tag, error) {
+	created, err := c.client.Tags().Create(ctx, &CreateTagRequest{Name: name})
+	if err != nil {
+		return Tag{}, err
+	}
+	t := *created
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byName == nil {
+		c.byName = map[string]Tag{}
+	}
+	c.byName[name] = t
+	return t, nil
+}
+
+// resolveCreateTags returns a copy of the request with its named tags added to Tags, or the request itself when it
+// has none
+func (g *getResponseClient) resolveCreateTags(ctx context.Context, request *CreateContactRequest) (*CreateContactRequest, error) {
+	if request == nil || len(request.TagNames) == 0 {
+		return request, nil
+	}
+	tags, err := g.tagCatalog.Resolve(ctx, request.TagNames, g.tagAutoCreate)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *request
+	resolved.Tags = append(append([]Tag{}, request.Tags...), tags...)
+	resolved.TagNames = nil
+	return &resolved, nil
+}
+
+// resolveUpdateTags returns a copy of the request with its named tags added to NewData.Tags, and to the field mask
+// when there is one
+func (g *getResponseClient) resolveUpdateTags(ctx context.Context, req *UpdateContactRequest) (*UpdateContactRequest, error) {
+	if req == nil || len(req.TagNames) == 0 {
+		return req, nil
+	}
+	tags, err := g.tagCatalog.Resolve(ctx, req.TagNames, g.tagAutoCreate)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *req
+	resolved.NewData.Tags = append(append([]Tag{}, req.NewData.Tags...), tags...)
+	resolved.TagNames = nil
+	if req.Fields != nil && !containsString(req.Fields, "tags") {
+		resolved.Fields = append(append([]string{}, req.Fields...), "tags")
+	}
+	return &resolved, nil
+}
@@ -0,0 +1,65 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_TagNames(t *testing.T) {
+	var calls, bodies []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/v3/tags" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"tagId":"t1","name":"vip"}]`)
+		case r.URL.Path == "/v3/tags":
+			fmt.Fprint(w, `{"tagId":"t2","name":"new"}`)
+		default:
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	c, ts := testClient(handler)
+	defer ts.Close()
+	ctx := context.Background()
+
+	err := c.Contacts().Create(ctx, &CreateContactRequest{Email: "a@example.com", Campaign: Campaign{CampaignID: "c"}, TagNames: []string{"vip"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = c.Contacts().Create(ctx, &CreateContactRequest{Email: "b@example.com", Campaign: Campaign{CampaignID: "c"}, TagNames: []string{"vip", "new"}})
+	uErr := &UnknownTagError{}
+	if !errors.As(err, &uErr) || uErr.Name != "new" {
+		t.Fatalf("Expected an unknown tag error, got (%#v)", err)
+	}
+
+	c = NewClient(ts.URL, "", "", nil, WithTagAutoCreate(true))
+	_, err = c.Contacts().Update(ctx, &UpdateContactRequest{ID: "a", Fields: []string{"name"}, TagNames: []string{"vip", "new"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	// cached, no more listing
+	_, err = c.Contacts().Update(ctx, &UpdateContactRequest{ID: "a", TagNames: []string{"new"}})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+
+	expected := []string{
+		`{"email":"a@example.com","campaign":{"campaignId":"c"},"tags":[{"tagId":"t1"}]}`,
+		`{"tags":[{"tagId":"t1"},{"tagId":"t2"}]}`,
+		`{"tags":[{"tagId":"t2"}]}`,
+	}
+	if fmt.Sprint(bodies) != fmt.Sprint(expected) {
+		t.Fatalf("Actual bodies (%v) did not match expected (%v)", bodies, expected)
+	}
+	expectedCalls := "[GET /v3/tags POST /v3/contacts GET /v3/tags GET /v3/tags GET /v3/tags POST /v3/tags POST /v3/contacts/a POST /v3/contacts/a]"
+	if fmt.Sprint(calls) != expectedCalls {
+		t.Fatalf("Actual calls (%v) did not match expected (%v)", calls, expectedCalls)
+	}
+}
@@ -0,0 +1,171 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+const segmentPerPage = 100
+
+// TagSegmentRequest tags the contacts of a segment (saved contact search) or of a query, e.g. to label a cohort
+// before a targeted send. Tags are added to those of each contact, which keeps its other tags.
+type TagSegmentRequest struct {
+	SegmentID string              // the saved search listing the contacts
+	Query     *GetContactsRequest // the contacts to tag when SegmentID is not set; paging is ignored
+	TagNames  []string            // created when missing
+	// Progress, when set, is called after every contact
+	Progress func(TagSegmentProgress)
+}
+
+// TagSegmentProgress reports how far a TagSegment call is
+type TagSegmentProgress struct {
+	Total   int // contacts matched
+	Tagged  int
+	Skipped int // contacts that had every tag already
+	Failed  int
+}
+
+// TagSegmentResult is the outcome of a TagSegment call
+type TagSegmentResult struct {
+	TagSegmentProgress
+	// Errors holds the error of every contact that could not be tagged by contact id, nil when none failed
+	Errors map[string]error
+}
+
+func (r *TagSegmentRequest) Validate() error {
+	if (r.SegmentID == "") == (r.Query == nil) {
+		return &ValidationError{Field: "segmentId", Message: "either a segment or a query is required"}
+	}
+	if len(r.TagNames) == 0 {
+		return &ValidationError{Field: "tags", Message: "at least one tag is required"}
+	}
+	return nil
+}
+
+func (g *getResponseClient) tagSegment(ctx context.Context, request *TagSegmentRequest) (*TagSegmentResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	tags, err := g.tagCatalog.Resolve(ctx, request.TagNames, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// every contact is listed before tagging, since tagging may change what a query matches
+	contacts, err := g.segmentContacts(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TagSegmentResult{TagSegmentProgress: TagSegmentProgress{Total: len(contacts)}}
+	for _, c := range contacts {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		id := ""
+		if c.ContactID != nil {
+			id = *c.ContactID
+		}
+		switch missing := missingTags(c, tags); {
+		case len(missing) == 0:
+			res.Skipped++
+		default:
+			err := g.upsertContactTags(ctx, id, missing)
+			if err != nil {
+				if res.Errors == nil {
+					res.Errors = map[string]error{}
+				}
+				res.Errors[id] = err
+				res.Failed++
+			} else {
+				res.Tagged++
+			}
+		}
+		if request.Progress != nil {
+			err := g.callHook(ctx, "progress", func() {
+				request.Progress(res.TagSegmentProgress)
+			})
+			if err != nil {
+				return res, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// missingTags returns the tags the contact does not have
+func missingTags(c Contact, tags []Tag) []Tag {
+	has := map[string]bool{}
+	for _, t := range c.Tags {
+		has[t.TagID] = true
+	}
+	var missing []Tag
+	for _, t := range tags {
+		if !has[t.TagID] {
+			missing = append(missing, Tag{TagID: t.TagID})
+		}
+	}
+	return missing
+}
+
+// segmentContacts lists the ids and tags of the contacts of the segment or query
+func (g *getResponseClient) segmentContacts(ctx context.Context, request *TagSegmentRequest) ([]Contact, error) {
+	fields := ContactFields(FieldContactID, FieldTags)
+	var contacts []Contact
+	for page := int32(1); ; page++ {
+		var res []Contact
+		var err error
+		if request.SegmentID != "" {
+			res, err = g.getSegmentContacts(ctx, request.SegmentID, fields, page)
+		} else {
+			q := *request.Query
+			q.Fields, q.Page, q.PerPage = fields, page, segmentPerPage
+			var list *GetContactsResponse
+			list, err = g.getContacts(ctx, &q)
+			if list != nil {
+				res = list.Contacts
+			}
+		}
+		if err != nil {
+			return nil, &PageError{Page: page, Err: err}
+		}
+		contacts = append(contacts, res...)
+		if len(res) < segmentPerPage {
+			return contacts, nil
+		}
+	}
+}
+
+func (g *getResponseClient) getSegmentContacts(ctx context.Context, segmentID string, fields []string, page int32) ([]Contact, error) {
+	ctx = withOperation(ctx, OpGetSegmentContacts)
+
+	path, err := resourcePath("search-contacts", segmentID)
+	if err != nil {
+		return nil, err
+	}
+	query := listQuery(nil, nil, nil, fields, page, segmentPerPage)
+	result, _, err := doGet[[]Contact](ctx, g, path+"/contacts", query)
+	return result, err
+}
+
+func (g *getResponseClient) upsertContactTags(ctx context.Context, contactID string, tags []Tag) error {
+	ctx = withOperation(ctx, OpUpsertContactTags)
+
+	path, err := resourcePath("contacts", contactID)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(struct {
+		Tags []Tag `json:"tags"`
+	}{tags})
+	if err != nil {
+		return err
+	}
+	_, _, err = g.do(ctx, http.MethodPost, path+"/tags", nil, body)
+	if err != nil {
+		return err
+	}
+	g.invalidate(path)
+	return nil
+}
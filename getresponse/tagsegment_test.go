@@ -0,0 +1,62 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestUnit_TagSegment(t *testing.T) {
+	var mu sync.Mutex
+	tagged := []string{}
+	c, ts := testClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v3/tags":
+			fmt.Fprint(w, `[{"tagId":"t1","name":"cohort-a"},{"tagId":"t2","name":"q3"}]`)
+		case r.URL.Path == "/v3/search-contacts/s/contacts":
+			if r.URL.Query().Get("fields") != "contactId,tags" {
+				t.Errorf("Unexpected query (%v)", r.URL.Query())
+			}
+			fmt.Fprint(w, `[{"contactId":"a"},{"contactId":"b","tags":[{"tagId":"t1"},{"tagId":"t2"}]},{"contactId":"c","tags":[{"tagId":"t2"}]},{"contactId":"d"}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/contacts/d/tags":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"httpStatus":404,"code":1013}`)
+		case r.Method == http.MethodPost:
+			body, _ := ioutil.ReadAll(r.Body)
+			mu.Lock()
+			tagged = append(tagged, r.URL.Path+" "+string(body))
+			mu.Unlock()
+		default:
+			t.Errorf("Unexpected request (%s %s)", r.Method, r.URL)
+		}
+	})
+	defer ts.Close()
+
+	var progress []TagSegmentProgress
+	res, err := c.Tags().TagSegment(context.Background(), &TagSegmentRequest{
+		SegmentID: "s",
+		TagNames:  []string{"cohort-a", "q3"},
+		Progress:  func(p TagSegmentProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	expected := TagSegmentProgress{Total: 4, Tagged: 2, Skipped: 1, Failed: 1}
+	if res.TagSegmentProgress != expected || len(res.Errors) != 1 || res.Errors["d"] == nil || len(progress) != 4 {
+		t.Fatalf("Actual result (%#v) did not match expected (%#v)", res, expected)
+	}
+	sort.Strings(tagged)
+	expectedTagged := []string{`/v3/contacts/a/tags {"tags":[{"tagId":"t1"},{"tagId":"t2"}]}`, `/v3/contacts/c/tags {"tags":[{"tagId":"t1"}]}`}
+	if fmt.Sprint(tagged) != fmt.Sprint(expectedTagged) {
+		t.Fatalf("Actual tagging (%v) did not match expected (%v)", tagged, expectedTagged)
+	}
+
+	_, err = c.Tags().TagSegment(context.Background(), &TagSegmentRequest{TagNames: []string{"q3"}})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Fatalf("Expected a validation error, got (%#v)", err)
+	}
+}
@@ -0,0 +1,210 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit headers, described @ https://apidocs.getresponse.com/v3/limits
+const (
+	XRateLimitLimitHeader     = "X-RateLimit-Limit"
+	XRateLimitRemainingHeader = "X-RateLimit-Remaining"
+	XRateLimitResetHeader     = "X-RateLimit-Reset"
+
+	defaultThrottleBackoff = time.Second
+	defaultThrottleMaxWait = 30 * time.Second
+	defaultLowWatermark    = 0.1
+)
+
+var (
+	// ErrThrottled matches (errors.Is) every error caused by an exhausted request budget
+	ErrThrottled = errors.New("request budget exhausted")
+)
+
+// ThrottledError is returned when the API answered 429, or when the client held a request back because the budget is
+// exhausted and waiting would take longer than the throttler allows
+type ThrottledError struct {
+	RetryAfter time.Duration
+	Err        error // the API error for a 429 response, nil if the request was never sent
+}
+
+func (e *ThrottledError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: retry after %s: %s", ErrThrottled.Error(), e.RetryAfter, e.Err.Error())
+	}
+	return fmt.Sprintf("%s: retry after %s", ErrThrottled.Error(), e.RetryAfter)
+}
+
+func (e *ThrottledError) Is(target error) bool {
+	return target == ErrThrottled
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// Throttler spaces out requests based on the rate limit headers and 429 responses seen so far. It is safe for
+// concurrent use and can be shared by several clients using the same API key, see WithThrottler. MaxWait and
+// LowWatermark must not be changed once the throttler is in use.
+type Throttler struct {
+	// MaxWait is how long a request may be held back before failing with ErrThrottled instead
+	MaxWait time.Duration
+	// LowWatermark is the fraction of the limit below which requests are spread evenly over the rest of the window
+	LowWatermark float64
+
+	mu           sync.Mutex
+	spacing      time.Duration
+	next         time.Time
+	blockedUntil time.Time
+}
+
+// NewThrottler returns a throttler that holds requests back for at most maxWait
+func NewThrottler(maxWait time.Duration) *Throttler {
+	return &Throttler{
+		MaxWait:      maxWait,
+		LowWatermark: defaultLowWatermark,
+	}
+}
+
+// WithThrottler replaces the client's own throttler, e.g. to share one between clients
+func WithThrottler(t *Throttler) Option {
+	return func(g *getResponseClient) {
+		g.throttler = t
+	}
+}
+
+// WithThrottleMaxWait sets how long a throttled request may wait before failing with ErrThrottled. The default is 30s,
+// zero makes throttled requests fail straight away.
+func WithThrottleMaxWait(d time.Duration) Option {
+	return func(g *getResponseClient) {
+		if g.throttler != nil {
+			g.throttler.MaxWait = d
+		}
+	}
+}
+
+// WithoutThrottling turns adaptive throttling off
+func WithoutThrottling() Option {
+	return func(g *getResponseClient) {
+		g.throttler = nil
+	}
+}
+
+// Wait blocks until the request may be sent, or returns a *ThrottledError if that would exceed MaxWait
+func (t *Throttler) Wait(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	at := now
+	if t.blockedUntil.After(at) {
+		at = t.blockedUntil
+	}
+	if t.next.After(at) {
+		at = t.next
+	}
+	delay := at.Sub(now)
+	if delay > t.MaxWait {
+		t.mu.Unlock()
+		return &ThrottledError{RetryAfter: delay}
+	}
+	t.next = at.Add(t.spacing)
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// canonical forms of the rate limit headers, so every response does not canonicalize them again
+var (
+	rateLimitLimitKey     = http.CanonicalHeaderKey(XRateLimitLimitHeader)
+	rateLimitRemainingKey = http.CanonicalHeaderKey(XRateLimitRemainingHeader)
+	rateLimitResetKey     = http.CanonicalHeaderKey(XRateLimitResetHeader)
+)
+
+// headerValue is h.Get for a key already in canonical form
+func headerValue(h http.Header, key string) string {
+	if values := h[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// rateLimitInt reads a numeric rate limit header, false when it is missing or invalid
+func rateLimitInt(h http.Header, key string) (int, bool) {
+	v := headerValue(h, key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
+
+// Observe adjusts the pace of later requests from a response's status and rate limit headers
+func (t *Throttler) Observe(status int, h http.Header) {
+	reset, hasReset := parseRateLimitReset(headerValue(h, rateLimitResetKey))
+	remaining, hasRemaining := rateLimitInt(h, rateLimitRemainingKey)
+	limit, hasLimit := rateLimitInt(h, rateLimitLimitKey)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+
+	switch {
+	case status == http.StatusTooManyRequests || (hasRemaining && remaining <= 0):
+		if !hasReset {
+			// no hint from the API, back off exponentially
+			reset = t.spacing * 2
+			if reset < defaultThrottleBackoff {
+				reset = defaultThrottleBackoff
+			}
+			t.spacing = reset
+		}
+		t.blockedUntil = now.Add(reset)
+	case hasRemaining && hasLimit && hasReset && float64(remaining) < float64(limit)*t.LowWatermark:
+		// spread what is left of the budget over the rest of the window
+		t.spacing = reset / time.Duration(remaining)
+	case hasRemaining:
+		t.spacing = 0
+	case status < http.StatusBadRequest:
+		t.spacing /= 2
+	}
+}
+
+func (t *Throttler) retryAfter() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if d := time.Until(t.blockedUntil); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// parseRateLimitReset reads values such as "600 seconds"
+func parseRateLimitReset(v string) (time.Duration, bool) {
+	fields := strings.Fields(v)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	secs, err := strconv.Atoi(fields[0])
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
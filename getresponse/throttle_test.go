@@ -0,0 +1,78 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUnit_Throttling(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set(XRateLimitResetHeader, "600 seconds")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"code":1015,"message":"quota reached"}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", nil, WithThrottleMaxWait(time.Second))
+
+	_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	tErr := &ThrottledError{}
+	if !errors.Is(err, ErrThrottled) || !errors.As(err, &tErr) || tErr.RetryAfter < 599*time.Second {
+		t.Fatalf("Expected a throttled error, got (%#v)", err)
+	}
+	grErr := &GetResponseError{}
+	if !errors.As(err, &grErr) || grErr.ErrorCode != ErrequestQuotaReached {
+		t.Fatalf("Expected the API error to be kept, got (%#v)", err)
+	}
+
+	// the budget is exhausted for longer than the max wait so the next request must not be sent
+	_, err = c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+	if !errors.Is(err, ErrThrottled) || calls != 1 {
+		t.Fatalf("Expected the request to be held back, got (%#v) after %d calls", err, calls)
+	}
+}
+
+func TestUnit_ThrottlerSpacing(t *testing.T) {
+	th := NewThrottler(time.Second)
+	h := http.Header{}
+	h.Set(XRateLimitLimitHeader, "30000")
+	h.Set(XRateLimitRemainingHeader, "10")
+	h.Set(XRateLimitResetHeader, "1 seconds")
+	th.Observe(http.StatusOK, h)
+
+	// 10 requests left for the next second are spread 100ms apart across goroutines
+	start := time.Now()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := th.Wait(context.Background()); err != nil {
+				t.Errorf("Unexpected error occurred (%#v)", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("Expected requests to be spaced out, took %s", elapsed)
+	}
+
+	h.Set(XRateLimitRemainingHeader, "29000")
+	th.Observe(http.StatusOK, h)
+	time.Sleep(100 * time.Millisecond)
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		th.Wait(context.Background())
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("Expected no spacing with a healthy budget, took %s", elapsed)
+	}
+}
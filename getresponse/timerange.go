@@ -0,0 +1,87 @@
+package getresponse
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// QueryDateLayout is the format of the date filters of queries, e.g. query[createdOn][from]
+const QueryDateLayout = "2006-01-02"
+
+// TimeRange is a window for the date filters of queries. The API filters whole days, so From counts from the start
+// of its day and To up to the end of its day. Either may be zero to leave the range open.
+//
+//	r := getresponse.TimeRange{From: time.Now().AddDate(0, 0, -7)}.In(loc)
+//	req := &getresponse.GetContactsRequest{QueryHash: r.SetHash(nil, "createdOn")}
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+
+	// Location is the time zone days are counted in, UTC when nil. Filters apply to the days of the account's
+	// time zone, see AccountLocation.
+	Location *time.Location
+}
+
+// LastDays returns the range of the last days, today included for 0 or more
+func LastDays(days int) TimeRange {
+	return TimeRange{From: time.Now().AddDate(0, 0, -days)}
+}
+
+// In returns the range with days counted in loc
+func (r TimeRange) In(loc *time.Location) TimeRange {
+	r.Location = loc
+	return r
+}
+
+// FromDate returns From formatted for a query, empty when it is zero
+func (r TimeRange) FromDate() string {
+	return r.format(r.From)
+}
+
+// ToDate returns To formatted for a query, empty when it is zero
+func (r TimeRange) ToDate() string {
+	return r.format(r.To)
+}
+
+func (r TimeRange) format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	loc := r.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(QueryDateLayout)
+}
+
+// SetHash adds the range on field to a QueryHash, creating it when nil, and returns it
+func (r TimeRange) SetHash(queryHash map[string]string, field string) map[string]string {
+	if queryHash == nil {
+		queryHash = map[string]string{}
+	}
+	// listQuery wraps keys in query[...], this nests the range inside it
+	if from := r.FromDate(); from != "" {
+		queryHash[field+"][from"] = from
+	}
+	if to := r.ToDate(); to != "" {
+		queryHash[field+"][to"] = to
+	}
+	return queryHash
+}
+
+// SetQuery adds the range on field to a query sent with Do or Get
+func (r TimeRange) SetQuery(query url.Values, field string) {
+	if from := r.FromDate(); from != "" {
+		query.Set("query["+field+"][from]", from)
+	}
+	if to := r.ToDate(); to != "" {
+		query.Set("query["+field+"][to]", to)
+	}
+}
+
+// AccountLocation returns the time zone of the account, which date filters count days in. The zone is read once per
+// client and shared with WithAccountTimeZone, see Client.AccountLocation.
+func AccountLocation(ctx context.Context, c Client) (*time.Location, error) {
+	return c.AccountLocation(ctx)
+}
@@ -0,0 +1,37 @@
+package getresponse
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUnit_TimeRange(t *testing.T) {
+	from := time.Date(2020, 3, 1, 23, 30, 0, 0, time.UTC)
+	to := time.Date(2020, 3, 31, 1, 0, 0, 0, time.UTC)
+	warsaw := time.FixedZone("CET", 3600)
+	newYork := time.FixedZone("EST", -5*3600)
+
+	type testcase struct {
+		r        TimeRange
+		expected map[string]string
+	}
+	for _, tc := range []testcase{
+		{TimeRange{From: from, To: to}, map[string]string{"createdOn][from": "2020-03-01", "createdOn][to": "2020-03-31"}},
+		{TimeRange{From: from}.In(warsaw), map[string]string{"createdOn][from": "2020-03-02"}},
+		{TimeRange{To: to}.In(newYork), map[string]string{"createdOn][to": "2020-03-30"}},
+		{TimeRange{}, map[string]string{}},
+	} {
+		actual := tc.r.SetHash(nil, "createdOn")
+		if fmt.Sprint(actual) != fmt.Sprint(tc.expected) {
+			t.Fatalf("Actual query (%v) did not match expected (%v)", actual, tc.expected)
+		}
+	}
+
+	query := url.Values{}
+	TimeRange{From: from, To: to}.SetQuery(query, "changedOn")
+	if query.Get("query[changedOn][from]") != "2020-03-01" || query.Get("query[changedOn][to]") != "2020-03-31" {
+		t.Fatalf("Unexpected query (%v)", query)
+	}
+}
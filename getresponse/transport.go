@@ -0,0 +1,106 @@
+package getresponse
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// transportConfig holds the transport options. They only apply when NewClient builds its own http.Client, i.e. when
+// it is passed a nil client.
+type transportConfig struct {
+	set                 bool
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	headerTimeout       time.Duration
+	tlsConfig           *tls.Config
+	proxyURL            *url.URL
+	http2               *bool
+}
+
+// WithMaxIdleConnsPerHost sets how many keep-alive connections to the API are kept open.
+// High-throughput jobs should raise it to avoid exhausting ephemeral ports.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(g *getResponseClient) {
+		g.transport.set = true
+		g.transport.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long idle keep-alive connections are kept open
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.transport.set = true
+		g.transport.idleConnTimeout = d
+	}
+}
+
+// WithResponseHeaderTimeout limits how long to wait for the response headers once the request was written. Use
+// WithBodyReadTimeout to limit the wait for the body.
+func WithResponseHeaderTimeout(d time.Duration) Option {
+	return func(g *getResponseClient) {
+		g.transport.set = true
+		g.transport.headerTimeout = d
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API
+func WithTLSConfig(c *tls.Config) Option {
+	return func(g *getResponseClient) {
+		g.transport.set = true
+		g.transport.tlsConfig = c
+	}
+}
+
+// WithProxyURL sends requests through the given proxy instead of the one from the environment
+func WithProxyURL(u *url.URL) Option {
+	return func(g *getResponseClient) {
+		g.transport.set = true
+		g.transport.proxyURL = u
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2. It is enabled by default.
+func WithHTTP2(enabled bool) Option {
+	return func(g *getResponseClient) {
+		g.transport.set = true
+		g.transport.http2 = &enabled
+	}
+}
+
+// newHTTPClient returns http.DefaultClient unless transport options were given
+func (t *transportConfig) newHTTPClient() *http.Client {
+	if !t.set {
+		return http.DefaultClient
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if t.maxIdleConnsPerHost > 0 {
+		tr.MaxIdleConnsPerHost = t.maxIdleConnsPerHost
+		if tr.MaxIdleConns > 0 && tr.MaxIdleConns < t.maxIdleConnsPerHost {
+			tr.MaxIdleConns = t.maxIdleConnsPerHost
+		}
+	}
+	if t.idleConnTimeout > 0 {
+		tr.IdleConnTimeout = t.idleConnTimeout
+	}
+	if t.headerTimeout > 0 {
+		tr.ResponseHeaderTimeout = t.headerTimeout
+	}
+	if t.tlsConfig != nil {
+		tr.TLSClientConfig = t.tlsConfig
+	}
+	if t.proxyURL != nil {
+		tr.Proxy = http.ProxyURL(t.proxyURL)
+	}
+	if t.http2 != nil {
+		tr.ForceAttemptHTTP2 = *t.http2
+		if !*t.http2 {
+			// a non-nil empty map disables HTTP/2
+			tr.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+	}
+
+	return &http.Client{Transport: tr}
+}
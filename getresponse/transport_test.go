@@ -0,0 +1,46 @@
+package getresponse
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUnit_TransportOptions(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.local:3128")
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	c := NewClient("", "", "", nil,
+		WithMaxIdleConnsPerHost(200),
+		WithIdleConnTimeout(time.Minute),
+		WithResponseHeaderTimeout(10*time.Second),
+		WithTLSConfig(tlsConfig),
+		WithProxyURL(proxy),
+		WithHTTP2(false),
+	).(*getResponseClient)
+
+	if c.c == http.DefaultClient {
+		t.Fatalf("Expected a dedicated http client")
+	}
+	tr := c.c.Transport.(*http.Transport)
+	if tr.MaxIdleConnsPerHost != 200 || tr.MaxIdleConns < 200 || tr.IdleConnTimeout != time.Minute || tr.ResponseHeaderTimeout != 10*time.Second || tr.TLSClientConfig != tlsConfig {
+		t.Fatalf("Transport options were not applied (%#v)", tr)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Fatalf("Expected HTTP/2 to be disabled")
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.getresponse.com/v3/contacts", nil)
+	if u, _ := tr.Proxy(req); u.String() != proxy.String() {
+		t.Fatalf("Expected the proxy to be used, got (%v)", u)
+	}
+
+	if NewClient("", "", "", nil).(*getResponseClient).c != http.DefaultClient {
+		t.Fatalf("Expected http.DefaultClient without transport options")
+	}
+	own := &http.Client{}
+	if NewClient("", "", "", own, WithMaxIdleConnsPerHost(5)).(*getResponseClient).c != own {
+		t.Fatalf("Expected the caller's client to be kept")
+	}
+}
@@ -1,17 +1,122 @@
 package getresponse
 
+import "encoding/json"
+
 // Campaign holds the representation of a campaign
 type Campaign struct {
-	CampaignID string  `json:"campaignId"` // required
-	Name       string  `json:"name,omitempty"`
-	Href       *string `json:"href,omitempty"`
+	CampaignID                string                     `json:"campaignId"` // required
+	Name                      string                     `json:"name,omitempty"`
+	Href                      *string                    `json:"href,omitempty"`
+	Description               *string                    `json:"description,omitempty"`
+	IsDefault                 *string                    `json:"isDefault,omitempty"`
+	CreatedOn                 *string                    `json:"createdOn,omitempty"`
+	LanguageCode              *string                    `json:"languageCode,omitempty"`
+	OptinTypes                *OptinTypes                `json:"optinTypes,omitempty"`
+	SubscriptionNotifications *SubscriptionNotifications `json:"subscriptionNotifications,omitempty"`
+	Profile                   *CampaignProfile           `json:"profile,omitempty"`
+	Postal                    *CampaignPostal            `json:"postal,omitempty"`
+	Confirmation              *CampaignConfirmation      `json:"confirmation,omitempty"`
+}
+
+// CampaignSettings are the writable parts of a campaign, nil fields are left unchanged
+type CampaignSettings struct {
+	Name                      *string                    `json:"name,omitempty"`
+	LanguageCode              *string                    `json:"languageCode,omitempty"`
+	IsDefault                 *string                    `json:"isDefault,omitempty"` // "true" or "false", as on Campaign
+	OptinTypes                *OptinTypes                `json:"optinTypes,omitempty"`
+	SubscriptionNotifications *SubscriptionNotifications `json:"subscriptionNotifications,omitempty"`
+	Profile                   *CampaignProfile           `json:"profile,omitempty"`
+	Postal                    *CampaignPostal            `json:"postal,omitempty"`
+	Confirmation              *CampaignConfirmation      `json:"confirmation,omitempty"`
+}
+
+// SubscriptionNotifications tells who is notified of new subscribers
+type SubscriptionNotifications struct {
+	Status     string         `json:"status,omitempty"` // "enabled" or "disabled"
+	Recipients []FromFieldRef `json:"recipients,omitempty"`
+}
+
+// Subscription notification statuses
+const (
+	NotificationsEnabled  = "enabled"
+	NotificationsDisabled = "disabled"
+)
+
+// CampaignProfile is the public description of a campaign shown on its subscription pages
+type CampaignProfile struct {
+	IndustryTagID *int32  `json:"industryTagId,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Logo          *string `json:"logo,omitempty"`
+	LogoLinkURL   *string `json:"logoLinkUrl,omitempty"`
+	Title         *string `json:"title,omitempty"`
+}
+
+// CampaignPostal is the postal address added to the footer of messages, as anti-spam laws require
+type CampaignPostal struct {
+	AddPostalToMessages *string `json:"addPostalToMessages,omitempty"`
+	City                *string `json:"city,omitempty"`
+	CompanyName         *string `json:"companyName,omitempty"`
+	Design              *string `json:"design,omitempty"`
+	State               *string `json:"state,omitempty"`
+	Street              *string `json:"street,omitempty"`
+	ZipCode             *string `json:"zipCode,omitempty"`
+	Country             *string `json:"country,omitempty"`
+}
+
+// CampaignConfirmation configures the double opt-in confirmation message
+type CampaignConfirmation struct {
+	FromField                         *FromFieldRef `json:"fromField,omitempty"`
+	ReplyTo                           *FromFieldRef `json:"replyTo,omitempty"`
+	RedirectType                      *string       `json:"redirectType,omitempty"`
+	RedirectURL                       *string       `json:"redirectUrl,omitempty"`
+	MimeType                          *string       `json:"mimeType,omitempty"`
+	SubscriptionConfirmationBodyID    *string       `json:"subscriptionConfirmationBodyId,omitempty"`
+	SubscriptionConfirmationSubjectID *string       `json:"subscriptionConfirmationSubjectId,omitempty"`
+}
+
+// OptinTypes tells for each way of adding contacts to a campaign whether they must confirm their subscription
+// ("double") or not ("single")
+type OptinTypes struct {
+	Email   string `json:"email,omitempty"`
+	API     string `json:"api,omitempty"`
+	Import  string `json:"import,omitempty"`
+	Webform string `json:"webform,omitempty"`
 }
 
-// CustomField holds key value sets
+// Optin types
+const (
+	OptinSingle = "single"
+	OptinDouble = "double"
+)
+
+// CustomField holds key value sets. Contacts read from the API also carry the name and type of their fields, only
+// CustomFieldID and Value are needed to set them.
 type CustomField struct {
 	CustomFieldID string   `json:"customFieldId"`
 	Value         []string `json:"value"`
 	Href          *string  `json:"href,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	FieldType     string   `json:"fieldType,omitempty"`
+	ValueType     string   `json:"valueType,omitempty"`
+	Type          string   `json:"type,omitempty"`
+}
+
+// MultiValued tells whether the field takes several values, as checkboxes and multi selects do. It is only known for
+// fields read from the API.
+func (f CustomField) MultiValued() bool {
+	return CustomFieldDefinition{FieldType: f.FieldType}.multiValued()
+}
+
+// CustomFieldDefinition describes a custom field of the account
+type CustomFieldDefinition struct {
+	CustomFieldID string   `json:"customFieldId"`
+	Href          *string  `json:"href,omitempty"`
+	Name          string   `json:"name"`
+	FieldType     string   `json:"fieldType,omitempty"`
+	ValueType     string   `json:"valueType,omitempty"`
+	Type          string   `json:"type,omitempty"`
+	Hidden        string   `json:"hidden,omitempty"`
+	Values        []string `json:"values,omitempty"`
 }
 
 // Geolocation holds geo data on contacts
@@ -26,10 +131,162 @@ type Geolocation struct {
 	City          *string `json:"city,omitempty"`
 }
 
+// Tag is a label attached to contacts
 type Tag struct {
+	TagID string  `json:"tagId"`
+	Href  *string `json:"href,omitempty"`
+	Name  string  `json:"name,omitempty"`
+	Color string  `json:"color,omitempty"`
+}
+
+// FromField is a sender name and address newsletters can be sent from
+type FromField struct {
+	FromFieldID string  `json:"fromFieldId"`
+	Href        *string `json:"href,omitempty"`
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	IsDefault   string  `json:"isDefault,omitempty"`
+	IsActive    string  `json:"isActive,omitempty"`
+	CreatedOn   *string `json:"createdOn,omitempty"`
+}
+
+// Newsletter represents a GR newsletter
+type Newsletter struct {
+	NewsletterID string    `json:"newsletterId"`
+	Href         *string   `json:"href,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Type         string    `json:"type,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	Editor       string    `json:"editor,omitempty"`
+	Subject      string    `json:"subject,omitempty"`
+	Campaign     *Campaign `json:"campaign,omitempty"`
+	CreatedOn    *string   `json:"createdOn,omitempty"`
+	SendOn       *string   `json:"sendOn,omitempty"`
+	// SendMetrics is the progress of the send, see NewslettersClient.WaitForSend
+	SendMetrics *SendMetrics `json:"sendMetrics,omitempty"`
+	// CreatedOnTime and SendOnTime are CreatedOn and SendOn read by the client, see WithAccountTimeZone
+	CreatedOnTime *AccountTime `json:"-"`
+	SendOnTime    *AccountTime `json:"-"`
+}
+
+// SendMetrics is the progress of a newsletter send
+type SendMetrics struct {
+	Status     string  `json:"status"`
+	Sent       int     `json:"sent"`
+	Total      int     `json:"total"`
+	LastSentOn *string `json:"lastSentOn,omitempty"`
+}
+
+// UnmarshalJSON accepts the counts as numbers or numeric strings, the API sends both
+func (m *SendMetrics) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Status     string          `json:"status"`
+		Sent       json.RawMessage `json:"sent"`
+		Total      json.RawMessage `json:"total"`
+		LastSentOn *string         `json:"lastSentOn"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*m = SendMetrics{Status: raw.Status, Sent: lenientInt(raw.Sent), Total: lenientInt(raw.Total), LastSentOn: raw.LastSentOn}
+	return nil
+}
+
+// Autoresponder is a message sent automatically to the contacts of a campaign
+type Autoresponder struct {
+	AutoresponderID string                `json:"autoresponderId"`
+	Href            *string               `json:"href,omitempty"`
+	Name            string                `json:"name,omitempty"`
+	Subject         string                `json:"subject,omitempty"`
+	Status          string                `json:"status,omitempty"` // "enabled" or "disabled"
+	Campaign        *Campaign             `json:"campaign,omitempty"`
+	TriggerSettings *AutoresponderTrigger `json:"triggerSettings,omitempty"`
+	CreatedOn       *string               `json:"createdOn,omitempty"`
+}
+
+// AutoresponderTrigger tells when an autoresponder is sent
+type AutoresponderTrigger struct {
+	Type       string `json:"type"`                 // "onday" for the autoresponders of the cycle, or the action sending it
+	DayOfCycle *int   `json:"dayOfCycle,omitempty"` // for "onday" autoresponders
+}
+
+// UnmarshalJSON accepts the day of cycle as a number or a numeric string, the API sends both
+func (t *AutoresponderTrigger) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type       string          `json:"type"`
+		DayOfCycle json.RawMessage `json:"dayOfCycle"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	*t = AutoresponderTrigger{Type: raw.Type}
+	if len(raw.DayOfCycle) > 0 && string(raw.DayOfCycle) != "null" {
+		day := lenientInt(raw.DayOfCycle)
+		t.DayOfCycle = &day
+	}
+	return nil
+}
+
+// FromFieldRef references a from field by id
+type FromFieldRef struct {
+	FromFieldID string  `json:"fromFieldId"`
+	Href        *string `json:"href,omitempty"`
+}
+
+// NewsletterContent is the body of a newsletter
+type NewsletterContent struct {
+	HTML  string `json:"html,omitempty"`
+	Plain string `json:"plain,omitempty"`
+}
+
+// SendSettings selects who receives a newsletter. Segments are saved contact searches, see SearchContact.
+type SendSettings struct {
+	SelectedCampaigns    []string `json:"selectedCampaigns,omitempty"`
+	SelectedSegments     []string `json:"selectedSegments,omitempty"`
+	SelectedSuppressions []string `json:"selectedSuppressions,omitempty"`
+	ExcludedCampaigns    []string `json:"excludedCampaigns,omitempty"`
+	ExcludedSegments     []string `json:"excludedSegments,omitempty"`
+	SelectedContacts     []string `json:"selectedContacts,omitempty"`
+	// TimeTravel delivers at the scheduled time in each contact's own timezone
+	TimeTravel bool `json:"timeTravel,string"`
+	// PerfectTiming delivers within a day of the scheduled time, when each contact is most likely to open it
+	PerfectTiming bool `json:"perfectTiming,string"`
+}
+
+// TransactionalRecipient is an addressee of a transactional email
+type TransactionalRecipient struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+// TransactionalRecipients lists who receives a transactional email
+type TransactionalRecipients struct {
+	To  TransactionalRecipient   `json:"to"`
+	CC  []TransactionalRecipient `json:"cc,omitempty"`
+	BCC []TransactionalRecipient `json:"bcc,omitempty"`
+}
+
+// TransactionalEmailTag groups transactional emails in statistics
+type TransactionalEmailTag struct {
 	TagID string `json:"tagId"`
 }
 
+// TransactionalEmail is a sent transactional email
+type TransactionalEmail struct {
+	TransactionalEmailID string  `json:"transactionalEmailId"`
+	Href                 *string `json:"href,omitempty"`
+}
+
+// SearchContact is a saved contact search, used as a segment when sending newsletters
+type SearchContact struct {
+	SearchContactID string  `json:"searchContactId"`
+	Href            *string `json:"href,omitempty"`
+	Name            string  `json:"name,omitempty"`
+	CreatedOn       *string `json:"createdOn,omitempty"`
+}
+
 // Contact represents a GR contact
 type Contact struct {
 	ContactID         *string       `json:"contactId,omitempty"`
@@ -50,3 +307,14 @@ type Contact struct {
 	Activities        *string       `json:"activities,omitempty"`
 	Scoring           *int64        `json:"scoring,omitempty"`
 }
+
+// GetCustomFieldValue returns the values of the custom field with the given name, false when the contact has none.
+// Names are only known for contacts read from the API with their customFieldValues.
+func (c Contact) GetCustomFieldValue(name string) ([]string, bool) {
+	for _, f := range c.CustomFieldValues {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
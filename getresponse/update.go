@@ -0,0 +1,131 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+)
+
+const defaultUpdateIfAttempts = 5
+
+var (
+	ErrUpdateConflict = errors.New("contact kept changing while it was being updated")
+)
+
+func (g *getResponseClient) updateContactIf(ctx context.Context, id string, mutate func(*Contact) error) (*Contact, error) {
+	if id == "" {
+		return nil, &ValidationError{Field: "id", Message: "is required"}
+	}
+	ctx = withoutCache(ctx)
+
+	for attempt := 0; attempt < defaultUpdateIfAttempts; attempt++ {
+		res, err := g.getContact(ctx, &GetContactRequest{ID: id})
+		if err != nil {
+			return nil, err
+		}
+		current := res.Contact
+		snapshot, err := json.Marshal(current)
+		if err != nil {
+			return nil, err
+		}
+
+		desired := Contact{}
+		err = json.Unmarshal(snapshot, &desired)
+		if err != nil {
+			return nil, err
+		}
+		err = mutate(&desired)
+		if err != nil {
+			return nil, err
+		}
+
+		fields := []string{}
+		for _, f := range ChangedContactFields(current, desired) {
+			if f != "customFieldValues" {
+				fields = append(fields, f)
+			}
+		}
+		customFields := changedCustomFields(current.CustomFieldValues, desired.CustomFieldValues)
+		if len(fields) == 0 && len(customFields) == 0 {
+			return &current, nil
+		}
+
+		// someone else changed the contact since we read it, start over from their version
+		res, err = g.getContact(ctx, &GetContactRequest{ID: id})
+		if err != nil {
+			return nil, err
+		}
+		check, err := json.Marshal(res.Contact)
+		if err != nil {
+			return nil, err
+		}
+		if string(check) != string(snapshot) {
+			continue
+		}
+
+		updated := &desired
+		if len(fields) > 0 {
+			uRes, err := g.updateContact(ctx, &UpdateContactRequest{ID: id, NewData: desired, Fields: fields})
+			if isConflict(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			updated = &uRes.Contact
+		}
+		if len(customFields) > 0 {
+			cRes, err := g.updateContactCustomFields(ctx, &UpdateContactCustomFieldsRequest{ID: id, CustomFields: customFields})
+			if isConflict(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			updated = &cRes.Contact
+		}
+		return updated, nil
+	}
+
+	return nil, ErrUpdateConflict
+}
+
+// changedCustomFields returns the custom fields of after whose values differ from before, leaving the others alone
+// so concurrent updates of other custom fields are kept
+func changedCustomFields(before, after []CustomField) []CustomField {
+	current := map[string][]string{}
+	for _, f := range before {
+		current[f.CustomFieldID] = f.Value
+	}
+
+	var ret []CustomField
+	for _, f := range after {
+		values, ok := current[f.CustomFieldID]
+		if !ok || !sameValues(values, f.Value) {
+			ret = append(ret, CustomField{CustomFieldID: f.CustomFieldID, Value: f.Value})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CustomFieldID < ret[j].CustomFieldID
+	})
+	return ret
+}
+
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isConflict(err error) bool {
+	apiErr := &APIError{}
+	return errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusConflict
+}
@@ -0,0 +1,110 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestUnit_UpdateContactIf(t *testing.T) {
+
+	type testcase struct {
+		name          string
+		versions      []string // contact returned by successive reads, the last one repeats
+		expectedErr   error
+		expectedReads int
+		expectedCalls []string
+	}
+
+	v1 := `{"contactId":"c1","note":"old","changedOn":"1","customFieldValues":[{"customFieldId":"a","value":["1"]},{"customFieldId":"b","value":["2"]}]}`
+	v2 := `{"contactId":"c1","note":"old","changedOn":"2","customFieldValues":[{"customFieldId":"a","value":["1"]},{"customFieldId":"b","value":["3"]}]}`
+
+	testcases := []testcase{
+		{
+			name:          "sends only the changes",
+			versions:      []string{v1},
+			expectedReads: 2,
+			expectedCalls: []string{
+				`POST /v3/contacts/c1 {"note":"new"}`,
+				`POST /v3/contacts/c1/custom-fields {"customFieldValues":[{"customFieldId":"a","value":["9"]}]}`,
+			},
+		},
+		{
+			name:          "starts over when the contact changed",
+			versions:      []string{v1, v2},
+			expectedReads: 4,
+			expectedCalls: []string{
+				`POST /v3/contacts/c1 {"note":"new"}`,
+				`POST /v3/contacts/c1/custom-fields {"customFieldValues":[{"customFieldId":"a","value":["9"]}]}`,
+			},
+		},
+		{
+			name:          "gives up when the contact keeps changing",
+			versions:      []string{v1, v2, v1, v2, v1, v2, v1, v2, v1, v2},
+			expectedErr:   ErrUpdateConflict,
+			expectedReads: 10,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			reads := 0
+			var calls []string
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					v := tc.versions[len(tc.versions)-1]
+					if reads < len(tc.versions) {
+						v = tc.versions[reads]
+					}
+					reads++
+					fmt.Fprint(w, v)
+					return
+				}
+				body, _ := ioutil.ReadAll(r.Body)
+				calls = append(calls, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, body))
+				fmt.Fprint(w, `{"contactId":"c1"}`)
+			}))
+			defer ts.Close()
+
+			_, err := c.UpdateContactIf(context.Background(), "c1", func(c *Contact) error {
+				c.Note = makeStringPtr("new")
+				for i := range c.CustomFieldValues {
+					if c.CustomFieldValues[i].CustomFieldID == "a" {
+						c.CustomFieldValues[i].Value = []string{"9"}
+					}
+				}
+				return nil
+			})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, tc.expectedErr)
+			}
+			if reads != tc.expectedReads {
+				t.Fatalf("Actual reads (%d) did not match expected (%d)", reads, tc.expectedReads)
+			}
+			if fmt.Sprint(calls) != fmt.Sprint(tc.expectedCalls) {
+				t.Fatalf("Actual calls (%v) did not match expected (%v)", calls, tc.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestUnit_UpdateContactIfMutateError(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Unexpected write %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"contactId":"c1"}`)
+	}))
+	defer ts.Close()
+
+	mErr := errors.New("not allowed")
+	_, err := c.UpdateContactIf(context.Background(), "c1", func(*Contact) error {
+		return mErr
+	})
+	if err != mErr {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+}
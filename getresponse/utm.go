@@ -0,0 +1,110 @@
+package getresponse
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// UTM holds the utm_* parameters appended to links, empty ones are left out
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+func (u UTM) params() [][2]string {
+	var ret [][2]string
+	for _, p := range [][2]string{
+		{"utm_source", u.Source},
+		{"utm_medium", u.Medium},
+		{"utm_campaign", u.Campaign},
+		{"utm_term", u.Term},
+		{"utm_content", u.Content},
+	} {
+		if p[1] != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// LinkChange is a link DecorateLinks rewrote
+type LinkChange struct {
+	Before string
+	After  string
+}
+
+var (
+	hrefPattern     = regexp.MustCompile(`(?i)(href\s*=\s*)("[^"]*"|'[^']*')`)
+	plainURLPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+)
+
+// WithLinkDecoration appends the UTM parameters to the http(s) links of every newsletter created through the client.
+// audit, if not nil, receives the links that were changed.
+func WithLinkDecoration(utm UTM, audit func(ctx context.Context, changes []LinkChange)) Option {
+	return func(g *getResponseClient) {
+		g.utm = &utm
+		g.utmAudit = audit
+	}
+}
+
+// DecorateLinks appends the UTM parameters to the http(s) links in href attributes of the html part and to the URLs
+// in the plain text part. Parameters a link already has are kept, and merge tags in links are left intact.
+func DecorateLinks(content NewsletterContent, utm UTM) (NewsletterContent, []LinkChange) {
+	var changes []LinkChange
+	decorate := func(link string) string {
+		after, ok := decorateURL(link, utm)
+		if ok {
+			changes = append(changes, LinkChange{Before: link, After: after})
+		}
+		return after
+	}
+
+	content.HTML = hrefPattern.ReplaceAllStringFunc(content.HTML, func(attr string) string {
+		m := hrefPattern.FindStringSubmatch(attr)
+		quote := m[2][:1]
+		return m[1] + quote + decorate(m[2][1:len(m[2])-1]) + quote
+	})
+	content.Plain = plainURLPattern.ReplaceAllStringFunc(content.Plain, decorate)
+	return content, changes
+}
+
+// decorateURL adds the missing parameters without re-encoding the rest of the URL, which could break merge tags
+func decorateURL(link string, utm UTM) (string, bool) {
+	lower := strings.ToLower(link)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return link, false
+	}
+
+	base, fragment := link, ""
+	if i := strings.Index(link, "#"); i >= 0 {
+		base, fragment = link[:i], link[i:]
+	}
+	existing := url.Values{}
+	if i := strings.Index(base, "?"); i >= 0 {
+		existing, _ = url.ParseQuery(base[i+1:])
+	}
+
+	var add []string
+	for _, p := range utm.params() {
+		if _, ok := existing[p[0]]; !ok {
+			add = append(add, p[0]+"="+url.QueryEscape(p[1]))
+		}
+	}
+	if len(add) == 0 {
+		return link, false
+	}
+
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+		if strings.HasSuffix(base, "?") || strings.HasSuffix(base, "&") {
+			sep = ""
+		}
+	}
+	return base + sep + strings.Join(add, "&") + fragment, true
+}
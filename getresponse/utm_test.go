@@ -0,0 +1,68 @@
+package getresponse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnit_DecorateLinks(t *testing.T) {
+	utm := UTM{Source: "getresponse", Medium: "email", Campaign: "spring sale"}
+	content := NewsletterContent{
+		HTML: `<a href="https://example.com/shop">Shop</a> <a HREF='https://example.com/?a=1#top'>Top</a> ` +
+			`<a href="https://example.com/?utm_source=own&e=[[email]]">Own</a> <a href="mailto:help@example.com">Help</a> ` +
+			`<a href="[[unsubscribe]]">Unsubscribe</a>`,
+		Plain: "Shop at https://example.com/shop now",
+	}
+
+	decorated, changes := DecorateLinks(content, utm)
+
+	expectedHTML := `<a href="https://example.com/shop?utm_source=getresponse&utm_medium=email&utm_campaign=spring+sale">Shop</a> ` +
+		`<a HREF='https://example.com/?a=1&utm_source=getresponse&utm_medium=email&utm_campaign=spring+sale#top'>Top</a> ` +
+		`<a href="https://example.com/?utm_source=own&e=[[email]]&utm_medium=email&utm_campaign=spring+sale">Own</a> ` +
+		`<a href="mailto:help@example.com">Help</a> <a href="[[unsubscribe]]">Unsubscribe</a>`
+	if decorated.HTML != expectedHTML {
+		t.Fatalf("Actual html (%s) did not match expected (%s)", decorated.HTML, expectedHTML)
+	}
+	expectedPlain := "Shop at https://example.com/shop?utm_source=getresponse&utm_medium=email&utm_campaign=spring+sale now"
+	if decorated.Plain != expectedPlain {
+		t.Fatalf("Actual plain text (%s) did not match expected (%s)", decorated.Plain, expectedPlain)
+	}
+	if len(changes) != 4 || changes[0].Before != "https://example.com/shop" {
+		t.Fatalf("Unexpected changes (%#v)", changes)
+	}
+}
+
+func TestUnit_WithLinkDecoration(t *testing.T) {
+	sent := CreateNewsletterRequest{}
+	var audited []LinkChange
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		fmt.Fprint(w, `{"newsletterId":"n1"}`)
+	}))
+	defer ts.Close()
+	c := NewClient(ts.URL, "", "", nil, WithLinkDecoration(UTM{Source: "gr"}, func(ctx context.Context, changes []LinkChange) {
+		audited = changes
+	}))
+
+	req := &CreateNewsletterRequest{
+		Subject:      "Hello",
+		Campaign:     Campaign{CampaignID: "c1"},
+		FromField:    FromFieldRef{FromFieldID: "f1"},
+		Content:      NewsletterContent{Plain: "See https://example.com"},
+		SendSettings: SendSettings{SelectedCampaigns: []string{"c1"}},
+	}
+	_, err := c.CreateNewsletter(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if sent.Content.Plain != "See https://example.com?utm_source=gr" || len(audited) != 1 {
+		t.Fatalf("Unexpected result (%s, %#v)", sent.Content.Plain, audited)
+	}
+	if req.Content.Plain != "See https://example.com" {
+		t.Fatalf("The request must not be modified (%s)", req.Content.Plain)
+	}
+}
@@ -0,0 +1,60 @@
+package vcr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+func TestUnit_RecordReplay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contactId":"foo","name":"foobar","email":"foo@bar.baz"}`)
+	}))
+	path := filepath.Join(t.TempDir(), "contact.json")
+
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	c := getresponse.NewClient(ts.URL, "secret-api-key", "", &http.Client{Transport: rec})
+	_, err = c.GetContact(context.Background(), &getresponse.GetContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	err = rec.Save()
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	ts.Close()
+
+	raw, _ := ioutil.ReadFile(path)
+	if strings.Contains(string(raw), "secret-api-key") {
+		t.Fatalf("API key was not scrubbed from the recording (%s)", raw)
+	}
+
+	rep, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	c = getresponse.NewClient(ts.URL, "another-key", "", &http.Client{Transport: rep})
+	ret, err := c.GetContact(context.Background(), &getresponse.GetContactRequest{ID: "foo"})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if *ret.Contact.Name != "foobar" {
+		t.Fatalf("Unexpected replayed contact (%#v)", ret.Contact)
+	}
+
+	_, err = c.GetContact(context.Background(), &getresponse.GetContactRequest{ID: "foo"})
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Fatalf("Expected interactions to be consumed once, got (%#v)", err)
+	}
+}
@@ -0,0 +1,31 @@
+package getresponse
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultAPIVersion is the API version requests are sent to unless WithAPIVersion says otherwise
+const DefaultAPIVersion = "v3"
+
+// versionSuffix matches a version segment at the end of a base URL, e.g. https://api.getresponse.com/v3/
+var versionSuffix = regexp.MustCompile(`/v[0-9]+/*$`)
+
+// WithAPIVersion sends requests to another API version, e.g. "v3". Paths are otherwise unchanged, so this is only
+// useful for versions compatible with the one the client was written for.
+func WithAPIVersion(version string) Option {
+	return func(g *getResponseClient) {
+		g.apiVersion = "/" + strings.Trim(version, "/")
+	}
+}
+
+// normalizeBaseURL strips trailing slashes and a version segment from the URL passed to NewClient, so both
+// https://api.getresponse.com and https://api.getresponse.com/v3 work
+func normalizeBaseURL(apiUrl string) string {
+	return strings.TrimRight(versionSuffix.ReplaceAllString(apiUrl, ""), "/")
+}
+
+// versionedPath swaps the version prefix the methods use for the configured one
+func (g *getResponseClient) versionedPath(path string) string {
+	return g.apiVersion + strings.TrimPrefix(path, "/"+DefaultAPIVersion)
+}
@@ -0,0 +1,62 @@
+package getresponse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnit_APIVersion(t *testing.T) {
+	var path string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprint(w, `{"contactId":"foo"}`)
+	}))
+	defer ts.Close()
+
+	type testcase struct {
+		name         string
+		apiURL       string
+		opts         []Option
+		expectedPath string
+	}
+
+	testcases := []testcase{
+		{
+			name:         "base URL",
+			apiURL:       ts.URL,
+			expectedPath: "/v3/contacts/foo",
+		},
+		{
+			name:         "URL with version",
+			apiURL:       ts.URL + "/v3/",
+			expectedPath: "/v3/contacts/foo",
+		},
+		{
+			name:         "URL with path prefix",
+			apiURL:       ts.URL + "/proxy/v3",
+			expectedPath: "/proxy/v3/contacts/foo",
+		},
+		{
+			name:         "other version",
+			apiURL:       ts.URL + "/v3",
+			opts:         []Option{WithAPIVersion("/v4/")},
+			expectedPath: "/v4/contacts/foo",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewClient(tc.apiURL, "", "", nil, tc.opts...)
+			_, err := c.GetContact(context.Background(), &GetContactRequest{ID: "foo"})
+			if err != nil {
+				t.Fatalf("Unexpected error occurred (%#v)", err)
+			}
+			if path != tc.expectedPath {
+				t.Fatalf("Actual path (%s) did not match expected (%s)", path, tc.expectedPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWaitTimeout     = 30 * time.Second
+	defaultWaitInterval    = 500 * time.Millisecond
+	defaultWaitMaxInterval = 5 * time.Second
+	defaultWaitMultiplier  = 2.0
+)
+
+var (
+	ErrContactWaitTimeout         = errors.New("contact did not appear before the wait timeout")
+	ErrContactPendingConfirmation = errors.New("contact is pending double opt-in confirmation")
+)
+
+// WaitOptions controls how CreateContactAndWait polls for the new contact.
+// Zero values fall back to sensible defaults.
+type WaitOptions struct {
+	Timeout     time.Duration // total time to wait for the contact (default 30s)
+	Interval    time.Duration // delay before the first poll (default 500ms)
+	MaxInterval time.Duration // upper bound for the delay between polls (default 5s)
+	Multiplier  float64       // backoff growth factor applied after every miss (default 2)
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	ret := WaitOptions{}
+	if o != nil {
+		ret = *o
+	}
+	if ret.Timeout <= 0 {
+		ret.Timeout = defaultWaitTimeout
+	}
+	if ret.Interval <= 0 {
+		ret.Interval = defaultWaitInterval
+	}
+	if ret.MaxInterval <= 0 {
+		ret.MaxInterval = defaultWaitMaxInterval
+	}
+	if ret.Multiplier < 1 {
+		ret.Multiplier = defaultWaitMultiplier
+	}
+	return ret
+}
+
+func (g *getResponseClient) createContactAndWait(ctx context.Context, request *CreateContactRequest, opts *WaitOptions) (*Contact, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	o := opts.withDefaults()
+
+	err := g.createContact(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if g.dryRun {
+		// the contact is never created so there is nothing to wait for
+		return &Contact{
+			Name:              request.Name,
+			Email:             &request.Email,
+			DayOfCycle:        request.DayOfCycle,
+			Campaign:          &request.Campaign,
+			CustomFieldValues: request.CustomFields,
+			IPAddress:         request.IPAddress,
+		}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	query := &GetContactsRequest{
+		QueryHash:       map[string]string{"email": request.Email},
+		Page:            1,
+		PerPage:         100,
+		AdditionalFlags: ContactFlags(FlagExactMatch),
+	}
+	if request.Campaign.CampaignID != "" {
+		query.QueryHash["campaignId"] = request.Campaign.CampaignID
+	}
+
+	interval := o.Interval
+	optinChecked := false
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, waitError(ctx)
+		case <-timer.C:
+		}
+
+		res, err := g.getContacts(ctx, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, waitError(ctx)
+			}
+			return nil, err
+		}
+		for i := range res.Contacts {
+			c := res.Contacts[i]
+			if c.Email != nil && strings.EqualFold(*c.Email, request.Email) {
+				return &c, nil
+			}
+		}
+
+		// a contact added to a double opt-in campaign is not listed until it
+		// is confirmed, so there is no point polling for it
+		if !optinChecked && request.Campaign.CampaignID != "" {
+			optinChecked = true
+			double, err := g.campaignRequiresAPIConfirmation(ctx, request.Campaign.CampaignID)
+			if err == nil && double {
+				return nil, ErrContactPendingConfirmation
+			}
+		}
+
+		interval = time.Duration(float64(interval) * o.Multiplier)
+		if interval > o.MaxInterval {
+			interval = o.MaxInterval
+		}
+	}
+}
+
+func waitError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return ErrContactWaitTimeout
+	}
+	return ctx.Err()
+}
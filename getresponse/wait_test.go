@@ -0,0 +1,102 @@
+package getresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUnit_CreateContactAndWait(t *testing.T) {
+
+	type testcase struct {
+		name          string
+		missesBefore  int
+		optinAPI      string
+		timeout       time.Duration
+		expectedErr   error
+		expectedPolls int
+	}
+
+	testcases := []testcase{
+		{
+			name:          "contact appears after a few polls",
+			missesBefore:  2,
+			optinAPI:      "single",
+			timeout:       time.Second,
+			expectedPolls: 3,
+		},
+		{
+			name:          "double opt-in campaign",
+			missesBefore:  100,
+			optinAPI:      "double",
+			timeout:       time.Second,
+			expectedErr:   ErrContactPendingConfirmation,
+			expectedPolls: 1,
+		},
+		{
+			name:         "timeout",
+			missesBefore: 1000,
+			optinAPI:     "single",
+			timeout:      50 * time.Millisecond,
+			expectedErr:  ErrContactWaitTimeout,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v3/contacts":
+					w.WriteHeader(http.StatusAccepted)
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/campaigns/123":
+					fmt.Fprintf(w, `{"campaignId":"123","optinTypes":{"api":%q}}`, tc.optinAPI)
+				case r.Method == http.MethodGet && r.URL.Path == "/v3/contacts":
+					polls++
+					if r.URL.Query().Get("query[email]") != "foo@bar.baz" || r.URL.Query().Get("query[campaignId]") != "123" {
+						t.Errorf("Unexpected query (%s)", r.URL.RawQuery)
+					}
+					if polls <= tc.missesBefore {
+						fmt.Fprint(w, `[]`)
+						return
+					}
+					fmt.Fprint(w, `[{"contactId":"abc","email":"Foo@Bar.baz"}]`)
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer ts.Close()
+
+			ret, err := c.CreateContactAndWait(context.Background(), &CreateContactRequest{
+				Email:    "foo@bar.baz",
+				Campaign: Campaign{CampaignID: "123"},
+			}, &WaitOptions{Timeout: tc.timeout, Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond})
+			if err != tc.expectedErr {
+				t.Fatalf("Actual error (%#v) did not match expected (%#v)", err, tc.expectedErr)
+			}
+			if err == nil && *ret.ContactID != "abc" {
+				t.Fatalf("Unexpected contact returned (%#v)", ret)
+			}
+			if tc.expectedPolls > 0 && polls != tc.expectedPolls {
+				t.Fatalf("Actual polls (%d) did not match expected (%d)", polls, tc.expectedPolls)
+			}
+		})
+	}
+}
+
+func TestUnit_CreateContactAndWait_CreateError(t *testing.T) {
+	c, ts := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"code":1008, "message":"conflict"}`)
+	}))
+	defer ts.Close()
+
+	_, err := c.CreateContactAndWait(context.Background(), &CreateContactRequest{Email: "foo@bar.baz"}, nil)
+	grErr := &GetResponseError{}
+	if !errors.As(err, &grErr) || grErr.ErrorCode != ErrResourceAlreadyExists {
+		t.Fatalf("Unexpected error (%#v)", err)
+	}
+}
@@ -0,0 +1,307 @@
+// Package watch captures contact changes by polling, for accounts where callbacks are not available. A Watcher lists
+// the contacts changed since its checkpoint and hands them to a function as created or updated events:
+//
+//	cp, err := watch.OpenFileCheckpoints("contacts.checkpoint")
+//	...
+//	w := &watch.Watcher{Client: client, Checkpoints: cp, Handle: func(ctx context.Context, e watch.Event) error {
+//		return mirror.Upsert(ctx, e.Contact)
+//	}}
+//	err = w.Run(ctx)
+//
+// Delivery is at least once: the checkpoint only moves past an event once Handle accepted it.
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+const (
+	listPerPage     = 100
+	defaultInterval = time.Minute
+	// timeLayout is how GetResponse formats createdOn and changedOn
+	timeLayout = "2006-01-02T15:04:05-0700"
+)
+
+// EventType tells whether a contact is new or changed
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+)
+
+// Event is a contact created or changed since the previous poll
+type Event struct {
+	Type      EventType
+	ChangedOn time.Time
+	Contact   getresponse.Contact
+}
+
+// Checkpoint is how far a Watcher got. The API filters changes by day only, so the contacts already handled at
+// ChangedOn are remembered to skip them when the same day is listed again.
+type Checkpoint struct {
+	ChangedOn  time.Time `json:"changedOn"`
+	ContactIDs []string  `json:"contactIds,omitempty"`
+}
+
+func (c *Checkpoint) seen(id string, t time.Time) bool {
+	if t.Before(c.ChangedOn) {
+		return true
+	}
+	if !t.Equal(c.ChangedOn) {
+		return false
+	}
+	for _, seen := range c.ContactIDs {
+		if seen == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Checkpoint) advance(id string, t time.Time) {
+	if t.Before(c.ChangedOn) {
+		return
+	}
+	if t.After(c.ChangedOn) {
+		c.ChangedOn = t
+		c.ContactIDs = nil
+	}
+	c.ContactIDs = append(c.ContactIDs, id)
+}
+
+// CheckpointStore persists the checkpoint of a Watcher
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, the zero Checkpoint when there is none
+	Load() (Checkpoint, error)
+	Save(c Checkpoint) error
+}
+
+// MemoryCheckpoints keeps the checkpoint in memory, so a restarted Watcher starts over
+type MemoryCheckpoints struct {
+	mu sync.Mutex
+	c  Checkpoint
+}
+
+func (m *MemoryCheckpoints) Load() (Checkpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.c, nil
+}
+
+func (m *MemoryCheckpoints) Save(c Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.c = c
+	return nil
+}
+
+// FileCheckpoints keeps the checkpoint in a JSON file, replaced atomically on every save
+type FileCheckpoints struct {
+	path string
+}
+
+// OpenFileCheckpoints returns a store for the checkpoint at path, which need not exist yet
+func OpenFileCheckpoints(path string) (*FileCheckpoints, error) {
+	_, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	return &FileCheckpoints{path: path}, nil
+}
+
+func (f *FileCheckpoints) Load() (Checkpoint, error) {
+	c := Checkpoint{}
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+func (f *FileCheckpoints) Save(c Checkpoint) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	err = ioutil.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// Watcher polls the contacts changed since its checkpoint
+type Watcher struct {
+	Client      getresponse.Client
+	Checkpoints CheckpointStore
+	Handle      func(ctx context.Context, e Event) error
+
+	// CampaignID limits the watch to one campaign, all campaigns when empty
+	CampaignID string
+	// Interval is the time between polls, one minute when not set
+	Interval time.Duration
+	// Since is where a Watcher without a saved checkpoint starts, the time of its first poll when not set
+	Since time.Time
+	// Location is the time zone the API counts changedOn days in, read with getresponse.AccountLocation when nil
+	Location *time.Location
+	// OnError is called with the errors of polls run by Run, which keeps polling. Nil ignores them.
+	OnError func(err error)
+}
+
+// Run polls until the context is done
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	for {
+		_, err := w.Poll(ctx)
+		if err != nil && ctx.Err() == nil && w.OnError != nil {
+			w.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Poll lists the changes since the checkpoint once, handing them to Handle oldest first, and returns how many were
+// handled. It stops at the first error, saving the checkpoint of the events handled so far.
+func (w *Watcher) Poll(ctx context.Context) (int, error) {
+	if w.Client == nil || w.Checkpoints == nil || w.Handle == nil {
+		return 0, fmt.Errorf("watch: Client, Checkpoints and Handle are required")
+	}
+
+	cp, err := w.Checkpoints.Load()
+	if err != nil {
+		return 0, err
+	}
+	if cp.ChangedOn.IsZero() {
+		cp.ChangedOn = w.Since
+		if cp.ChangedOn.IsZero() {
+			cp.ChangedOn = time.Now()
+		}
+		cp.ChangedOn = cp.ChangedOn.Truncate(time.Second)
+	}
+	since := cp.ChangedOn
+	start := Checkpoint{ChangedOn: cp.ChangedOn, ContactIDs: append([]string(nil), cp.ContactIDs...)}
+	// pages overlap, and contacts shifted onto a page listed already come after newer ones
+	handledAt := map[string]time.Time{}
+
+	handled := 0
+	err = w.changes(ctx, since, func(c getresponse.Contact) error {
+		changedOn, err := parseTime(c.ChangedOn)
+		if err != nil {
+			return err
+		}
+		if c.ContactID == nil || start.seen(*c.ContactID, changedOn) {
+			return nil
+		}
+		if t, ok := handledAt[*c.ContactID]; ok && !changedOn.After(t) {
+			return nil
+		}
+
+		e := Event{Type: EventUpdated, ChangedOn: changedOn, Contact: c}
+		if createdOn, err := parseTime(c.CreatedOn); err == nil && !createdOn.Before(since) {
+			e.Type = EventCreated
+		}
+		err = w.Handle(ctx, e)
+		if err != nil {
+			if changedOn.Before(cp.ChangedOn) {
+				// the checkpoint moved past the failed event, the next poll lists it again with the ones after it
+				cp = Checkpoint{ChangedOn: changedOn}
+			}
+			return err
+		}
+		handledAt[*c.ContactID] = changedOn
+		cp.advance(*c.ContactID, changedOn)
+		handled++
+		return nil
+	})
+
+	saveErr := w.Checkpoints.Save(cp)
+	if err != nil {
+		return handled, err
+	}
+	return handled, saveErr
+}
+
+// changes lists the contacts changed on or after the day of since, oldest change first. A contact changing during the
+// listing moves to its end and shifts the ones after it back a place, so every page after the first is followed by the
+// page before it listed again, whose contacts are handed to fn first: fn must skip the contacts it saw already.
+func (w *Watcher) changes(ctx context.Context, since time.Time, fn func(c getresponse.Contact) error) error {
+	loc := w.Location
+	if loc == nil {
+		var err error
+		loc, err = getresponse.AccountLocation(ctx, w.Client)
+		if err != nil {
+			return err
+		}
+	}
+	query := getresponse.TimeRange{From: since, Location: loc}.SetHash(nil, "changedOn")
+	if w.CampaignID != "" {
+		query["campaignId"] = w.CampaignID
+	}
+	req := &getresponse.GetContactsRequest{
+		QueryHash: query,
+		SortHash:  map[string]string{"changedOn": "ASC"},
+		Page:      1,
+		PerPage:   listPerPage,
+	}
+
+	for page := int32(1); ; page++ {
+		req.Page = page
+		res, err := w.Client.Contacts().List(ctx, req)
+		if err != nil {
+			return err
+		}
+		contacts := res.Contacts
+		if page > 1 {
+			req.Page = page - 1
+			previous, err := w.Client.Contacts().List(ctx, req)
+			if err != nil {
+				return err
+			}
+			contacts = append(previous.Contacts, contacts...)
+		}
+		for _, c := range contacts {
+			err = fn(c)
+			if err != nil {
+				return err
+			}
+		}
+		if len(res.Contacts) < listPerPage {
+			return nil
+		}
+	}
+}
+
+func parseTime(s *string) (time.Time, error) {
+	if s == nil {
+		return time.Time{}, fmt.Errorf("watch: contact has no change time")
+	}
+	t, err := time.Parse(timeLayout, *s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, *s)
+	}
+	return t, err
+}
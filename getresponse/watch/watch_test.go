@@ -0,0 +1,180 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devimteam/go-getresponse/getresponse"
+)
+
+type contact struct {
+	ContactID string `json:"contactId"`
+	Email     string `json:"email"`
+	CreatedOn string `json:"createdOn"`
+	ChangedOn string `json:"changedOn"`
+}
+
+func TestUnit_WatcherPoll(t *testing.T) {
+	var mu sync.Mutex
+	contacts := []contact{
+		{"old", "old@example.com", "2020-01-01T08:00:00+0000", "2020-01-02T09:00:00+0000"},
+		{"a", "a@example.com", "2020-01-01T08:00:00+0000", "2020-01-02T11:00:00+0000"},
+		{"b", "b@example.com", "2020-01-02T11:00:00+0000", "2020-01-02T11:00:00+0000"},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("query[changedOn][from]") != "2020-01-02" || q.Get("sort[changedOn]") != "ASC" || q.Get("query[campaignId]") != "c" {
+			t.Errorf("Unexpected query (%v)", q)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		json.NewEncoder(w).Encode(contacts)
+	}))
+	defer ts.Close()
+	client := getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling())
+
+	cp, err := OpenFileCheckpoints(filepath.Join(t.TempDir(), "checkpoint.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	var events []string
+	failOn := "b"
+	w := &Watcher{
+		Client:      client,
+		Checkpoints: cp,
+		CampaignID:  "c",
+		Since:       time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC),
+		Location:    time.UTC,
+		Handle: func(ctx context.Context, e Event) error {
+			if *e.Contact.ContactID == failOn {
+				return errors.New("mirror unavailable")
+			}
+			events = append(events, string(e.Type)+" "+*e.Contact.ContactID)
+			return nil
+		},
+	}
+
+	type testcase struct {
+		fails    bool
+		handled  int
+		expected []string
+	}
+	for i, tc := range []testcase{
+		{fails: true, handled: 1, expected: []string{"updated a"}},
+		{handled: 1, expected: []string{"updated a", "created b"}},
+		{handled: 0, expected: []string{"updated a", "created b"}},
+	} {
+		if i == 1 {
+			failOn = ""
+		}
+		handled, err := w.Poll(context.Background())
+		if (err != nil) != tc.fails || handled != tc.handled {
+			t.Fatalf("Unexpected poll %d result (%d, %#v)", i, handled, err)
+		}
+		if len(events) != len(tc.expected) || events[len(events)-1] != tc.expected[len(tc.expected)-1] {
+			t.Fatalf("Actual events (%v) did not match expected (%v)", events, tc.expected)
+		}
+	}
+
+	mu.Lock()
+	contacts = append(contacts, contact{"a", "a@example.com", "2020-01-01T08:00:00+0000", "2020-01-02T12:00:00+0000"})
+	mu.Unlock()
+	handled, err := w.Poll(context.Background())
+	if err != nil || handled != 1 || events[len(events)-1] != "updated a" {
+		t.Fatalf("Unexpected result (%d, %#v, %v)", handled, err, events)
+	}
+
+	saved, err := cp.Load()
+	if err != nil || !saved.ChangedOn.Equal(time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)) || len(saved.ContactIDs) != 1 {
+		t.Fatalf("Unexpected checkpoint (%#v, %#v)", saved, err)
+	}
+}
+
+func TestUnit_WatcherPollShiftedPages(t *testing.T) {
+	var mu sync.Mutex
+	var contacts []contact
+	base := time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 150; i++ {
+		changedOn := base.Add(time.Duration(i) * time.Second).Format(timeLayout)
+		contacts = append(contacts, contact{fmt.Sprint("c", i), "", "2020-01-01T08:00:00+0000", changedOn})
+	}
+	shifted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 2 && !shifted {
+			// c0 changes while page 1 is handled, which moves c100 onto page 1
+			shifted = true
+			c0 := contacts[0]
+			c0.ChangedOn = base.Add(time.Hour).Format(timeLayout)
+			contacts = append(contacts[1:], c0)
+		}
+		end := page * listPerPage
+		if end > len(contacts) {
+			end = len(contacts)
+		}
+		json.NewEncoder(w).Encode(contacts[(page-1)*listPerPage : end])
+	}))
+	defer ts.Close()
+
+	seen := map[string]int{}
+	w := &Watcher{
+		Client:      getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Checkpoints: &MemoryCheckpoints{},
+		Since:       base,
+		Location:    time.UTC,
+		Handle: func(ctx context.Context, e Event) error {
+			seen[*e.Contact.ContactID]++
+			return nil
+		},
+	}
+	handled, err := w.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error occurred (%#v)", err)
+	}
+	if handled != 151 || len(seen) != 150 || seen["c100"] != 1 || seen["c0"] != 2 {
+		t.Fatalf("Actual events (%d, c100 %d times, c0 %d times) did not match expected (151, 1, 2)", handled, seen["c100"], seen["c0"])
+	}
+}
+
+func TestUnit_WatcherPollAccountTimeZone(t *testing.T) {
+	var froms []string
+	accounts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/accounts" {
+			accounts++
+			fmt.Fprint(w, `{"accountId":"a","timeZone":{"name":"America/New_York","offset":"-05:00"}}`)
+			return
+		}
+		froms = append(froms, r.URL.Query().Get("query[changedOn][from]"))
+		fmt.Fprint(w, `[]`)
+	}))
+	defer ts.Close()
+
+	w := &Watcher{
+		Client:      getresponse.NewClient(ts.URL, "", "", nil, getresponse.WithoutThrottling()),
+		Checkpoints: &MemoryCheckpoints{},
+		// still January 1st in New York
+		Since:  time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC),
+		Handle: func(ctx context.Context, e Event) error { return nil },
+	}
+	for i := 0; i < 2; i++ {
+		_, err := w.Poll(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error occurred (%#v)", err)
+		}
+	}
+	if fmt.Sprint(froms) != "[2020-01-01 2020-01-01]" || accounts != 1 {
+		t.Fatalf("Actual filters (%v, %d account reads) did not match expected ([2020-01-01 2020-01-01], 1)", froms, accounts)
+	}
+}
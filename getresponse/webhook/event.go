@@ -0,0 +1,109 @@
+// Package webhook receives GetResponse callbacks and fans the events out to subscribers.
+//
+// Callbacks are configured in the GetResponse account with the URL the Handler is mounted on. GetResponse sends
+// them as query (or form) parameters, one request per event:
+//
+//	h := &webhook.Handler{Secret: os.Getenv("GETRESPONSE_CALLBACK_SECRET")}
+//	http.Handle("/getresponse/callbacks", h) // configured as https://example.com/getresponse/callbacks?secret=...
+//	events, stop := h.Subscribe(100, false)
+//	defer stop()
+//	for e := range events {
+//		...
+//	}
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Action is the kind of callback event
+type Action string
+
+const (
+	ActionSubscribe   Action = "subscribe"
+	ActionUnsubscribe Action = "unsubscribe"
+	ActionOpen        Action = "open"
+	ActionClick       Action = "click"
+	ActionGoal        Action = "goal"
+	ActionSurvey      Action = "survey"
+)
+
+var knownActions = map[Action]bool{
+	ActionSubscribe:   true,
+	ActionUnsubscribe: true,
+	ActionOpen:        true,
+	ActionClick:       true,
+	ActionGoal:        true,
+	ActionSurvey:      true,
+}
+
+// Event is a callback received from GetResponse. Fields the action does not carry are empty.
+type Event struct {
+	// Seq numbers the events received by a Handler, starting at 1
+	Seq      uint64
+	Received time.Time
+
+	Action         Action
+	AccountLogin   string
+	CampaignID     string
+	CampaignName   string
+	ContactID      string
+	ContactEmail   string
+	ContactName    string
+	ContactIP      string
+	MessageID      string
+	MessageName    string
+	MessageSubject string
+	LinkURL        string
+	GoalURL        string
+}
+
+// ParseError is returned for requests that are not valid callbacks
+type ParseError struct {
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return "webhook: " + e.Message
+}
+
+// ParseEvent reads a callback from the request's query and form parameters. Parameter names are matched case
+// insensitively, since GetResponse capitalizes some of them.
+func ParseEvent(r *http.Request) (*Event, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, &ParseError{Message: err.Error()}
+	}
+	params := map[string]string{}
+	for k, v := range r.Form {
+		if len(v) > 0 {
+			params[strings.ToLower(k)] = v[0]
+		}
+	}
+
+	e := &Event{
+		Action:         Action(strings.ToLower(params["action"])),
+		AccountLogin:   params["account_login"],
+		CampaignID:     params["campaign_id"],
+		CampaignName:   params["campaign_name"],
+		ContactID:      params["contact_id"],
+		ContactEmail:   params["contact_email"],
+		ContactName:    params["contact_name"],
+		ContactIP:      params["contact_ip"],
+		MessageID:      params["message_id"],
+		MessageName:    params["message_name"],
+		MessageSubject: params["message_subject"],
+		LinkURL:        params["link_url"],
+		GoalURL:        params["goal_url"],
+	}
+	if !knownActions[e.Action] {
+		return nil, &ParseError{Message: fmt.Sprintf("unknown action %q", params["action"])}
+	}
+	if e.ContactEmail == "" {
+		return nil, &ParseError{Message: "contact_email is missing"}
+	}
+	return e, nil
+}
@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHistory         = 100
+	defaultDeliveryTimeout = 5 * time.Second
+)
+
+// Handler is an http.Handler receiving callbacks and delivering them to its subscribers.
+//
+// Delivery is at least once: the callback is only answered with 200 once every subscriber took the event. If a
+// subscriber channel stays full for DeliveryTimeout or a subscriber function fails, the request fails with 503 and
+// GetResponse sends the callback again, so subscribers may see an event twice. The zero Handler is ready to use.
+type Handler struct {
+	// Secret, when set, must be passed as the "secret" parameter of the callback URL
+	Secret string
+	// History is the number of recent events kept for replay, 100 when not set
+	History int
+	// DeliveryTimeout is how long an event may wait for a full subscriber channel, 5s when not set
+	DeliveryTimeout time.Duration
+
+	mu      sync.Mutex
+	subs    map[*subscription]bool
+	history []Event
+	seq     uint64
+}
+
+type subscription struct {
+	ch chan Event
+	fn func(ctx context.Context, e Event) error
+
+	mu     sync.Mutex // held while sending, so the channel is not closed under a sender
+	done   chan struct{}
+	closed bool
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Secret != "" && subtle.ConstantTimeCompare([]byte(r.FormValue("secret")), []byte(h.Secret)) != 1 {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	e, err := ParseEvent(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.Publish(r.Context(), e) {
+		http.Error(w, "event not delivered", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Publish numbers the event, delivers it to every subscriber and records it for replay. It reports whether every
+// subscriber took it; ServeHTTP uses it for callbacks, and tests or other sources can call it directly.
+func (h *Handler) Publish(ctx context.Context, e *Event) bool {
+	h.mu.Lock()
+	h.seq++
+	e.Seq = h.seq
+	if e.Received.IsZero() {
+		e.Received = time.Now()
+	}
+	subs := make([]*subscription, 0, len(h.subs))
+	for s := range h.subs {
+		subs = append(subs, s)
+	}
+	h.mu.Unlock()
+
+	timeout := h.DeliveryTimeout
+	if timeout <= 0 {
+		timeout = defaultDeliveryTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delivered := true
+	for _, s := range subs {
+		if !s.deliver(ctx, *e) {
+			delivered = false
+		}
+	}
+	if delivered {
+		h.record(*e)
+	}
+	return delivered
+}
+
+func (s *subscription) deliver(ctx context.Context, e Event) bool {
+	if s.fn != nil {
+		return s.fn(ctx, e) == nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		// unsubscribed meanwhile, nothing to deliver to
+		return true
+	}
+	select {
+	case s.ch <- e:
+		return true
+	case <-s.done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (h *Handler) record(e Event) {
+	size := h.History
+	if size <= 0 {
+		size = defaultHistory
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.history = append(h.history, e)
+	if len(h.history) > size {
+		h.history = append([]Event(nil), h.history[len(h.history)-size:]...)
+	}
+}
+
+// Recent returns the last delivered events, oldest first
+func (h *Handler) Recent() []Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Event(nil), h.history...)
+}
+
+// Subscribe returns a channel receiving the events, buffering up to buffer of them, and a function ending the
+// subscription and closing the channel. With replay, the recent events are sent first.
+func (h *Handler) Subscribe(buffer int, replay bool) (<-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var past []Event
+	if replay {
+		past = h.history
+	}
+	if buffer < len(past) {
+		buffer = len(past)
+	}
+	s := &subscription{ch: make(chan Event, buffer), done: make(chan struct{})}
+	for _, e := range past {
+		s.ch <- e
+	}
+	h.add(s)
+
+	return s.ch, func() {
+		h.remove(s)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.closed {
+			s.closed = true
+			close(s.ch)
+		}
+	}
+}
+
+// SubscribeFunc calls fn for every event, the callback failing when fn returns an error. fn may be called
+// concurrently. The returned function ends the subscription.
+func (h *Handler) SubscribeFunc(fn func(ctx context.Context, e Event) error) func() {
+	s := &subscription{fn: fn, done: make(chan struct{})}
+	h.mu.Lock()
+	h.add(s)
+	h.mu.Unlock()
+	return func() {
+		h.remove(s)
+	}
+}
+
+func (h *Handler) add(s *subscription) {
+	if h.subs == nil {
+		h.subs = map[*subscription]bool{}
+	}
+	h.subs[s] = true
+}
+
+func (h *Handler) remove(s *subscription) {
+	h.mu.Lock()
+	if h.subs[s] {
+		delete(h.subs, s)
+		close(s.done)
+	}
+	h.mu.Unlock()
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func callback(t *testing.T, h http.Handler, query string) int {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/callbacks?"+query, nil))
+	return w.Code
+}
+
+func TestUnit_HandlerFanOut(t *testing.T) {
+	h := &Handler{Secret: "s3cret", History: 2}
+	events, stop := h.Subscribe(10, false)
+	defer stop()
+
+	type testcase struct {
+		query  string
+		status int
+	}
+	for _, tc := range []testcase{
+		{"secret=s3cret&action=subscribe&contact_email=a@example.com&CAMPAIGN_ID=c1", http.StatusOK},
+		{"secret=wrong&action=subscribe&contact_email=a@example.com", http.StatusForbidden},
+		{"secret=s3cret&action=dance&contact_email=a@example.com", http.StatusBadRequest},
+		{"secret=s3cret&action=open&contact_email=b@example.com", http.StatusOK},
+		{"secret=s3cret&action=click&contact_email=c@example.com&link_url=https://example.com", http.StatusOK},
+	} {
+		status := callback(t, h, tc.query)
+		if status != tc.status {
+			t.Fatalf("Actual status (%v) did not match expected (%v) for %s", status, tc.status, tc.query)
+		}
+	}
+
+	e := <-events
+	if e.Seq != 1 || e.Action != ActionSubscribe || e.CampaignID != "c1" {
+		t.Fatalf("Unexpected first event (%#v)", e)
+	}
+
+	recent := h.Recent()
+	if len(recent) != 2 || recent[0].ContactEmail != "b@example.com" || recent[1].LinkURL != "https://example.com" {
+		t.Fatalf("Unexpected recent events (%#v)", recent)
+	}
+
+	replayed, stopReplay := h.Subscribe(0, true)
+	defer stopReplay()
+	if e := <-replayed; e.ContactEmail != "b@example.com" {
+		t.Fatalf("Actual replayed event (%v) did not match expected (%v)", e.ContactEmail, "b@example.com")
+	}
+}
+
+func TestUnit_HandlerFailsUndelivered(t *testing.T) {
+	h := &Handler{DeliveryTimeout: 10 * time.Millisecond}
+	events, stop := h.Subscribe(0, false)
+
+	if status := callback(t, h, "action=goal&contact_email=a@example.com"); status != http.StatusServiceUnavailable {
+		t.Fatalf("Actual status (%v) did not match expected (%v)", status, http.StatusServiceUnavailable)
+	}
+	if len(h.Recent()) != 0 {
+		t.Fatalf("Undelivered event was recorded (%#v)", h.Recent())
+	}
+
+	stop()
+	if _, ok := <-events; ok {
+		t.Fatalf("Expected the channel to be closed")
+	}
+
+	failing := true
+	h.SubscribeFunc(func(ctx context.Context, e Event) error {
+		if failing {
+			return errors.New("not now")
+		}
+		return nil
+	})
+	if status := callback(t, h, "action=goal&contact_email=a@example.com"); status != http.StatusServiceUnavailable {
+		t.Fatalf("Actual status (%v) did not match expected (%v)", status, http.StatusServiceUnavailable)
+	}
+	failing = false
+	if status := callback(t, h, "action=goal&contact_email=a@example.com"); status != http.StatusOK {
+		t.Fatalf("Actual status (%v) did not match expected (%v)", status, http.StatusOK)
+	}
+}